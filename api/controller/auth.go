@@ -158,7 +158,7 @@ func (rc *AuthHandlers) ForgotPassword(c echo.Context) error {
 		return handleEchoError(c, err)
 	}
 
-	if err := rc.emailUC.SendPasswordResetEmail(user.Email, user.Username, resetToken); err != nil {
+	if err := rc.emailUC.SendPasswordResetEmail(c.Request().Context(), user, resetToken); err != nil {
 		return handleEchoError(c, err)
 	}
 
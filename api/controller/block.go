@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type BlockHandlers struct {
+	blockUC *uc.BlockUC
+}
+
+func NewBlockHandlers(blockUC *uc.BlockUC) *BlockHandlers {
+	return &BlockHandlers{
+		blockUC: blockUC,
+	}
+}
+
+// Block godoc
+//
+//	@Summary		Block a user
+//	@Description	This endpoint blocks a user. Any connection between the users is removed, the blocked user cannot send connection requests and both users are hidden from each other in searches, feeds and event listings.
+//	@Tags			blocks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.BlockCreateInput	true	"Block input"
+//	@Success		201		{object}	SuccessListResponse		"User blocked successfully"
+//	@Failure		400		{object}	FailureResponse			"Invalid request data"
+//	@Failure		500		{object}	FailureResponse			"Block failed"
+//	@Router			/blocks [post]
+func (rc *BlockHandlers) Block(c echo.Context) error {
+	var input model.BlockCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	block, err := rc.blockUC.Block(c.Request().Context(), &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: block,
+	})
+}
+
+// Unblock godoc
+//
+//	@Summary		Unblock a user
+//	@Description	This endpoint removes a block of the owner. The removed connection is not restored.
+//	@Tags			blocks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			user_id	path		string			true	"Blocked user ID"
+//	@Success		200		{object}	SuccessResponse	"User unblocked successfully"
+//	@Failure		400		{object}	FailureResponse	"User is not blocked"
+//	@Failure		500		{object}	FailureResponse	"Unblock failed"
+//	@Router			/blocks/{user_id} [delete]
+func (rc *BlockHandlers) Unblock(c echo.Context) error {
+	userID := c.Param("user_id")
+
+	if err := rc.blockUC.Unblock(c.Request().Context(), userID); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "User unblocked successfully",
+	})
+}
+
+// ListBlocked godoc
+//
+//	@Summary		List blocked users
+//	@Description	Returns the users blocked by the owner, newest first.
+//	@Tags			blocks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			limit	query		string				false	"Limit the number of users returned"
+//	@Param			skip	query		string				false	"Number of users to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Blocked users retrieved successfully"
+//	@Failure		500		{object}	FailureResponse		"Blocked users retrieval failed"
+//	@Router			/blocks [get]
+func (rc *BlockHandlers) ListBlocked(c echo.Context) error {
+	list, err := rc.blockUC.ListBlocked(c.Request().Context(), getPagination(c))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Blocks,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
+// Mute godoc
+//
+//	@Summary		Mute a user
+//	@Description	This endpoint hides the events of a user from the feed of the owner. The muted user is not told and the connection stays as it is.
+//	@Tags			blocks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.MuteCreateInput	true	"Mute input"
+//	@Success		201		{object}	SuccessListResponse		"User muted successfully"
+//	@Failure		400		{object}	FailureResponse			"Invalid request data"
+//	@Failure		500		{object}	FailureResponse			"Mute failed"
+//	@Router			/mutes [post]
+func (rc *BlockHandlers) Mute(c echo.Context) error {
+	var input model.MuteCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	mute, err := rc.blockUC.Mute(c.Request().Context(), &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: mute,
+	})
+}
+
+// Unmute godoc
+//
+//	@Summary		Unmute a user
+//	@Description	This endpoint shows the events of a muted user in the feed of the owner again.
+//	@Tags			blocks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			user_id	path		string			true	"Muted user ID"
+//	@Success		200		{object}	SuccessResponse	"User unmuted successfully"
+//	@Failure		400		{object}	FailureResponse	"User is not muted"
+//	@Failure		500		{object}	FailureResponse	"Unmute failed"
+//	@Router			/mutes/{user_id} [delete]
+func (rc *BlockHandlers) Unmute(c echo.Context) error {
+	userID := c.Param("user_id")
+
+	if err := rc.blockUC.Unmute(c.Request().Context(), userID); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "User unmuted successfully",
+	})
+}
+
+// ListMuted godoc
+//
+//	@Summary		List muted users
+//	@Description	Returns the users muted by the owner, newest first.
+//	@Tags			blocks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			limit	query		string				false	"Limit the number of users returned"
+//	@Param			skip	query		string				false	"Number of users to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Muted users retrieved successfully"
+//	@Failure		500		{object}	FailureResponse		"Muted users retrieval failed"
+//	@Router			/mutes [get]
+func (rc *BlockHandlers) ListMuted(c echo.Context) error {
+	list, err := rc.blockUC.ListMuted(c.Request().Context(), getPagination(c))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Mutes,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
@@ -0,0 +1,290 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CoOwnerHandlers struct {
+	coOwnerUC *uc.CoOwnerUC
+}
+
+func NewCoOwnerHandlers(coOwnerUC *uc.CoOwnerUC) *CoOwnerHandlers {
+	return &CoOwnerHandlers{
+		coOwnerUC: coOwnerUC,
+	}
+}
+
+// InviteToEvent godoc
+//
+//	@Summary		Invite a co-owner to an event
+//	@Description	This endpoint invites one of the owners connections to co-own an event. Owners and co-owners can invite, the invited user gets notified and can edit and delete the event once approved.
+//	@Tags			co-owners
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Event ID"
+//	@Param			Body	body		model.CoOwnerCreateInput	true	"Co-owner invitation input"
+//	@Success		201		{object}	SuccessListResponse			"Co-owner invited successfully"
+//	@Failure		400		{object}	FailureResponse				"User is not a connection"
+//	@Failure		403		{object}	FailureResponse				"Only owners can invite"
+//	@Failure		409		{object}	FailureResponse				"User is already invited"
+//	@Failure		500		{object}	FailureResponse				"Invitation failed"
+//	@Router			/events/{id}/owners [post]
+func (rc *CoOwnerHandlers) InviteToEvent(c echo.Context) error {
+	eventID := c.Param("id")
+	var input model.CoOwnerCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	coOwner, err := rc.coOwnerUC.InviteToEvent(c.Request().Context(), eventID, &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: coOwner,
+	})
+}
+
+// InviteToEra godoc
+//
+//	@Summary		Invite a co-owner to an era
+//	@Description	This endpoint invites one of the owners connections to co-own an era. Owners and co-owners can invite, the invited user gets notified and can edit and delete the era once approved.
+//	@Tags			co-owners
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Era ID"
+//	@Param			Body	body		model.CoOwnerCreateInput	true	"Co-owner invitation input"
+//	@Success		201		{object}	SuccessListResponse			"Co-owner invited successfully"
+//	@Failure		400		{object}	FailureResponse				"User is not a connection"
+//	@Failure		403		{object}	FailureResponse				"Only owners can invite"
+//	@Failure		409		{object}	FailureResponse				"User is already invited"
+//	@Failure		500		{object}	FailureResponse				"Invitation failed"
+//	@Router			/eras/{id}/owners [post]
+func (rc *CoOwnerHandlers) InviteToEra(c echo.Context) error {
+	eraID := c.Param("id")
+	var input model.CoOwnerCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	coOwner, err := rc.coOwnerUC.InviteToEra(c.Request().Context(), eraID, &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: coOwner,
+	})
+}
+
+// Update godoc
+//
+//	@Summary		Answer a co-owner invitation
+//	@Description	This endpoint approves (101) or rejects (102) an invitation of the owner. Rejected invitations are removed.
+//	@Tags			co-owners
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Invitation ID"
+//	@Param			Body	body		model.CoOwnerUpdateInput	true	"Invitation answer"
+//	@Success		200		{object}	SuccessResponse				"Invitation answered successfully"
+//	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		403		{object}	FailureResponse				"Invitation belongs to another user"
+//	@Failure		500		{object}	FailureResponse				"Invitation update failed"
+//	@Router			/co-owners/{id} [patch]
+func (rc *CoOwnerHandlers) Update(c echo.Context) error {
+	id := c.Param("id")
+	var input model.CoOwnerUpdateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	if err := rc.coOwnerUC.Update(c.Request().Context(), id, &input); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Invitation answered successfully",
+	})
+}
+
+// RemoveFromEvent godoc
+//
+//	@Summary		Remove a co-owner from an event
+//	@Description	This endpoint removes a co-owner or invitation from an event. The owner can remove anyone, co-owners only themselves.
+//	@Tags			co-owners
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string			true	"Event ID"
+//	@Param			user_id	path		string			true	"Co-owner user ID"
+//	@Success		200		{object}	SuccessResponse	"Co-owner removed successfully"
+//	@Failure		403		{object}	FailureResponse	"Not allowed to remove the co-owner"
+//	@Failure		404		{object}	FailureResponse	"User is not a co-owner"
+//	@Failure		500		{object}	FailureResponse	"Co-owner remove failed"
+//	@Router			/events/{id}/owners/{user_id} [delete]
+func (rc *CoOwnerHandlers) RemoveFromEvent(c echo.Context) error {
+	eventID := c.Param("id")
+	userID := c.Param("user_id")
+
+	if err := rc.coOwnerUC.RemoveFromEvent(c.Request().Context(), eventID, userID); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Co-owner removed successfully",
+	})
+}
+
+// RemoveFromEra godoc
+//
+//	@Summary		Remove a co-owner from an era
+//	@Description	This endpoint removes a co-owner or invitation from an era. The owner can remove anyone, co-owners only themselves.
+//	@Tags			co-owners
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string			true	"Era ID"
+//	@Param			user_id	path		string			true	"Co-owner user ID"
+//	@Success		200		{object}	SuccessResponse	"Co-owner removed successfully"
+//	@Failure		403		{object}	FailureResponse	"Not allowed to remove the co-owner"
+//	@Failure		404		{object}	FailureResponse	"User is not a co-owner"
+//	@Failure		500		{object}	FailureResponse	"Co-owner remove failed"
+//	@Router			/eras/{id}/owners/{user_id} [delete]
+func (rc *CoOwnerHandlers) RemoveFromEra(c echo.Context) error {
+	eraID := c.Param("id")
+	userID := c.Param("user_id")
+
+	if err := rc.coOwnerUC.RemoveFromEra(c.Request().Context(), eraID, userID); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Co-owner removed successfully",
+	})
+}
+
+// ListEvent godoc
+//
+//	@Summary		List co-owners of an event
+//	@Description	Returns the co-owners of an event visible to the owner. Pending invitations are listed only to the owners of the event.
+//	@Tags			co-owners
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				false	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		string				true	"Event ID"
+//	@Param			limit			query		string				false	"Limit the number of co-owners returned"
+//	@Param			skip			query		string				false	"Number of co-owners to skip for pagination"
+//	@Success		200				{object}	SuccessListResponse	"Co-owners retrieved successfully"
+//	@Failure		403				{object}	FailureResponse		"Event is not visible"
+//	@Failure		500				{object}	FailureResponse		"Co-owner retrieval failed"
+//	@Router			/events/{id}/owners [get]
+func (rc *CoOwnerHandlers) ListEvent(c echo.Context) error {
+	eventID := c.Param("id")
+
+	opts := model.CoOwnerFindOpts{
+		PaginationOpts: getPagination(c),
+	}
+
+	list, err := rc.coOwnerUC.ListEvent(c.Request().Context(), eventID, &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.CoOwners,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
+// ListEra godoc
+//
+//	@Summary		List co-owners of an era
+//	@Description	Returns the co-owners of an era. Pending invitations are listed only to the owners of the era.
+//	@Tags			co-owners
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				false	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		string				true	"Era ID"
+//	@Param			limit			query		string				false	"Limit the number of co-owners returned"
+//	@Param			skip			query		string				false	"Number of co-owners to skip for pagination"
+//	@Success		200				{object}	SuccessListResponse	"Co-owners retrieved successfully"
+//	@Failure		500				{object}	FailureResponse		"Co-owner retrieval failed"
+//	@Router			/eras/{id}/owners [get]
+func (rc *CoOwnerHandlers) ListEra(c echo.Context) error {
+	eraID := c.Param("id")
+
+	opts := model.CoOwnerFindOpts{
+		PaginationOpts: getPagination(c),
+	}
+
+	list, err := rc.coOwnerUC.ListEra(c.Request().Context(), eraID, &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.CoOwners,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
+// ListOwn godoc
+//
+//	@Summary		List the owners co-owner invitations
+//	@Description	Returns the events and eras the owner is invited to co-own, filter by status=100 for the invitations waiting for an answer.
+//	@Tags			co-owners
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			status	query		string				false	"Filter by status"
+//	@Param			limit	query		string				false	"Limit the number of invitations returned"
+//	@Param			skip	query		string				false	"Number of invitations to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Invitations retrieved successfully"
+//	@Failure		500		{object}	FailureResponse		"Invitation retrieval failed"
+//	@Router			/co-owners [get]
+func (rc *CoOwnerHandlers) ListOwn(c echo.Context) error {
+	opts := model.CoOwnerFindOpts{
+		PaginationOpts: getPagination(c),
+		Status:         getFilter(c, "status"),
+	}
+
+	list, err := rc.coOwnerUC.ListOwn(c.Request().Context(), &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.CoOwners,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
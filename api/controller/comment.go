@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CommentHandlers struct {
+	commentUC *uc.CommentUC
+}
+
+func NewCommentHandlers(commentUC *uc.CommentUC) *CommentHandlers {
+	return &CommentHandlers{
+		commentUC: commentUC,
+	}
+}
+
+// Create godoc
+//
+//	@Summary		Comment on an event
+//	@Description	This endpoint comments on an event visible to the owner, or replies to one of its comments when parent_id is set. The event owner gets notified.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Event ID"
+//	@Param			Body	body		model.CommentCreateInput	true	"Comment creation input"
+//	@Success		201		{object}	SuccessListResponse			"Comment created successfully"
+//	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		403		{object}	FailureResponse				"Event is not visible"
+//	@Failure		500		{object}	FailureResponse				"Comment creation failed"
+//	@Router			/events/{id}/comments [post]
+func (rc *CommentHandlers) Create(c echo.Context) error {
+	eventID := c.Param("id")
+	var input model.CommentCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	comment, err := rc.commentUC.Create(c.Request().Context(), eventID, &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: comment,
+	})
+}
+
+// Update godoc
+//
+//	@Summary		Edit a comment
+//	@Description	This endpoint edits the body of one of the owners comments.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Comment ID"
+//	@Param			Body	body		model.CommentUpdateInput	true	"Comment update input"
+//	@Success		200		{object}	SuccessResponse				"Comment updated successfully"
+//	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		403		{object}	FailureResponse				"Comment belongs to another user"
+//	@Failure		500		{object}	FailureResponse				"Comment update failed"
+//	@Router			/comments/{id} [patch]
+func (rc *CommentHandlers) Update(c echo.Context) error {
+	id := c.Param("id")
+	var input model.CommentUpdateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	if _, err := rc.commentUC.Update(c.Request().Context(), id, &input); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Comment updated successfully",
+	})
+}
+
+// Delete godoc
+//
+//	@Summary		Delete a comment
+//	@Description	This endpoint deletes a comment with its replies. Authors can delete their comments and event owners any comment on their events.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string			true	"Comment ID"
+//	@Success		200	{object}	SuccessResponse	"Comment deleted successfully"
+//	@Failure		403	{object}	FailureResponse	"Not allowed to delete the comment"
+//	@Failure		500	{object}	FailureResponse	"Comment delete failed"
+//	@Router			/comments/{id} [delete]
+func (rc *CommentHandlers) Delete(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := rc.commentUC.Delete(c.Request().Context(), id); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Comment deleted successfully",
+	})
+}
+
+// List godoc
+//
+//	@Summary		List comments of an event
+//	@Description	Returns the top level comments of an event visible to the owner, or the replies of a comment when parent_id is set, oldest first.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				false	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		string				true	"Event ID"
+//	@Param			parent_id		query		string				false	"List the replies of this comment"
+//	@Param			limit			query		string				false	"Limit the number of comments returned"
+//	@Param			skip			query		string				false	"Number of comments to skip for pagination"
+//	@Success		200				{object}	SuccessListResponse	"Comments retrieved successfully"
+//	@Failure		403				{object}	FailureResponse		"Event is not visible"
+//	@Failure		500				{object}	FailureResponse		"Comment retrieval failed"
+//	@Router			/events/{id}/comments [get]
+func (rc *CommentHandlers) List(c echo.Context) error {
+	eventID := c.Param("id")
+
+	opts := model.CommentFindOpts{
+		PaginationOpts: getPagination(c),
+		ParentID:       getFilter(c, "parent_id"),
+	}
+
+	list, err := rc.commentUC.List(c.Request().Context(), eventID, &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Comments,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
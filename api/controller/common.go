@@ -69,13 +69,16 @@ func getFilter(c echo.Context, query string) model.Filter {
 	}
 
 	// example: is_ocr_checked=eq:true
-	splitted := strings.Split(paramQ, ":")
+	// only the first colon separates the operand, values like timestamps may contain more
+	splitted := strings.SplitN(paramQ, ":", 2)
 	if len(splitted) == 1 {
 		return basicQuery
 	}
 
-	operand := model.OperandEqual
+	var operand model.Operand
 	switch splitted[0] {
+	case model.OperandEqual.String():
+		operand = model.OperandEqual
 	case model.OperandNot.String():
 		operand = model.OperandNot
 	case model.OperandGreater.String():
@@ -88,6 +91,15 @@ func getFilter(c echo.Context, query string) model.Filter {
 		operand = model.OperandLessEqual
 	case model.OperandLike.String():
 		operand = model.OperandLike
+	case model.OperandIn.String():
+		operand = model.OperandIn
+	case model.OperandNotIn.String():
+		operand = model.OperandNotIn
+	case model.OperandBetween.String():
+		operand = model.OperandBetween
+	default:
+		// no known operand prefix, the colon belongs to the value
+		return basicQuery
 	}
 
 	return model.Filter{
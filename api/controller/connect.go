@@ -33,6 +33,8 @@ func NewConnectHandlers(connectUC *uc.ConnectsUC, userUC *uc.UserUC) *ConnectHan
 //	@Param			Body	body		model.ConnectCreateInput	true	"Connect creation input"
 //	@Success		201		{object}	SuccessResponse				"Connect created successfully"
 //	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		403		{object}	FailureResponse				"User is blocked"
+//	@Failure		409		{object}	FailureResponse				"Already connected or requested"
 //	@Failure		500		{object}	FailureResponse				"Connect creation failed"
 //	@Router			/connects [post]
 func (rc *ConnectHandlers) Create(c echo.Context) error {
@@ -59,12 +61,12 @@ func (rc *ConnectHandlers) Create(c echo.Context) error {
 // Update godoc
 //
 //	@Summary		Update updates an existing connection
-//	@Description	This endpoint updates a connection by binding the incoming JSON request to the ConnectUpdateInput model.
+//	@Description	This endpoint answers or cancels a pending connection request by binding the incoming JSON request to the ConnectUpdateInput model. Rejected and cancelled requests are deleted.
 //	@Tags			connects
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Param			id		path		string						true	"Connection ID to update,approved:101, rejected:102"
+//	@Param			id		path		string						true	"Connection ID to update, approved:101 and rejected:102 by the receiver, cancelled:103 by the sender"
 //	@Param			Body	body		model.ConnectUpdateInput	true	"Connect update input"
 //	@Success		200		{object}	SuccessResponse				"Connect updated successfully"
 //	@Failure		400		{object}	FailureResponse				"Invalid request data"
@@ -151,6 +153,32 @@ func (rc *ConnectHandlers) ConnectsRequests(c echo.Context) error {
 	})
 }
 
+// Suggestions godoc
+//
+//	@Summary		Suggestions lists people the owner may know
+//	@Description	Ranks users by mutual connections, events both users are tagged in or own, and imported contacts. Users already connected, with a pending request or blocked are left out.
+//	@Tags			connects
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			limit	query		string				false	"Limit the number of suggestions returned"
+//	@Param			skip	query		string				false	"Number of suggestions to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Successful response containing the list of suggestions"
+//	@Failure		500		{object}	FailureResponse		"Internal error"
+//	@Router			/connects/suggestions [get]
+func (rc *ConnectHandlers) Suggestions(c echo.Context) error {
+	list, err := rc.connectUC.Suggestions(c.Request().Context(), getPagination(c))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Suggestions,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
 func (rc *ConnectHandlers) relocateFriend(c echo.Context, list *model.ConnectList) {
 	ownerID := util.GetOwnerIDFromCtx(c.Request().Context())
 
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ContactHandlers struct {
+	contactUC *uc.ContactUC
+}
+
+func NewContactHandlers(contactUC *uc.ContactUC) *ContactHandlers {
+	return &ContactHandlers{
+		contactUC: contactUC,
+	}
+}
+
+// Import godoc
+//
+//	@Summary		Import contacts
+//	@Description	This endpoint imports up to 1000 contacts of the owner. Contacts are only used to suggest connections, importing an email again updates its name.
+//	@Tags			contacts
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.ContactImportInput	true	"Contacts to import"
+//	@Success		201		{object}	SuccessResponse				"Contacts imported successfully"
+//	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		500		{object}	FailureResponse				"Contacts import failed"
+//	@Router			/contacts [post]
+func (rc *ContactHandlers) Import(c echo.Context) error {
+	var input model.ContactImportInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	count, err := rc.contactUC.Import(c.Request().Context(), &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessResponse{
+		Message: fmt.Sprintf("%d contacts imported successfully", count),
+	})
+}
+
+// Delete godoc
+//
+//	@Summary		Delete a contact
+//	@Description	This endpoint deletes an imported contact of the owner.
+//	@Tags			contacts
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string			true	"Contact ID"
+//	@Success		200	{object}	SuccessResponse	"Contact deleted successfully"
+//	@Failure		400	{object}	FailureResponse	"Invalid request data"
+//	@Failure		500	{object}	FailureResponse	"Contact deletion failed"
+//	@Router			/contacts/{id} [delete]
+func (rc *ContactHandlers) Delete(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := rc.contactUC.Delete(c.Request().Context(), id); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Contact deleted successfully",
+	})
+}
+
+// List godoc
+//
+//	@Summary		List imported contacts
+//	@Description	Returns the imported contacts of the owner ordered by name.
+//	@Tags			contacts
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			limit	query		string				false	"Limit the number of contacts returned"
+//	@Param			skip	query		string				false	"Number of contacts to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Contacts retrieved successfully"
+//	@Failure		500		{object}	FailureResponse		"Contacts retrieval failed"
+//	@Router			/contacts [get]
+func (rc *ContactHandlers) List(c echo.Context) error {
+	list, err := rc.contactUC.List(c.Request().Context(), getPagination(c))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Contacts,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
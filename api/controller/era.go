@@ -121,6 +121,8 @@ func (rc *EraController) Delete(c echo.Context) error {
 //	@Param			Authorization	header		string				false	"Insert your access token"	default(Bearer <Add access token here>)
 //	@Param			name			query		string				false	"Filter eras by name"		example(eq:test)
 //	@Param			user_id			query		string				false	"Filter eras by user id"	example(eq:1)
+//	@Param			time_start		query		string				false	"Filter eras by start time, btw takes an inclusive from,to range"	example(gte:2020-01-01)
+//	@Param			time_end		query		string				false	"Filter eras by end time"	example(lte:2020-12-31)
 //	@Param			limit			query		string				false	"Limit the number of connects returned"
 //	@Param			skip			query		string				false	"Number of connects to skip for pagination"
 //	@Param			order			query		string				false	"Order by column (prefix with asc: or desc:)"	example(desc:created_at)
@@ -176,5 +178,7 @@ func (rc *EraController) getErasFindOpts(c echo.Context) model.EraFindOpts {
 		PaginationOpts: getPagination(c),
 		Name:           getFilter(c, "name"),
 		UserID:         getFilter(c, "user_id"),
+		TimeStart:      getFilter(c, "time_start"),
+		TimeEnd:        getFilter(c, "time_end"),
 	}
 }
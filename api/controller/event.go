@@ -54,14 +54,16 @@ func (rc *EventController) Create(c echo.Context) error {
 // Update handles the update of an existing event.
 //
 //	@Summary		Update an existing event
-//	@Description	This endpoint updates an existing event by binding the incoming JSON request to the EventUpdateInput model.
+//	@Description	This endpoint changes only the fields sent in the EventUpdateInput model, so drafts can be autosaved. Null clears a time, publish_at schedules publishing a draft.
 //	@Tags			events
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
+//	@Param			id		path		string					true	"Event ID"
 //	@Param			Body	body		model.EventUpdateInput	true	"Event update input"
 //	@Success		200		{object}	SuccessResponse			"Event updated successfully"
 //	@Failure		400		{object}	FailureResponse			"Invalid request data"
+//	@Failure		409		{object}	FailureResponse			"Event was published or opened meanwhile"
 //	@Failure		500		{object}	FailureResponse			"Event update failed"
 //	@Router			/events/{id} [patch]
 func (rc *EventController) Update(c echo.Context) error {
@@ -111,6 +113,33 @@ func (rc *EventController) Delete(c echo.Context) error {
 	})
 }
 
+// Publish handles publishing a draft now.
+//
+//	@Summary		Publish a draft
+//	@Description	This endpoint makes a draft of the owner visible, the connections of the owner are notified unless it is a just me event.
+//	@Tags			events
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string				true	"Event ID"
+//	@Success		200	{object}	SuccessListResponse	"Event published successfully"
+//	@Failure		400	{object}	FailureResponse		"Invalid request data"
+//	@Failure		404	{object}	FailureResponse		"Draft not found"
+//	@Failure		500	{object}	FailureResponse		"Event publish failed"
+//	@Router			/events/{id}/publish [post]
+func (rc *EventController) Publish(c echo.Context) error {
+	eventID := c.Param("id")
+
+	event, err := rc.EventDBUC.Publish(c.Request().Context(), eventID)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data: event,
+	})
+}
+
 // List handles the retrieval of a list of events.
 //
 //	@Summary		Retrieve a list of events
@@ -121,6 +150,13 @@ func (rc *EventController) Delete(c echo.Context) error {
 //	@Param			Authorization	header		string				false	"Insert your access token"												default(Bearer <Add access token here>)
 //	@Param			user_id			query		string				false	"Filter events by user id, returns owners events if not provided"		example(eq:1)
 //	@Param			visibility		query		string				false	"Filter events by visibility status (public:1, private:2, just me:3)"	example(eq:1)
+//	@Param			name			query		string				false	"Filter events by name"													example(like:trip)
+//	@Param			date			query		string				false	"Filter events by date, btw takes an inclusive from,to range"			example(btw:2020-01-01,2020-12-31)
+//	@Param			time_start		query		string				false	"Filter events by start time"											example(gte:2020-01-01)
+//	@Param			time_end		query		string				false	"Filter events by end time"												example(lte:2020-12-31)
+//	@Param			draft			query		string				false	"Filter the owners events by draft state"								example(eq:true)
+//	@Param			tags			query		string				false	"Filter events having any of the comma separated tag names, nin excludes them"	example(travel,career)
+//	@Param			q				query		string				false	"Full-text search over name and description, ranked by relevance"		example(summer holiday)
 //	@Param			limit			query		string				false	"Limit the number of events returned"									example(10)
 //	@Param			skip			query		string				false	"Number of events to skip for pagination"								example(0)
 //	@Param			order			query		string				false	"Order by column (prefix with asc: or desc:)"							example(desc:created_at)
@@ -155,12 +191,14 @@ func (rc *EventController) List(c echo.Context) error {
 //	@Param			id	path		string				true	"Event name or UID"
 //	@Success		200	{object}	SuccessListResponse	"Event retrieved successfully"
 //	@Failure		400	{object}	FailureResponse		"Invalid request data"
+//	@Failure		403	{object}	FailureResponse		"Event is not visible to the user"
+//	@Failure		404	{object}	FailureResponse		"Event not found"
 //	@Failure		500	{object}	FailureResponse		"Event retrieval failed"
 //	@Router			/events/{id} [get]
 func (rc *EventController) GetByID(c echo.Context) error {
 	eventID := c.Param("id")
 
-	event, err := rc.EventDBUC.GetByID(c.Request().Context(), eventID)
+	event, err := rc.EventDBUC.GetVisible(c.Request().Context(), eventID)
 	if err != nil {
 		return handleEchoError(c, err)
 	}
@@ -177,5 +215,14 @@ func (rc *EventController) getEventsFindOpts(c echo.Context) model.EventFindOpts
 		UserID:         getFilter(c, "user_id"),
 		Visibility:     getFilter(c, "visibility"),
 		Name:           getFilter(c, "name"),
+		Date:           getFilter(c, "date"),
+		TimeStart:      getFilter(c, "time_start"),
+		TimeEnd:        getFilter(c, "time_end"),
+		Tags:           getFilter(c, "tags"),
+		Draft:          getFilter(c, "draft"),
+		Search: model.Filter{
+			Value:    c.QueryParam("q"),
+			IsSended: c.QueryParam("q") != "",
+		},
 	}
 }
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FeedHandlers struct {
+	feedUC *uc.FeedUC
+}
+
+func NewFeedHandlers(feedUC *uc.FeedUC) *FeedHandlers {
+	return &FeedHandlers{
+		feedUC: feedUC,
+	}
+}
+
+// Get godoc
+//
+//	@Summary		Friends feed
+//	@Description	Returns the recent public and connections only events of all approved connections of the owner and the public events of the users the owner follows, newest first. Muted and blocked users are left out. Pass next_cursor of the response as cursor to get the next page.
+//	@Tags			feed
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			limit	query		string			false	"Maximum number of events returned"	example(20)
+//	@Param			cursor	query		string			false	"next_cursor of the previous page"
+//	@Success		200		{object}	SuccessListResponse	"Feed retrieved successfully, data is the feed and total its number of events"
+//	@Failure		400		{object}	FailureResponse	"Invalid cursor"
+//	@Failure		500		{object}	FailureResponse	"Feed retrieval failed"
+//	@Router			/feed [get]
+func (rc *FeedHandlers) Get(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	feed, err := rc.feedUC.Get(c.Request().Context(), c.QueryParam("cursor"), limit)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  feed,
+		Total: len(feed.Events),
+	})
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FollowHandlers struct {
+	followUC *uc.FollowUC
+}
+
+func NewFollowHandlers(followUC *uc.FollowUC) *FollowHandlers {
+	return &FollowHandlers{
+		followUC: followUC,
+	}
+}
+
+// Create godoc
+//
+//	@Summary		Follow a user
+//	@Description	This endpoint follows a user without an approval. The public events of followed users show up in the feed and the followed user gets notified.
+//	@Tags			follows
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.FollowCreateInput	true	"Follow input"
+//	@Success		201		{object}	SuccessListResponse		"User followed successfully"
+//	@Failure		400		{object}	FailureResponse			"Invalid request data"
+//	@Failure		403		{object}	FailureResponse			"User is blocked"
+//	@Failure		409		{object}	FailureResponse			"User is already followed"
+//	@Failure		500		{object}	FailureResponse			"Follow failed"
+//	@Router			/follows [post]
+func (rc *FollowHandlers) Create(c echo.Context) error {
+	var input model.FollowCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	follow, err := rc.followUC.Create(c.Request().Context(), &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: follow,
+	})
+}
+
+// Delete godoc
+//
+//	@Summary		Unfollow a user
+//	@Description	This endpoint unfollows a user followed by the owner.
+//	@Tags			follows
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			user_id	path		string			true	"Followed user ID"
+//	@Success		200		{object}	SuccessResponse	"User unfollowed successfully"
+//	@Failure		400		{object}	FailureResponse	"User is not followed"
+//	@Failure		500		{object}	FailureResponse	"Unfollow failed"
+//	@Router			/follows/{user_id} [delete]
+func (rc *FollowHandlers) Delete(c echo.Context) error {
+	userID := c.Param("user_id")
+
+	if err := rc.followUC.Delete(c.Request().Context(), userID); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "User unfollowed successfully",
+	})
+}
+
+// Followers godoc
+//
+//	@Summary		List followers of a user
+//	@Description	Returns the followers of a user, newest first.
+//	@Tags			follows
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				false	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		string				true	"User ID"
+//	@Param			limit			query		string				false	"Limit the number of followers returned"
+//	@Param			skip			query		string				false	"Number of followers to skip for pagination"
+//	@Success		200				{object}	SuccessListResponse	"Followers retrieved successfully"
+//	@Failure		403				{object}	FailureResponse		"User is blocked"
+//	@Failure		500				{object}	FailureResponse		"Followers retrieval failed"
+//	@Router			/users/{id}/followers [get]
+func (rc *FollowHandlers) Followers(c echo.Context) error {
+	userID := c.Param("id")
+
+	opts := model.FollowFindOpts{
+		PaginationOpts: getPagination(c),
+	}
+
+	list, err := rc.followUC.Followers(c.Request().Context(), userID, &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Follows,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
+// Following godoc
+//
+//	@Summary		List users a user follows
+//	@Description	Returns the users followed by a user, newest first.
+//	@Tags			follows
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				false	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		string				true	"User ID"
+//	@Param			limit			query		string				false	"Limit the number of users returned"
+//	@Param			skip			query		string				false	"Number of users to skip for pagination"
+//	@Success		200				{object}	SuccessListResponse	"Followed users retrieved successfully"
+//	@Failure		403				{object}	FailureResponse		"User is blocked"
+//	@Failure		500				{object}	FailureResponse		"Followed users retrieval failed"
+//	@Router			/users/{id}/following [get]
+func (rc *FollowHandlers) Following(c echo.Context) error {
+	userID := c.Param("id")
+
+	opts := model.FollowFindOpts{
+		PaginationOpts: getPagination(c),
+	}
+
+	list, err := rc.followUC.Following(c.Request().Context(), userID, &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Follows,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MemoryHandlers struct {
+	memoryUC *uc.MemoryUC
+}
+
+func NewMemoryHandlers(memoryUC *uc.MemoryUC) *MemoryHandlers {
+	return &MemoryHandlers{
+		memoryUC: memoryUC,
+	}
+}
+
+// OnThisDay godoc
+//
+//	@Summary		On this day memories
+//	@Description	Returns the owners events from the same calendar day in earlier years, newest first. The day defaults to today in the timezone of the owner.
+//	@Tags			events
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			date	query		string				false	"Day to look back from"	example(2025-06-15)
+//	@Success		200		{object}	SuccessListResponse	"Events retrieved successfully"
+//	@Failure		400		{object}	FailureResponse		"Invalid date"
+//	@Failure		500		{object}	FailureResponse		"Event retrieval failed"
+//	@Router			/events/on-this-day [get]
+func (rc *MemoryHandlers) OnThisDay(c echo.Context) error {
+	events, err := rc.memoryUC.OnThisDay(c.Request().Context(), c.QueryParam("date"))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  events,
+		Total: len(events),
+	})
+}
@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MentionHandlers struct {
+	mentionUC *uc.MentionUC
+}
+
+func NewMentionHandlers(mentionUC *uc.MentionUC) *MentionHandlers {
+	return &MentionHandlers{
+		mentionUC: mentionUC,
+	}
+}
+
+// Create godoc
+//
+//	@Summary		Tag a connection in an event
+//	@Description	This endpoint tags one of the owners connections in one of the owners events. The tagged user gets notified and has to approve the tag before the event shows up on their timeline.
+//	@Tags			mentions
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Event ID"
+//	@Param			Body	body		model.MentionCreateInput	true	"Mention creation input"
+//	@Success		201		{object}	SuccessListResponse			"User tagged successfully"
+//	@Failure		400		{object}	FailureResponse				"User is not a connection"
+//	@Failure		403		{object}	FailureResponse				"Event belongs to another user"
+//	@Failure		409		{object}	FailureResponse				"User is already tagged"
+//	@Failure		500		{object}	FailureResponse				"Tagging failed"
+//	@Router			/events/{id}/mentions [post]
+func (rc *MentionHandlers) Create(c echo.Context) error {
+	eventID := c.Param("id")
+	var input model.MentionCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	mention, err := rc.mentionUC.Create(c.Request().Context(), eventID, &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: mention,
+	})
+}
+
+// Update godoc
+//
+//	@Summary		Approve or reject a tag
+//	@Description	This endpoint approves (101) or rejects (102) a tag of the owner. Approved events show up on the owners timeline as shared memories, rejecting an approved tag removes it again.
+//	@Tags			mentions
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Mention ID"
+//	@Param			Body	body		model.MentionUpdateInput	true	"Mention update input"
+//	@Success		200		{object}	SuccessResponse				"Mention updated successfully"
+//	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		403		{object}	FailureResponse				"Mention belongs to another user"
+//	@Failure		500		{object}	FailureResponse				"Mention update failed"
+//	@Router			/mentions/{id} [patch]
+func (rc *MentionHandlers) Update(c echo.Context) error {
+	id := c.Param("id")
+	var input model.MentionUpdateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	if err := rc.mentionUC.Update(c.Request().Context(), id, &input); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Mention updated successfully",
+	})
+}
+
+// Delete godoc
+//
+//	@Summary		Remove a tag
+//	@Description	This endpoint removes the tag of a user from an event. Event owners can remove the tags in their events, tagged users their own tags.
+//	@Tags			mentions
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string			true	"Event ID"
+//	@Param			user_id	path		string			true	"Tagged user ID"
+//	@Success		200		{object}	SuccessResponse	"Tag removed successfully"
+//	@Failure		403		{object}	FailureResponse	"Not allowed to remove the tag"
+//	@Failure		404		{object}	FailureResponse	"User is not tagged"
+//	@Failure		500		{object}	FailureResponse	"Tag remove failed"
+//	@Router			/events/{id}/mentions/{user_id} [delete]
+func (rc *MentionHandlers) Delete(c echo.Context) error {
+	eventID := c.Param("id")
+	userID := c.Param("user_id")
+
+	if err := rc.mentionUC.Delete(c.Request().Context(), eventID, userID); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Tag removed successfully",
+	})
+}
+
+// List godoc
+//
+//	@Summary		List users tagged in an event
+//	@Description	Returns the users tagged in an event visible to the owner. Pending and rejected tags are listed only to the event owner.
+//	@Tags			mentions
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				false	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		string				true	"Event ID"
+//	@Param			status			query		string				false	"Filter by status"
+//	@Param			limit			query		string				false	"Limit the number of mentions returned"
+//	@Param			skip			query		string				false	"Number of mentions to skip for pagination"
+//	@Success		200				{object}	SuccessListResponse	"Mentions retrieved successfully"
+//	@Failure		403				{object}	FailureResponse		"Event is not visible"
+//	@Failure		500				{object}	FailureResponse		"Mention retrieval failed"
+//	@Router			/events/{id}/mentions [get]
+func (rc *MentionHandlers) List(c echo.Context) error {
+	eventID := c.Param("id")
+
+	opts := model.MentionFindOpts{
+		PaginationOpts: getPagination(c),
+		Status:         getFilter(c, "status"),
+	}
+
+	list, err := rc.mentionUC.List(c.Request().Context(), eventID, &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Mentions,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
+// ListOwn godoc
+//
+//	@Summary		List the owners tags
+//	@Description	Returns the events the owner is tagged in, filter by status=100 for the tags waiting for approval.
+//	@Tags			mentions
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			status	query		string				false	"Filter by status"
+//	@Param			limit	query		string				false	"Limit the number of mentions returned"
+//	@Param			skip	query		string				false	"Number of mentions to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Mentions retrieved successfully"
+//	@Failure		500		{object}	FailureResponse		"Mention retrieval failed"
+//	@Router			/mentions [get]
+func (rc *MentionHandlers) ListOwn(c echo.Context) error {
+	opts := model.MentionFindOpts{
+		PaginationOpts: getPagination(c),
+		Status:         getFilter(c, "status"),
+	}
+
+	list, err := rc.mentionUC.ListOwn(c.Request().Context(), &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Mentions,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
@@ -1,7 +1,10 @@
 package controller
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 	"github.com/fleimkeipa/lifery/uc"
@@ -10,6 +13,9 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// streamHeartbeat keeps idle streams open through proxies
+const streamHeartbeat = 30 * time.Second
+
 type NotificationHandlers struct {
 	notificationUC *uc.NotificationUC
 }
@@ -56,10 +62,171 @@ func (rc *NotificationHandlers) Update(c echo.Context) error {
 	})
 }
 
+// MarkAllRead godoc
+//
+//	@Summary		MarkAllRead marks all notifications as read
+//	@Description	This endpoint marks every unread notification of the owner as read.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	SuccessResponse	"Notifications marked as read successfully"
+//	@Failure		500	{object}	FailureResponse	"Notification update failed"
+//	@Router			/notifications/read [patch]
+func (rc *NotificationHandlers) MarkAllRead(c echo.Context) error {
+	count, err := rc.notificationUC.MarkAllRead(c.Request().Context())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("%d notifications marked as read", count),
+	})
+}
+
+// Delete godoc
+//
+//	@Summary		Delete deletes a notification
+//	@Description	This endpoint deletes a notification of the owner.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string			true	"Notification ID to delete"
+//	@Success		200	{object}	SuccessResponse	"Notification deleted successfully"
+//	@Failure		400	{object}	FailureResponse	"Invalid request data"
+//	@Failure		403	{object}	FailureResponse	"Notification of another user"
+//	@Failure		500	{object}	FailureResponse	"Notification deletion failed"
+//	@Router			/notifications/{id} [delete]
+func (rc *NotificationHandlers) Delete(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := rc.notificationUC.Delete(c.Request().Context(), id); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Notification deleted successfully",
+	})
+}
+
+// DeleteMany godoc
+//
+//	@Summary		DeleteMany deletes notifications at once
+//	@Description	This endpoint deletes the given notifications of the owner, ids of notifications of other users are skipped.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.NotificationDeleteInput	true	"Notification ids to delete"
+//	@Success		200		{object}	SuccessResponse					"Notifications deleted successfully"
+//	@Failure		400		{object}	FailureResponse					"Invalid request data"
+//	@Failure		500		{object}	FailureResponse					"Notification deletion failed"
+//	@Router			/notifications [delete]
+func (rc *NotificationHandlers) DeleteMany(c echo.Context) error {
+	var input model.NotificationDeleteInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	count, err := rc.notificationUC.DeleteMany(c.Request().Context(), input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("%d notifications deleted successfully", count),
+	})
+}
+
+// UnreadCount godoc
+//
+//	@Summary		UnreadCount counts unread notifications
+//	@Description	Returns the number of unread notifications of the owner.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	SuccessListResponse	"Number of unread notifications, data is the count"
+//	@Failure		500	{object}	FailureResponse		"Internal error"
+//	@Router			/notifications/unread-count [get]
+func (rc *NotificationHandlers) UnreadCount(c echo.Context) error {
+	count, err := rc.notificationUC.UnreadCount(c.Request().Context())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data: model.NotificationUnreadCount{
+			Count: count,
+		},
+	})
+}
+
+// GetPreferences godoc
+//
+//	@Summary		GetPreferences returns notification preferences
+//	@Description	This endpoint returns the channel the owner chose for each notification type, their quiet hours and daily digest option. Types left out of channels are delivered in app.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	SuccessListResponse	"Notification preferences, data is the preferences"
+//	@Failure		500	{object}	FailureResponse		"Internal error"
+//	@Router			/notifications/preferences [get]
+func (rc *NotificationHandlers) GetPreferences(c echo.Context) error {
+	prefs, err := rc.notificationUC.GetPreferences(c.Request().Context())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data: prefs,
+	})
+}
+
+// UpdatePreferences godoc
+//
+//	@Summary		UpdatePreferences updates notification preferences
+//	@Description	This endpoint sets for each notification type whether it is delivered in app, by email or not at all, plus quiet hours and the daily digest. Emails are held back in quiet hours, the daily digest sends them together once a day.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.NotificationPreferencesInput	true	"Notification preferences"
+//	@Success		200		{object}	SuccessResponse						"Notification preferences updated successfully"
+//	@Failure		400		{object}	FailureResponse						"Invalid request data"
+//	@Failure		500		{object}	FailureResponse						"Internal error"
+//	@Router			/notifications/preferences [put]
+func (rc *NotificationHandlers) UpdatePreferences(c echo.Context) error {
+	var input model.NotificationPreferencesInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	if err := rc.notificationUC.UpdatePreferences(c.Request().Context(), input); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Notification preferences updated successfully",
+	})
+}
+
 // List godoc
 //
 //	@Summary		List lists all notifications
-//	@Description	Retrieves a filtered and paginated list of notifications based on query parameters.
+//	@Description	Retrieves a filtered and paginated list of notifications based on query parameters. Messages are rendered in the language of the user and link is the path of the ui page the notification leads to.
 //	@Tags			notifications
 //	@Accept			json
 //	@Produce		json
@@ -125,3 +292,117 @@ func (rc *NotificationHandlers) getNotificationFindOpts(c echo.Context, fields .
 
 	return defaultFilter
 }
+
+// StreamTicket godoc
+//
+//	@Summary		StreamTicket creates a notification stream ticket
+//	@Description	EventSource cannot set the Authorization header, so the stream is opened with a ticket instead of the token.
+//	@Description	A ticket opens one stream and expires after 30 seconds, take a new one for every reconnect.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		201	{object}	SuccessListResponse	"Stream ticket, data is the ticket"
+//	@Failure		500	{object}	FailureResponse		"Internal error"
+//	@Router			/notifications/stream/ticket [post]
+func (rc *NotificationHandlers) StreamTicket(c echo.Context) error {
+	ticket, err := rc.notificationUC.CreateStreamTicket(c.Request().Context())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: ticket,
+	})
+}
+
+// Stream godoc
+//
+//	@Summary		Stream streams new notifications
+//	@Description	Server-Sent Events stream of the notifications of the owner as they are created, each event id is the notification id.
+//	@Description	On reconnect the notifications created after the Last-Event-ID header or the last_event_id query param are replayed first.
+//	@Description	The stream is opened with a ticket from POST /notifications/stream/ticket, a ticket opens one stream only.
+//	@Tags			notifications
+//	@Produce		text/event-stream
+//	@Param			ticket			query		string				true	"Stream ticket"
+//	@Param			Last-Event-ID	header		string				false	"ID of the last notification received"
+//	@Param			last_event_id	query		string				false	"ID of the last notification received"
+//	@Success		200				{object}	model.Notification	"Stream of notifications"
+//	@Failure		400				{object}	FailureResponse		"Invalid last event id"
+//	@Failure		401				{object}	FailureResponse		"Invalid or expired stream ticket"
+//	@Router			/notifications/stream [get]
+func (rc *NotificationHandlers) Stream(c echo.Context) error {
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+
+	ctx, err := rc.notificationUC.RedeemStreamTicket(c.Request().Context(), c.QueryParam("ticket"))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	replay, live, unsubscribe, err := rc.notificationUC.Subscribe(ctx, lastEventID)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+	defer unsubscribe()
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// notifications published while the replay was loaded come in twice
+	sent := make(map[string]bool, len(replay))
+	for _, v := range replay {
+		if err := writeEvent(w, v); err != nil {
+			return nil
+		}
+
+		sent[v.ID] = true
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return nil
+			}
+		case notification, ok := <-live:
+			if !ok {
+				// dropped for falling behind, the client reconnects with its last event id
+				return nil
+			}
+
+			if sent[notification.ID] {
+				continue
+			}
+
+			if err := writeEvent(w, notification); err != nil {
+				return nil
+			}
+		}
+
+		w.Flush()
+	}
+}
+
+func writeEvent(w *echo.Response, notification model.Notification) error {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: notification\ndata: %s\n\n", notification.ID, data)
+
+	return err
+}
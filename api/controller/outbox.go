@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type OutboxHandlers struct {
+	outboxUC *uc.OutboxUC
+}
+
+func NewOutboxHandlers(outboxUC *uc.OutboxUC) *OutboxHandlers {
+	return &OutboxHandlers{
+		outboxUC: outboxUC,
+	}
+}
+
+// ListDeadLetters godoc
+//
+//	@Summary		List dead letters
+//	@Description	Returns the domain events the outbox gave up delivering after running out of attempts, latest first. last_error tells why the last attempt failed.
+//	@Tags			outbox
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			limit	query		string				false	"Limit the number of dead letters returned"
+//	@Param			skip	query		string				false	"Number of dead letters to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Dead letters"
+//	@Failure		500		{object}	FailureResponse		"Internal error"
+//	@Router			/outbox/dead-letters [get]
+func (rc *OutboxHandlers) ListDeadLetters(c echo.Context) error {
+	list, err := rc.outboxUC.ListDeadLetters(c.Request().Context(), getPagination(c))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.DeadLetters,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
+// RetryDeadLetter godoc
+//
+//	@Summary		Retry a dead letter
+//	@Description	This endpoint moves a dead letter back to the outbox with its attempts reset. Handlers that delivered it before are skipped.
+//	@Tags			outbox
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string			true	"Dead letter ID"
+//	@Success		200	{object}	SuccessResponse	"Dead letter queued for delivery"
+//	@Failure		404	{object}	FailureResponse	"Dead letter not found"
+//	@Failure		500	{object}	FailureResponse	"Internal error"
+//	@Router			/outbox/dead-letters/{id}/retry [post]
+func (rc *OutboxHandlers) RetryDeadLetter(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := rc.outboxUC.RetryDeadLetter(c.Request().Context(), id); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Dead letter queued for delivery",
+	})
+}
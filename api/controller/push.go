@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PushHandlers struct {
+	pushUC *uc.PushUC
+}
+
+func NewPushHandlers(pushUC *uc.PushUC) *PushHandlers {
+	return &PushHandlers{
+		pushUC: pushUC,
+	}
+}
+
+// PublicKey godoc
+//
+//	@Summary		VAPID public key
+//	@Description	Returns the VAPID public key browsers pass as applicationServerKey when they subscribe to web push.
+//	@Tags			push
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	SuccessListResponse	"VAPID public key, data is the key"
+//	@Failure		503	{object}	FailureResponse		"Web push is not configured"
+//	@Router			/push/public-key [get]
+func (rc *PushHandlers) PublicKey(c echo.Context) error {
+	publicKey, err := rc.pushUC.PublicKey(c.Request().Context())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data: publicKey,
+	})
+}
+
+// Subscribe godoc
+//
+//	@Summary		Subscribe to web push
+//	@Description	This endpoint stores the PushSubscription json of a browser of the owner. Notifications are pushed to every subscribed browser, subscribing again from the same browser replaces its keys.
+//	@Tags			push
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.PushSubscriptionInput	true	"PushSubscription of the browser"
+//	@Success		201		{object}	SuccessResponse				"Subscribed successfully"
+//	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		500		{object}	FailureResponse				"Subscription failed"
+//	@Router			/push/subscriptions [post]
+func (rc *PushHandlers) Subscribe(c echo.Context) error {
+	var input model.PushSubscriptionInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	_, err := rc.pushUC.Subscribe(c.Request().Context(), &input, c.Request().UserAgent())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Subscribed successfully",
+	})
+}
+
+// Unsubscribe godoc
+//
+//	@Summary		Unsubscribe from web push
+//	@Description	This endpoint deletes a push subscription of the owner, the browser gets no more notifications.
+//	@Tags			push
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string			true	"Subscription ID"
+//	@Success		200	{object}	SuccessResponse	"Unsubscribed successfully"
+//	@Failure		400	{object}	FailureResponse	"Invalid request data"
+//	@Failure		500	{object}	FailureResponse	"Unsubscription failed"
+//	@Router			/push/subscriptions/{id} [delete]
+func (rc *PushHandlers) Unsubscribe(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := rc.pushUC.Unsubscribe(c.Request().Context(), id); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Unsubscribed successfully",
+	})
+}
+
+// List godoc
+//
+//	@Summary		List push subscriptions
+//	@Description	Returns the browsers of the owner subscribed to web push.
+//	@Tags			push
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	SuccessListResponse	"Push subscriptions"
+//	@Failure		500	{object}	FailureResponse		"Internal error"
+//	@Router			/push/subscriptions [get]
+func (rc *PushHandlers) List(c echo.Context) error {
+	subscriptions, err := rc.pushUC.List(c.Request().Context())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  subscriptions,
+		Total: len(subscriptions),
+	})
+}
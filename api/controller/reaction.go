@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReactionHandlers struct {
+	reactionUC *uc.ReactionUC
+}
+
+func NewReactionHandlers(reactionUC *uc.ReactionUC) *ReactionHandlers {
+	return &ReactionHandlers{
+		reactionUC: reactionUC,
+	}
+}
+
+// Set godoc
+//
+//	@Summary		React to an event
+//	@Description	This endpoint reacts to an event visible to the owner with one of 👍 ❤️ 😂 😮 😢 👏. Reacting again replaces the earlier reaction. The event owner gets a batched notification.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string					true	"Event ID"
+//	@Param			Body	body		model.ReactionSetInput	true	"Reaction input"
+//	@Success		200		{object}	SuccessListResponse		"Reaction set successfully"
+//	@Failure		400		{object}	FailureResponse			"Invalid request data"
+//	@Failure		403		{object}	FailureResponse			"Event is not visible"
+//	@Failure		500		{object}	FailureResponse			"Reaction failed"
+//	@Router			/events/{id}/reactions [put]
+func (rc *ReactionHandlers) Set(c echo.Context) error {
+	eventID := c.Param("id")
+	var input model.ReactionSetInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	reaction, err := rc.reactionUC.Set(c.Request().Context(), eventID, &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data: reaction,
+	})
+}
+
+// Delete godoc
+//
+//	@Summary		Remove a reaction
+//	@Description	This endpoint removes the owners reaction from an event.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string			true	"Event ID"
+//	@Success		200	{object}	SuccessResponse	"Reaction removed successfully"
+//	@Failure		400	{object}	FailureResponse	"No reaction on the event"
+//	@Failure		500	{object}	FailureResponse	"Reaction remove failed"
+//	@Router			/events/{id}/reactions [delete]
+func (rc *ReactionHandlers) Delete(c echo.Context) error {
+	eventID := c.Param("id")
+
+	if err := rc.reactionUC.Delete(c.Request().Context(), eventID); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Reaction removed successfully",
+	})
+}
+
+// List godoc
+//
+//	@Summary		List reactions of an event
+//	@Description	Returns who reacted to an event visible to the owner, newest first. Counts per emoji are part of the event itself.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				false	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		string				true	"Event ID"
+//	@Param			emoji			query		string				false	"Filter by emoji"
+//	@Param			limit			query		string				false	"Limit the number of reactions returned"
+//	@Param			skip			query		string				false	"Number of reactions to skip for pagination"
+//	@Success		200				{object}	SuccessListResponse	"Reactions retrieved successfully"
+//	@Failure		403				{object}	FailureResponse		"Event is not visible"
+//	@Failure		500				{object}	FailureResponse		"Reaction retrieval failed"
+//	@Router			/events/{id}/reactions [get]
+func (rc *ReactionHandlers) List(c echo.Context) error {
+	eventID := c.Param("id")
+
+	opts := model.ReactionFindOpts{
+		PaginationOpts: getPagination(c),
+		Emoji:          getFilter(c, "emoji"),
+	}
+
+	list, err := rc.reactionUC.List(c.Request().Context(), eventID, &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Reactions,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
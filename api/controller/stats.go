@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type StatsHandlers struct {
+	statsUC *uc.StatsUC
+}
+
+func NewStatsHandlers(statsUC *uc.StatsUC) *StatsHandlers {
+	return &StatsHandlers{
+		statsUC: statsUC,
+	}
+}
+
+// Get godoc
+//
+//	@Summary		Life statistics
+//	@Description	Returns insights over the owners timeline: events per period, media counts by item type, the longest era, gaps without events, visibility breakdown, most used tags and connection growth.
+//	@Tags			stats
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			user_id		query		string				false	"Stats of user id if you are admin"
+//	@Param			group_by	query		string				false	"Period to group counts by, year or month"	example(month)
+//	@Success		200			{object}	SuccessListResponse	"Stats retrieved successfully"
+//	@Failure		400			{object}	FailureResponse		"Invalid request data"
+//	@Failure		403			{object}	FailureResponse		"Stats of another user"
+//	@Failure		500			{object}	FailureResponse		"Stats retrieval failed"
+//	@Router			/stats [get]
+func (rc *StatsHandlers) Get(c echo.Context) error {
+	opts := model.StatsFindOpts{
+		UserID:  c.QueryParam("user_id"),
+		GroupBy: model.TimelinePeriod(c.QueryParam("group_by")),
+	}
+
+	stats, err := rc.statsUC.Get(c.Request().Context(), &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data: stats,
+	})
+}
@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TagHandlers struct {
+	tagUC *uc.TagUC
+}
+
+func NewTagHandlers(tagUC *uc.TagUC) *TagHandlers {
+	return &TagHandlers{
+		tagUC: tagUC,
+	}
+}
+
+// Create godoc
+//
+//	@Summary		Create creates a new tag
+//	@Description	This endpoint creates a new tag for the owner by binding the incoming JSON request to the TagCreateInput model.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.TagCreateInput	true	"Tag creation input"
+//	@Success		201		{object}	SuccessListResponse		"Tag created successfully"
+//	@Failure		400		{object}	FailureResponse			"Invalid request data"
+//	@Failure		409		{object}	FailureResponse			"Tag already exists"
+//	@Failure		500		{object}	FailureResponse			"Tag creation failed"
+//	@Router			/tags [post]
+func (rc *TagHandlers) Create(c echo.Context) error {
+	var input model.TagCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	tag, err := rc.tagUC.Create(c.Request().Context(), &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, SuccessListResponse{
+		Data: tag,
+	})
+}
+
+// Update godoc
+//
+//	@Summary		Update updates an existing tag
+//	@Description	This endpoint updates a tag by binding the incoming JSON request to the TagUpdateInput model.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string					true	"Tag ID to update"
+//	@Param			Body	body		model.TagUpdateInput	true	"Tag update input"
+//	@Success		200		{object}	SuccessResponse			"Tag updated successfully"
+//	@Failure		400		{object}	FailureResponse			"Invalid request data"
+//	@Failure		409		{object}	FailureResponse			"Tag already exists"
+//	@Failure		500		{object}	FailureResponse			"Tag update failed"
+//	@Router			/tags/{id} [patch]
+func (rc *TagHandlers) Update(c echo.Context) error {
+	id := c.Param("id")
+	var input model.TagUpdateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	_, err := rc.tagUC.Update(c.Request().Context(), id, &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Tag updated successfully",
+	})
+}
+
+// Delete godoc
+//
+//	@Summary		Delete deletes an existing tag
+//	@Description	This endpoint deletes a tag and detaches it from all events.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string			true	"Tag ID to delete"
+//	@Success		200	{object}	SuccessResponse	"Tag deleted successfully"
+//	@Failure		400	{object}	FailureResponse	"Invalid request data"
+//	@Failure		500	{object}	FailureResponse	"Tag delete failed"
+//	@Router			/tags/{id} [delete]
+func (rc *TagHandlers) Delete(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := rc.tagUC.Delete(c.Request().Context(), id); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Tag deleted successfully",
+	})
+}
+
+// List godoc
+//
+//	@Summary		List lists the owners tags
+//	@Description	Retrieves a filtered and paginated list of tags based on query parameters.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			name	query		string				false	"Filter tags by name"							example(like:trav)
+//	@Param			user_id	query		string				false	"Filter tags by user id if you are admin"
+//	@Param			limit	query		string				false	"Limit the number of tags returned"
+//	@Param			skip	query		string				false	"Number of tags to skip for pagination"
+//	@Param			order	query		string				false	"Order by column (prefix with asc: or desc:)"	example(asc:name)
+//	@Success		200		{object}	SuccessListResponse	"Tags retrieved successfully"
+//	@Failure		500		{object}	FailureResponse		"Tag retrieval failed"
+//	@Router			/tags [get]
+func (rc *TagHandlers) List(c echo.Context) error {
+	opts := rc.getTagsFindOpts(c)
+
+	list, err := rc.tagUC.List(c.Request().Context(), &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Tags,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
+// GetByID godoc
+//
+//	@Summary		Retrieve tag by ID
+//	@Description	Fetches one of the owners tags by its ID.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string				true	"Tag ID"
+//	@Success		200	{object}	SuccessListResponse	"Tag retrieved successfully"
+//	@Failure		403	{object}	FailureResponse		"Tag belongs to another user"
+//	@Failure		500	{object}	FailureResponse		"Tag retrieval failed"
+//	@Router			/tags/{id} [get]
+func (rc *TagHandlers) GetByID(c echo.Context) error {
+	id := c.Param("id")
+
+	tag, err := rc.tagUC.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data: tag,
+	})
+}
+
+// Autocomplete godoc
+//
+//	@Summary		Autocomplete tag names
+//	@Description	Returns the owners tags containing the query with their usage counts, most used first.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			q	query		string				false	"Part of the tag name"	example(trav)
+//	@Success		200	{object}	SuccessListResponse	"Tags retrieved successfully"
+//	@Failure		500	{object}	FailureResponse		"Tag retrieval failed"
+//	@Router			/tags/autocomplete [get]
+func (rc *TagHandlers) Autocomplete(c echo.Context) error {
+	tags, err := rc.tagUC.Autocomplete(c.Request().Context(), c.QueryParam("q"))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  tags,
+		Total: len(tags),
+	})
+}
+
+// Counts godoc
+//
+//	@Summary		Tag usage counts
+//	@Description	Returns the owners tags with the number of events they are attached to, most used first, for building a tag cloud.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			user_id	query		string				false	"Count tags of user id if you are admin"
+//	@Param			limit	query		string				false	"Limit the number of tags returned"
+//	@Param			skip	query		string				false	"Number of tags to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Tag counts retrieved successfully"
+//	@Failure		500		{object}	FailureResponse		"Tag count failed"
+//	@Router			/tags/counts [get]
+func (rc *TagHandlers) Counts(c echo.Context) error {
+	opts := model.TagFindOpts{
+		PaginationOpts: getPagination(c),
+		UserID:         getFilter(c, "user_id"),
+	}
+
+	counts, err := rc.tagUC.Counts(c.Request().Context(), &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  counts,
+		Total: len(counts),
+		Limit: opts.Limit,
+		Skip:  opts.Skip,
+	})
+}
+
+func (rc *TagHandlers) getTagsFindOpts(c echo.Context) model.TagFindOpts {
+	return model.TagFindOpts{
+		OrderByOpts:    getOrder(c),
+		PaginationOpts: getPagination(c),
+		Name:           getFilter(c, "name"),
+		UserID:         getFilter(c, "user_id"),
+	}
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TimelineHandlers struct {
+	timelineUC *uc.TimelineUC
+}
+
+func NewTimelineHandlers(timelineUC *uc.TimelineUC) *TimelineHandlers {
+	return &TimelineHandlers{
+		timelineUC: timelineUC,
+	}
+}
+
+// Get godoc
+//
+//	@Summary		Retrieve a timeline
+//	@Description	Returns the events and eras of a user merged and grouped by year or month, with per bucket counts. Events follow the same visibility rules as the event list, eras are placed in the bucket they start in.
+//	@Tags			timeline
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			false	"Insert your access token"											default(Bearer <Add access token here>)
+//	@Param			user_id			query		string			false	"User id, returns owners timeline if not provided"					example(1)
+//	@Param			from			query		string			false	"Start of the time range (RFC3339 or YYYY-MM-DD)"					example(2015-01-01)
+//	@Param			to				query		string			false	"End of the time range (RFC3339 or YYYY-MM-DD)"						example(2025-12-31)
+//	@Param			group_by		query		string			false	"Bucket size, year or month"										example(year)
+//	@Param			order			query		string			false	"Bucket order, asc or desc"											example(desc)
+//	@Param			limit			query		string			false	"Maximum number of buckets returned"								example(12)
+//	@Param			cursor			query		string			false	"next_cursor of the previous page"
+//	@Param			counts_only		query		bool			false	"Return only per bucket counts without events and eras"			example(true)
+//	@Success		200				{object}	SuccessListResponse	"Timeline retrieved successfully, data is the timeline and total its number of buckets"
+//	@Failure		400				{object}	FailureResponse	"Invalid request data"
+//	@Failure		500				{object}	FailureResponse	"Timeline retrieval failed"
+//	@Router			/timeline [get]
+func (rc *TimelineHandlers) Get(c echo.Context) error {
+	opts := rc.getTimelineFindOpts(c)
+
+	timeline, err := rc.timelineUC.Get(c.Request().Context(), &opts)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  timeline,
+		Total: len(timeline.Buckets),
+		Limit: opts.Limit,
+	})
+}
+
+func (rc *TimelineHandlers) getTimelineFindOpts(c echo.Context) model.TimelineFindOpts {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	countsOnly, _ := strconv.ParseBool(c.QueryParam("counts_only"))
+
+	return model.TimelineFindOpts{
+		UserID:     c.QueryParam("user_id"),
+		From:       c.QueryParam("from"),
+		To:         c.QueryParam("to"),
+		Cursor:     c.QueryParam("cursor"),
+		GroupBy:    model.TimelinePeriod(c.QueryParam("group_by")),
+		Limit:      limit,
+		CountsOnly: countsOnly,
+		Ascending:  c.QueryParam("order") == "asc",
+	}
+}
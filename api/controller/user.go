@@ -139,7 +139,7 @@ func (rc *UserHandlers) List(c echo.Context) error {
 func (rc *UserHandlers) Search(c echo.Context) error {
 	opts := rc.getUsersSearchOpts(c, model.ZeroCreds)
 
-	list, err := rc.userUC.List(c.Request().Context(), &opts)
+	list, err := rc.userUC.Search(c.Request().Context(), &opts)
 	if err != nil {
 		return handleEchoError(c, err)
 	}
@@ -296,3 +296,59 @@ func (rc *UserHandlers) UpdatePassword(c echo.Context) error {
 		Message: "Password updated successfully",
 	})
 }
+
+// GetPreferences godoc
+//
+//	@Summary		Get preferences
+//	@Description	This endpoint returns the timezone, digest email frequency and language of the user.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	SuccessListResponse	"Preferences retrieved successfully"
+//	@Failure		500	{object}	FailureResponse		"Internal error"
+//	@Router			/user/preferences [get]
+func (rc *UserHandlers) GetPreferences(c echo.Context) error {
+	prefs, err := rc.userUC.GetPreferences(c.Request().Context())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data: prefs,
+	})
+}
+
+// UpdatePreferences godoc
+//
+//	@Summary		Update preferences
+//	@Description	This endpoint allows a user to set their timezone and language and to opt in to weekly or monthly digest emails. Notifications are rendered in the language, it is kept as it is if left empty.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			body	body		model.UpdatePreferencesRequest	true	"Preferences update input"
+//	@Success		200		{object}	SuccessResponse					"Preferences updated successfully"
+//	@Failure		400		{object}	FailureResponse					"Error message including details on failure"
+//	@Failure		500		{object}	FailureResponse					"Internal error"
+//	@Router			/user/preferences [put]
+func (rc *UserHandlers) UpdatePreferences(c echo.Context) error {
+	var input model.UpdatePreferencesRequest
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	err := rc.userUC.UpdatePreferences(c.Request().Context(), &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Preferences updated successfully",
+	})
+}
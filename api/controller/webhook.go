@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/uc"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandlers struct {
+	webhookUC *uc.WebhookUC
+}
+
+func NewWebhookHandlers(webhookUC *uc.WebhookUC) *WebhookHandlers {
+	return &WebhookHandlers{
+		webhookUC: webhookUC,
+	}
+}
+
+// Create godoc
+//
+//	@Summary		Register a webhook
+//	@Description	This endpoint registers an https endpoint the chosen events are posted to. The response holds the signing secret, it is not shown again. Every request carries X-Lifery-Event, X-Lifery-Delivery, X-Lifery-Timestamp and X-Lifery-Signature headers, the signature is sha256= followed by the hex HMAC-SHA256 of timestamp + "." + body with the secret.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			Body	body		model.WebhookCreateInput	true	"Webhook endpoint and events"
+//	@Success		201		{object}	model.WebhookCreated		"Webhook with its signing secret"
+//	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		500		{object}	FailureResponse				"Webhook registration failed"
+//	@Router			/webhooks [post]
+func (rc *WebhookHandlers) Create(c echo.Context) error {
+	var input model.WebhookCreateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	webhook, err := rc.webhookUC.Create(c.Request().Context(), &input)
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+// Update godoc
+//
+//	@Summary		Update a webhook
+//	@Description	This endpoint changes the url or events of a webhook of the owner. A webhook is disabled after failing too many times in a row, setting enabled to true turns it on again and sends its pending deliveries.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Webhook ID"
+//	@Param			Body	body		model.WebhookUpdateInput	true	"Webhook changes"
+//	@Success		200		{object}	SuccessResponse				"Webhook updated successfully"
+//	@Failure		400		{object}	FailureResponse				"Invalid request data"
+//	@Failure		404		{object}	FailureResponse				"Webhook not found"
+//	@Failure		500		{object}	FailureResponse				"Internal error"
+//	@Router			/webhooks/{id} [patch]
+func (rc *WebhookHandlers) Update(c echo.Context) error {
+	id := c.Param("id")
+
+	var input model.WebhookUpdateInput
+
+	if err := c.Bind(&input); err != nil {
+		return handleBindingErrors(c, err)
+	}
+
+	if err := c.Validate(&input); err != nil {
+		return handleValidatingErrors(c, err)
+	}
+
+	if _, err := rc.webhookUC.Update(c.Request().Context(), id, &input); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Webhook updated successfully",
+	})
+}
+
+// Delete godoc
+//
+//	@Summary		Delete a webhook
+//	@Description	This endpoint deletes a webhook of the owner with its delivery log.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string			true	"Webhook ID"
+//	@Success		200	{object}	SuccessResponse	"Webhook deleted successfully"
+//	@Failure		400	{object}	FailureResponse	"Invalid request data"
+//	@Failure		500	{object}	FailureResponse	"Internal error"
+//	@Router			/webhooks/{id} [delete]
+func (rc *WebhookHandlers) Delete(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := rc.webhookUC.Delete(c.Request().Context(), id); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// List godoc
+//
+//	@Summary		List webhooks
+//	@Description	Returns the webhooks of the owner. failure_count is the failed attempts in a row, disabled_at is set when the webhook was disabled for failing.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	SuccessListResponse	"Webhooks"
+//	@Failure		500	{object}	FailureResponse		"Internal error"
+//	@Router			/webhooks [get]
+func (rc *WebhookHandlers) List(c echo.Context) error {
+	webhooks, err := rc.webhookUC.List(c.Request().Context())
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  webhooks,
+		Total: len(webhooks),
+	})
+}
+
+// Deliveries godoc
+//
+//	@Summary		List webhook deliveries
+//	@Description	Returns the delivery log of a webhook of the owner, latest first. Status is 100 pending, 101 succeeded or 102 failed after running out of attempts, response_status and response_body are the answer of the last attempt.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string				true	"Webhook ID"
+//	@Param			limit	query		string				false	"Limit the number of deliveries returned"
+//	@Param			skip	query		string				false	"Number of deliveries to skip for pagination"
+//	@Success		200		{object}	SuccessListResponse	"Webhook deliveries"
+//	@Failure		404		{object}	FailureResponse		"Webhook not found"
+//	@Failure		500		{object}	FailureResponse		"Internal error"
+//	@Router			/webhooks/{id}/deliveries [get]
+func (rc *WebhookHandlers) Deliveries(c echo.Context) error {
+	id := c.Param("id")
+
+	list, err := rc.webhookUC.Deliveries(c.Request().Context(), id, getPagination(c))
+	if err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessListResponse{
+		Data:  list.Deliveries,
+		Total: list.Total,
+		Limit: list.Limit,
+		Skip:  list.Skip,
+	})
+}
+
+// Redeliver godoc
+//
+//	@Summary		Redeliver a webhook delivery
+//	@Description	This endpoint sends a succeeded or failed delivery of a webhook of the owner again with its attempts reset. It is signed with a new timestamp.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id			path		string			true	"Webhook ID"
+//	@Param			delivery_id	path		string			true	"Delivery ID"
+//	@Success		202			{object}	SuccessResponse	"Delivery queued"
+//	@Failure		400			{object}	FailureResponse	"Delivery is pending already"
+//	@Failure		404			{object}	FailureResponse	"Webhook or delivery not found"
+//	@Failure		500			{object}	FailureResponse	"Internal error"
+//	@Router			/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (rc *WebhookHandlers) Redeliver(c echo.Context) error {
+	id := c.Param("id")
+	deliveryID := c.Param("delivery_id")
+
+	if _, err := rc.webhookUC.Redeliver(c.Request().Context(), id, deliveryID); err != nil {
+		return handleEchoError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, SuccessResponse{
+		Message: "Delivery queued",
+	})
+}
@@ -199,14 +199,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/connects": {
+        "/blocks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a filtered and paginated list of connects requests based on query parameters.",
+                "description": "Returns the users blocked by the owner, newest first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -214,44 +214,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "connects"
+                    "blocks"
                 ],
-                "summary": "ConnectsRequests list all connects requests",
+                "summary": "List blocked users",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter connects by status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter connects by user id if you are admin",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Limit the number of connects returned",
+                        "description": "Limit the number of users returned",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Number of connects to skip for pagination",
+                        "description": "Number of users to skip for pagination",
                         "name": "skip",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response containing the list of connects",
+                        "description": "Blocked users retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/controller.SuccessResponse"
+                            "$ref": "#/definitions/controller.SuccessListResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Blocked users retrieval failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -264,7 +252,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint creates a new connection by binding the incoming JSON request to the ConnectCreateInput model.",
+                "description": "This endpoint blocks a user. Any connection between the users is removed, the blocked user cannot send connection requests and both users are hidden from each other in searches, feeds and event listings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -272,25 +260,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "connects"
+                    "blocks"
                 ],
-                "summary": "Create creates a new connection",
+                "summary": "Block a user",
                 "parameters": [
                     {
-                        "description": "Connect creation input",
+                        "description": "Block input",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ConnectCreateInput"
+                            "$ref": "#/definitions/model.BlockCreateInput"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Connect created successfully",
+                        "description": "User blocked successfully",
                         "schema": {
-                            "$ref": "#/definitions/controller.SuccessResponse"
+                            "$ref": "#/definitions/controller.SuccessListResponse"
                         }
                     },
                     "400": {
@@ -300,7 +288,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Connect creation failed",
+                        "description": "Block failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -308,14 +296,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/connects/{id}": {
+        "/blocks/{user_id}": {
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint deletes a connection by binding the incoming JSON request to the ConnectUpdateInput model.",
+                "description": "This endpoint removes a block of the owner. The removed connection is not restored.",
                 "consumes": [
                     "application/json"
                 ],
@@ -323,46 +311,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "connects"
+                    "blocks"
                 ],
-                "summary": "Delete deletes an existing connection",
+                "summary": "Unblock a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Connection ID to delete",
-                        "name": "id",
+                        "description": "Blocked user ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Connect deleted successfully",
+                        "description": "User unblocked successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "User is not blocked",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
                     "500": {
-                        "description": "Connect update failed",
+                        "description": "Unblock failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/co-owners": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint updates a connection by binding the incoming JSON request to the ConnectUpdateInput model.",
+                "description": "Returns the events and eras the owner is invited to co-own, filter by status=100 for the invitations waiting for an answer.",
                 "consumes": [
                     "application/json"
                 ],
@@ -370,42 +360,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "connects"
+                    "co-owners"
                 ],
-                "summary": "Update updates an existing connection",
+                "summary": "List the owners co-owner invitations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Connection ID to update,approved:101, rejected:102",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "description": "Connect update input",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ConnectUpdateInput"
-                        }
+                        "type": "string",
+                        "description": "Limit the number of invitations returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of invitations to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Connect updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/controller.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request data",
+                        "description": "Invitations retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/controller.FailureResponse"
+                            "$ref": "#/definitions/controller.SuccessListResponse"
                         }
                     },
                     "500": {
-                        "description": "Connect update failed",
+                        "description": "Invitation retrieval failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -413,9 +399,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/eras": {
-            "get": {
-                "description": "This endpoint retrieves a list of eras.",
+        "/co-owners/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint approves (101) or rejects (102) an invitation of the owner. Rejected invitations are removed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -423,56 +414,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "eras"
+                    "co-owners"
                 ],
-                "summary": "Retrieve a list of eras",
+                "summary": "Answer a co-owner invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "Bearer \u003cAdd access token here\u003e",
-                        "description": "Insert your access token",
-                        "name": "Authorization",
-                        "in": "header"
-                    },
-                    {
-                        "type": "string",
-                        "example": "eq:test",
-                        "description": "Filter eras by name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "eq:1",
-                        "description": "Filter eras by user id",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Limit the number of connects returned",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Number of connects to skip for pagination",
-                        "name": "skip",
-                        "in": "query"
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "example": "desc:created_at",
-                        "description": "Order by column (prefix with asc: or desc:)",
-                        "name": "order",
-                        "in": "query"
+                        "description": "Invitation answer",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CoOwnerUpdateInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Eras retrieved successfully",
+                        "description": "Invitation answered successfully",
                         "schema": {
-                            "$ref": "#/definitions/controller.SuccessListResponse"
+                            "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
                     "400": {
@@ -481,21 +448,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
+                    "403": {
+                        "description": "Invitation belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Era retrieval failed",
+                        "description": "Invitation update failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/comments/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint creates a new era by binding the incoming JSON request to the EraCreateInput model.",
+                "description": "This endpoint deletes a comment with its replies. Authors can delete their comments and event owners any comment on their events.",
                 "consumes": [
                     "application/json"
                 ],
@@ -503,50 +478,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "eras"
+                    "comments"
                 ],
-                "summary": "Create a new era",
+                "summary": "Delete a comment",
                 "parameters": [
                     {
-                        "description": "Era creation input",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.EraCreateInput"
-                        }
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Era created successfully",
+                    "200": {
+                        "description": "Comment deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
+                    "403": {
+                        "description": "Not allowed to delete the comment",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
                     "500": {
-                        "description": "Era creation failed",
+                        "description": "Comment delete failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            }
-        },
-        "/eras/{id}": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Fetches an era by its unique name or UID from the database.",
+                "description": "This endpoint edits the body of one of the owners comments.",
                 "consumes": [
                     "application/json"
                 ],
@@ -554,21 +525,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "eras"
+                    "comments"
                 ],
-                "summary": "Retrieve era by ID",
+                "summary": "Edit a comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Era name or UID",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Comment update input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentUpdateInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Era retrieved successfully",
+                        "description": "Comment updated successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
@@ -579,21 +559,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
+                    "403": {
+                        "description": "Comment belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Era retrieval failed",
+                        "description": "Comment update failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/connects": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint deletes an existing era by providing era name or UID.",
+                "description": "Retrieves a filtered and paginated list of connects requests based on query parameters.",
                 "consumes": [
                     "application/json"
                 ],
@@ -601,46 +589,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "eras"
+                    "connects"
                 ],
-                "summary": "Delete an existing era",
+                "summary": "ConnectsRequests list all connects requests",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Era name or UID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter connects by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter connects by user id if you are admin",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of connects returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of connects to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Era deleted successfully",
+                        "description": "Successful response containing the list of connects",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
-                        "schema": {
-                            "$ref": "#/definitions/controller.FailureResponse"
-                        }
-                    },
                     "500": {
-                        "description": "Era delete failed",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint updates an existing era by binding the incoming JSON request to the EraUpdateInput model.",
+                "description": "This endpoint creates a new connection by binding the incoming JSON request to the ConnectCreateInput model.",
                 "consumes": [
                     "application/json"
                 ],
@@ -648,23 +647,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "eras"
+                    "connects"
                 ],
-                "summary": "Update an existing era",
+                "summary": "Create creates a new connection",
                 "parameters": [
                     {
-                        "description": "Era update input",
+                        "description": "Connect creation input",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.EraUpdateInput"
+                            "$ref": "#/definitions/model.ConnectCreateInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Era updated successfully",
+                    "201": {
+                        "description": "Connect created successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
@@ -675,136 +674,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
-                    "500": {
-                        "description": "Era update failed",
+                    "403": {
+                        "description": "User is blocked",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/events": {
-            "get": {
-                "description": "This endpoint retrieves a list of events.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "events"
-                ],
-                "summary": "Retrieve a list of events",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "Bearer \u003cAdd access token here\u003e",
-                        "description": "Insert your access token",
-                        "name": "Authorization",
-                        "in": "header"
-                    },
-                    {
-                        "type": "string",
-                        "example": "eq:1",
-                        "description": "Filter events by user id, returns owners events if not provided",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "eq:1",
-                        "description": "Filter events by visibility status (public:1, private:2, just me:3)",
-                        "name": "visibility",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "10",
-                        "description": "Limit the number of events returned",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "0",
-                        "description": "Number of events to skip for pagination",
-                        "name": "skip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "desc:created_at",
-                        "description": "Order by column (prefix with asc: or desc:)",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Events retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/controller.SuccessListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request data",
-                        "schema": {
-                            "$ref": "#/definitions/controller.FailureResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Event retrieval failed",
-                        "schema": {
-                            "$ref": "#/definitions/controller.FailureResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "This endpoint creates a new event by binding the incoming JSON request to the EventCreateInput model.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "events"
-                ],
-                "summary": "Create a new event",
-                "parameters": [
-                    {
-                        "description": "Event creation input",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.EventCreateInput"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Event created successfully",
-                        "schema": {
-                            "$ref": "#/definitions/controller.SuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Invalid request data",
+                    "409": {
+                        "description": "Already connected or requested",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
                     "500": {
-                        "description": "Event creation failed",
+                        "description": "Connect creation failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -812,14 +695,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{id}": {
+        "/connects/suggestions": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Fetches an event by its unique name or UID from the database.",
+                "description": "Ranks users by mutual connections, events both users are tagged in or own, and imported contacts. Users already connected, with a pending request or blocked are left out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -827,46 +710,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "connects"
                 ],
-                "summary": "Retrieve event by ID",
+                "summary": "Suggestions lists people the owner may know",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event name or UID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Limit the number of suggestions returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of suggestions to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event retrieved successfully",
+                        "description": "Successful response containing the list of suggestions",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
-                        "schema": {
-                            "$ref": "#/definitions/controller.FailureResponse"
-                        }
-                    },
                     "500": {
-                        "description": "Event retrieval failed",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/connects/{id}": {
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint deletes an existing event by providing event name or UID.",
+                "description": "This endpoint deletes a connection by binding the incoming JSON request to the ConnectUpdateInput model.",
                 "consumes": [
                     "application/json"
                 ],
@@ -874,13 +758,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "connects"
                 ],
-                "summary": "Delete an existing event",
+                "summary": "Delete deletes an existing connection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event name or UID",
+                        "description": "Connection ID to delete",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -888,7 +772,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event deleted successfully",
+                        "description": "Connect deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
@@ -900,7 +784,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Event delete failed",
+                        "description": "Connect update failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -913,7 +797,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint updates an existing event by binding the incoming JSON request to the EventUpdateInput model.",
+                "description": "This endpoint answers or cancels a pending connection request by binding the incoming JSON request to the ConnectUpdateInput model. Rejected and cancelled requests are deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -921,23 +805,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "connects"
                 ],
-                "summary": "Update an existing event",
+                "summary": "Update updates an existing connection",
                 "parameters": [
                     {
-                        "description": "Event update input",
+                        "type": "string",
+                        "description": "Connection ID to update, approved:101 and rejected:102 by the receiver, cancelled:103 by the sender",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Connect update input",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.EventUpdateInput"
+                            "$ref": "#/definitions/model.ConnectUpdateInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event updated successfully",
+                        "description": "Connect updated successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
@@ -949,7 +840,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Event update failed",
+                        "description": "Connect update failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -957,14 +848,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications": {
+        "/contacts": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a filtered and paginated list of notifications based on query parameters.",
+                "description": "Returns the imported contacts of the owner ordered by name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -972,59 +863,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "contacts"
                 ],
-                "summary": "List lists all notifications",
+                "summary": "List imported contacts",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter notifications by user id if you are admin",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter notifications by read status",
-                        "name": "read",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Limit the number of notifications returned",
+                        "description": "Limit the number of contacts returned",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Number of notifications to skip for pagination",
+                        "description": "Number of contacts to skip for pagination",
                         "name": "skip",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response containing the list of notifications",
+                        "description": "Contacts retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/controller.SuccessResponse"
+                            "$ref": "#/definitions/controller.SuccessListResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Contacts retrieval failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            }
-        },
-        "/notifications/{id}": {
-            "patch": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint updates a notification by binding the incoming JSON request to the NotificationUpdateInput model.",
+                "description": "This endpoint imports up to 1000 contacts of the owner. Contacts are only used to suggest connections, importing an email again updates its name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1032,30 +909,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "contacts"
                 ],
-                "summary": "Update updates an existing notification",
+                "summary": "Import contacts",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Notification ID to update",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Notification update input",
+                        "description": "Contacts to import",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.NotificationUpdateInput"
+                            "$ref": "#/definitions/model.ContactImportInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Notification updated successfully",
+                    "201": {
+                        "description": "Contacts imported successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
@@ -1067,7 +937,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Notification update failed",
+                        "description": "Contacts import failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -1075,9 +945,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/oauth/google/callback": {
-            "post": {
-                "description": "This endpoint handles the Google OAuth callback and creates or logs in the user.",
+        "/contacts/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint deletes an imported contact of the owner.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1085,35 +960,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "oauth"
+                    "contacts"
                 ],
-                "summary": "Google OAuth callback",
+                "summary": "Delete a contact",
                 "parameters": [
                     {
-                        "description": "Google OAuth code",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.GoogleAuthRequest"
-                        }
+                        "type": "string",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully authenticated with JWT token",
+                        "description": "Contact deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/controller.AuthResponse"
+                            "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Error message including details on failure",
+                        "description": "Invalid request data",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Contact deletion failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -1121,9 +994,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/oauth/google/url": {
+        "/eras": {
             "get": {
-                "description": "This endpoint returns the Google OAuth authorization URL.",
+                "description": "This endpoint retrieves a list of eras.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1131,17 +1004,3506 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "oauth"
+                    "eras"
+                ],
+                "summary": "Retrieve a list of eras",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:test",
+                        "description": "Filter eras by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:1",
+                        "description": "Filter eras by user id",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "gte:2020-01-01",
+                        "description": "Filter eras by start time, btw takes an inclusive from,to range",
+                        "name": "time_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "lte:2020-12-31",
+                        "description": "Filter eras by end time",
+                        "name": "time_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of connects returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of connects to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "desc:created_at",
+                        "description": "Order by column (prefix with asc: or desc:)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Eras retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Era retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint creates a new era by binding the incoming JSON request to the EraCreateInput model.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "eras"
+                ],
+                "summary": "Create a new era",
+                "parameters": [
+                    {
+                        "description": "Era creation input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EraCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Era created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Era creation failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/eras/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetches an era by its unique name or UID from the database.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "eras"
+                ],
+                "summary": "Retrieve era by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Era name or UID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Era retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Era retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint deletes an existing era by providing era name or UID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "eras"
+                ],
+                "summary": "Delete an existing era",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Era name or UID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Era deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Era delete failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint updates an existing era by binding the incoming JSON request to the EraUpdateInput model.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "eras"
+                ],
+                "summary": "Update an existing era",
+                "parameters": [
+                    {
+                        "description": "Era update input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EraUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Era updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Era update failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/eras/{id}/owners": {
+            "get": {
+                "description": "Returns the co-owners of an era. Pending invitations are listed only to the owners of the era.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "co-owners"
+                ],
+                "summary": "List co-owners of an era",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Era ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of co-owners returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of co-owners to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Co-owners retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Co-owner retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint invites one of the owners connections to co-own an era. Owners and co-owners can invite, the invited user gets notified and can edit and delete the era once approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "co-owners"
+                ],
+                "summary": "Invite a co-owner to an era",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Era ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Co-owner invitation input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CoOwnerCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Co-owner invited successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "User is not a connection",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only owners can invite",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already invited",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Invitation failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/eras/{id}/owners/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint removes a co-owner or invitation from an era. The owner can remove anyone, co-owners only themselves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "co-owners"
+                ],
+                "summary": "Remove a co-owner from an era",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Era ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Co-owner user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Co-owner removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to remove the co-owner",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is not a co-owner",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Co-owner remove failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "This endpoint retrieves a list of events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Retrieve a list of events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:1",
+                        "description": "Filter events by user id, returns owners events if not provided",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:1",
+                        "description": "Filter events by visibility status (public:1, private:2, just me:3)",
+                        "name": "visibility",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "like:trip",
+                        "description": "Filter events by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "btw:2020-01-01,2020-12-31",
+                        "description": "Filter events by date, btw takes an inclusive from,to range",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "gte:2020-01-01",
+                        "description": "Filter events by start time",
+                        "name": "time_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "lte:2020-12-31",
+                        "description": "Filter events by end time",
+                        "name": "time_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:true",
+                        "description": "Filter the owners events by draft state",
+                        "name": "draft",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "travel,career",
+                        "description": "Filter events having any of the comma separated tag names, nin excludes them",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "summer holiday",
+                        "description": "Full-text search over name and description, ranked by relevance",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "10",
+                        "description": "Limit the number of events returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "0",
+                        "description": "Number of events to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "desc:created_at",
+                        "description": "Order by column (prefix with asc: or desc:)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Event retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint creates a new event by binding the incoming JSON request to the EventCreateInput model.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Create a new event",
+                "parameters": [
+                    {
+                        "description": "Event creation input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EventCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Event created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Event creation failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/on-this-day": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the owners events from the same calendar day in earlier years, newest first. The day defaults to today in the timezone of the owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "On this day memories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2025-06-15",
+                        "description": "Day to look back from",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Event retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetches an event by its unique name or UID from the database.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Retrieve event by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event name or UID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Event is not visible to the user",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Event retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint deletes an existing event by providing event name or UID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Delete an existing event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event name or UID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Event delete failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint changes only the fields sent in the EventUpdateInput model, so drafts can be autosaved. Null clears a time, publish_at schedules publishing a draft.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Update an existing event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event update input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EventUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event was published or opened meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Event update failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/comments": {
+            "get": {
+                "description": "Returns the top level comments of an event visible to the owner, or the replies of a comment when parent_id is set, oldest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List comments of an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the replies of this comment",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of comments returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of comments to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comments retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Event is not visible",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Comment retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint comments on an event visible to the owner, or replies to one of its comments when parent_id is set. The event owner gets notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment creation input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Comment created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Event is not visible",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Comment creation failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/mentions": {
+            "get": {
+                "description": "Returns the users tagged in an event visible to the owner. Pending and rejected tags are listed only to the event owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mentions"
+                ],
+                "summary": "List users tagged in an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of mentions returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of mentions to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mentions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Event is not visible",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Mention retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint tags one of the owners connections in one of the owners events. The tagged user gets notified and has to approve the tag before the event shows up on their timeline.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mentions"
+                ],
+                "summary": "Tag a connection in an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mention creation input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MentionCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User tagged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "User is not a connection",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Event belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already tagged",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tagging failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/mentions/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint removes the tag of a user from an event. Event owners can remove the tags in their events, tagged users their own tags.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mentions"
+                ],
+                "summary": "Remove a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tagged user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to remove the tag",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is not tagged",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tag remove failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/owners": {
+            "get": {
+                "description": "Returns the co-owners of an event visible to the owner. Pending invitations are listed only to the owners of the event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "co-owners"
+                ],
+                "summary": "List co-owners of an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of co-owners returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of co-owners to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Co-owners retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Event is not visible",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Co-owner retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint invites one of the owners connections to co-own an event. Owners and co-owners can invite, the invited user gets notified and can edit and delete the event once approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "co-owners"
+                ],
+                "summary": "Invite a co-owner to an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Co-owner invitation input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CoOwnerCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Co-owner invited successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "User is not a connection",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only owners can invite",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already invited",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Invitation failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/owners/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint removes a co-owner or invitation from an event. The owner can remove anyone, co-owners only themselves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "co-owners"
+                ],
+                "summary": "Remove a co-owner from an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Co-owner user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Co-owner removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to remove the co-owner",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is not a co-owner",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Co-owner remove failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint makes a draft of the owner visible, the connections of the owner are notified unless it is a just me event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Publish a draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event published successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Draft not found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Event publish failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/reactions": {
+            "get": {
+                "description": "Returns who reacted to an event visible to the owner, newest first. Counts per emoji are part of the event itself.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "List reactions of an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by emoji",
+                        "name": "emoji",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of reactions returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of reactions to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reactions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Event is not visible",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Reaction retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint reacts to an event visible to the owner with one of 👍 ❤️ 😂 😮 😢 👏. Reacting again replaces the earlier reaction. The event owner gets a batched notification.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "React to an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reaction input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReactionSetInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reaction set successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Event is not visible",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Reaction failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint removes the owners reaction from an event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "Remove a reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reaction removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "No reaction on the event",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Reaction remove failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the recent public and connections only events of all approved connections of the owner and the public events of the users the owner follows, newest first. Muted and blocked users are left out. Pass next_cursor of the response as cursor to get the next page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feed"
+                ],
+                "summary": "Friends feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "20",
+                        "description": "Maximum number of events returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed retrieved successfully, data is the feed and total its number of events",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cursor",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Feed retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/follows": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint follows a user without an approval. The public events of followed users show up in the feed and the followed user gets notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "follows"
+                ],
+                "summary": "Follow a user",
+                "parameters": [
+                    {
+                        "description": "Follow input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FollowCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User followed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is blocked",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already followed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Follow failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/follows/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint unfollows a user followed by the owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "follows"
+                ],
+                "summary": "Unfollow a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Followed user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unfollowed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "User is not followed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unfollow failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mentions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the events the owner is tagged in, filter by status=100 for the tags waiting for approval.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mentions"
+                ],
+                "summary": "List the owners tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of mentions returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of mentions to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mentions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Mention retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mentions/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint approves (101) or rejects (102) a tag of the owner. Approved events show up on the owners timeline as shared memories, rejecting an approved tag removes it again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mentions"
+                ],
+                "summary": "Approve or reject a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Mention ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mention update input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MentionUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mention updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Mention belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Mention update failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mutes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the users muted by the owner, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocks"
+                ],
+                "summary": "List muted users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Limit the number of users returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of users to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Muted users retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Muted users retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint hides the events of a user from the feed of the owner. The muted user is not told and the connection stays as it is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocks"
+                ],
+                "summary": "Mute a user",
+                "parameters": [
+                    {
+                        "description": "Mute input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MuteCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User muted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Mute failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mutes/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint shows the events of a muted user in the feed of the owner again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocks"
+                ],
+                "summary": "Unmute a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Muted user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unmuted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "User is not muted",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unmute failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a filtered and paginated list of notifications based on query parameters. Messages are rendered in the language of the user and link is the path of the ui page the notification leads to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "List lists all notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter notifications by user id if you are admin",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter notifications by read status",
+                        "name": "read",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of notifications returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of notifications to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response containing the list of notifications",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint deletes the given notifications of the owner, ids of notifications of other users are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "DeleteMany deletes notifications at once",
+                "parameters": [
+                    {
+                        "description": "Notification ids to delete",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationDeleteInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Notification deletion failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint returns the channel the owner chose for each notification type, their quiet hours and daily digest option. Types left out of channels are delivered in app.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "GetPreferences returns notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "Notification preferences, data is the preferences",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint sets for each notification type whether it is delivered in app, by email or not at all, plus quiet hours and the daily digest. Emails are held back in quiet hours, the daily digest sends them together once a day.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "UpdatePreferences updates notification preferences",
+                "parameters": [
+                    {
+                        "description": "Notification preferences",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationPreferencesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification preferences updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint marks every unread notification of the owner as read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "MarkAllRead marks all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "Notifications marked as read successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Notification update failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of the notifications of the owner as they are created, each event id is the notification id.\nOn reconnect the notifications created after the Last-Event-ID header or the last_event_id query param are replayed first.\nThe stream is opened with a ticket from POST /notifications/stream/ticket, a ticket opens one stream only.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Stream streams new notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stream ticket",
+                        "name": "ticket",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last notification received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last notification received",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of notifications",
+                        "schema": {
+                            "$ref": "#/definitions/model.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid last event id",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired stream ticket",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/stream/ticket": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "EventSource cannot set the Authorization header, so the stream is opened with a ticket instead of the token.\nA ticket opens one stream and expires after 30 seconds, take a new one for every reconnect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "StreamTicket creates a notification stream ticket",
+                "responses": {
+                    "201": {
+                        "description": "Stream ticket, data is the ticket",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the number of unread notifications of the owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "UnreadCount counts unread notifications",
+                "responses": {
+                    "200": {
+                        "description": "Number of unread notifications, data is the count",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint deletes a notification of the owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Delete deletes a notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Notification of another user",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Notification deletion failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint updates a notification by binding the incoming JSON request to the NotificationUpdateInput model.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update updates an existing notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification update input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Notification update failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/google/callback": {
+            "post": {
+                "description": "This endpoint handles the Google OAuth callback and creates or logs in the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Google OAuth callback",
+                "parameters": [
+                    {
+                        "description": "Google OAuth code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.GoogleAuthRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully authenticated with JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/controller.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error message including details on failure",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/google/url": {
+            "get": {
+                "description": "This endpoint returns the Google OAuth authorization URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Get Google OAuth URL",
+                "responses": {
+                    "200": {
+                        "description": "Google OAuth URL",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/linkedin/callback": {
+            "post": {
+                "description": "This endpoint handles the LinkedIn OAuth callback and creates or logs in the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "LinkedIn OAuth callback",
+                "parameters": [
+                    {
+                        "description": "LinkedIn OAuth code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LinkedInAuthRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully authenticated with JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/controller.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error message including details on failure",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/linkedin/url": {
+            "get": {
+                "description": "This endpoint returns the LinkedIn OAuth authorization URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Get LinkedIn OAuth URL",
+                "responses": {
+                    "200": {
+                        "description": "LinkedIn OAuth URL",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/outbox/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the domain events the outbox gave up delivering after running out of attempts, latest first. last_error tells why the last attempt failed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "outbox"
+                ],
+                "summary": "List dead letters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Limit the number of dead letters returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of dead letters to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dead letters",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/outbox/dead-letters/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint moves a dead letter back to the outbox with its attempts reset. Handlers that delivered it before are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "outbox"
+                ],
+                "summary": "Retry a dead letter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dead letter queued for delivery",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dead letter not found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/push/public-key": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the VAPID public key browsers pass as applicationServerKey when they subscribe to web push.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "push"
+                ],
+                "summary": "VAPID public key",
+                "responses": {
+                    "200": {
+                        "description": "VAPID public key, data is the key",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Web push is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/push/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the browsers of the owner subscribed to web push.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "push"
+                ],
+                "summary": "List push subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "Push subscriptions",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint stores the PushSubscription json of a browser of the owner. Notifications are pushed to every subscribed browser, subscribing again from the same browser replaces its keys.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "push"
+                ],
+                "summary": "Subscribe to web push",
+                "parameters": [
+                    {
+                        "description": "PushSubscription of the browser",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PushSubscriptionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscribed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Subscription failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/push/subscriptions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint deletes a push subscription of the owner, the browser gets no more notifications.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "push"
+                ],
+                "summary": "Unsubscribe from web push",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unsubscribed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unsubscription failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns insights over the owners timeline: events per period, media counts by item type, the longest era, gaps without events, visibility breakdown, most used tags and connection growth.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Life statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stats of user id if you are admin",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "month",
+                        "description": "Period to group counts by, year or month",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stats retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Stats of another user",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Stats retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a filtered and paginated list of tags based on query parameters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List lists the owners tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "like:trav",
+                        "description": "Filter tags by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter tags by user id if you are admin",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of tags returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of tags to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "asc:name",
+                        "description": "Order by column (prefix with asc: or desc:)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tag retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint creates a new tag for the owner by binding the incoming JSON request to the TagCreateInput model.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create creates a new tag",
+                "parameters": [
+                    {
+                        "description": "Tag creation input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Tag created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tag already exists",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tag creation failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/autocomplete": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the owners tags containing the query with their usage counts, most used first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Autocomplete tag names",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "trav",
+                        "description": "Part of the tag name",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tag retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/counts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the owners tags with the number of events they are attached to, most used first, for building a tag cloud.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Tag usage counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Count tags of user id if you are admin",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of tags returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of tags to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag counts retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tag count failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetches one of the owners tags by its ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Retrieve tag by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Tag belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tag retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint deletes a tag and detaches it from all events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete deletes an existing tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tag delete failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint updates a tag by binding the incoming JSON request to the TagUpdateInput model.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update updates an existing tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag update input",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tag already exists",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tag update failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timeline": {
+            "get": {
+                "description": "Returns the events and eras of a user merged and grouped by year or month, with per bucket counts. Events follow the same visibility rules as the event list, eras are placed in the bucket they start in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeline"
+                ],
+                "summary": "Retrieve a timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1",
+                        "description": "User id, returns owners timeline if not provided",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2015-01-01",
+                        "description": "Start of the time range (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-12-31",
+                        "description": "End of the time range (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "year",
+                        "description": "Bucket size, year or month",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "desc",
+                        "description": "Bucket order, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "12",
+                        "description": "Maximum number of buckets returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Return only per bucket counts without events and eras",
+                        "name": "counts_only",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timeline retrieved successfully, data is the timeline and total its number of buckets",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Timeline retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint allows a user to update their password by providing their current password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update password",
+                "parameters": [
+                    {
+                        "description": "Password update input",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePasswordRequest"
+                        }
+                    }
                 ],
-                "summary": "Get Google OAuth URL",
                 "responses": {
                     "200": {
-                        "description": "Google OAuth URL",
+                        "description": "Password updated successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error message including details on failure",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
                     "500": {
@@ -1153,9 +4515,224 @@ const docTemplate = `{
                 }
             }
         },
-        "/oauth/linkedin/callback": {
+        "/user/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint returns the timezone, digest email frequency and language of the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get preferences",
+                "responses": {
+                    "200": {
+                        "description": "Preferences retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint allows a user to set their timezone and language and to opt in to weekly or monthly digest emails. Notifications are rendered in the language, it is kept as it is if left empty.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences update input",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preferences updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error message including details on failure",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/username": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint allows a user to update their username.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update username",
+                "parameters": [
+                    {
+                        "description": "Username update input",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateUsernameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Username updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error message including details on failure",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a filtered and paginated list of users from the database based on query parameters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List all users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "eq:test",
+                        "description": "Filter users by username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:test@test.com",
+                        "description": "Filter users by email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:1",
+                        "description": "Filter users by role ID",
+                        "name": "role_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "10",
+                        "description": "Limit the number of users returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "0",
+                        "description": "Number of users to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "desc:created_at",
+                        "description": "Order by column (prefix with asc: or desc:)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response containing the list of users",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Interval error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "This endpoint handles the LinkedIn OAuth callback and creates or logs in the user.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint creates a new user by providing username, email, password, and role ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1163,25 +4740,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "oauth"
+                    "users"
                 ],
-                "summary": "LinkedIn OAuth callback",
+                "summary": "Create creates a new user",
                 "parameters": [
                     {
-                        "description": "LinkedIn OAuth code",
+                        "description": "User creation input",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.LinkedInAuthRequest"
+                            "$ref": "#/definitions/model.UserCreateInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully authenticated with JWT token",
+                    "201": {
+                        "description": "user username",
                         "schema": {
-                            "$ref": "#/definitions/controller.AuthResponse"
+                            "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
                     "400": {
@@ -1191,7 +4768,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Interval error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -1199,9 +4776,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/oauth/linkedin/url": {
+        "/users/search": {
             "get": {
-                "description": "This endpoint returns the LinkedIn OAuth authorization URL.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a filtered and paginated list of users from the database based on query parameters.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1209,21 +4791,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "oauth"
+                    "users"
+                ],
+                "summary": "Search all users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "eq:test",
+                        "description": "Filter users by username",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "10",
+                        "description": "Limit the number of users returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "0",
+                        "description": "Number of users to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "desc:created_at",
+                        "description": "Order by column (prefix with asc: or desc:)",
+                        "name": "order",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get LinkedIn OAuth URL",
                 "responses": {
                     "200": {
-                        "description": "LinkedIn OAuth URL",
+                        "description": "Successful response containing the list of users",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Interval error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -1231,14 +4840,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/password": {
-            "put": {
+        "/users/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint allows a user to update their password by providing their current password.",
+                "description": "Fetches a user by their unique ID from the database.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1248,48 +4857,70 @@ const docTemplate = `{
                 "tags": [
                     "users"
                 ],
-                "summary": "Update password",
+                "summary": "Retrieve user by ID",
                 "parameters": [
                     {
-                        "description": "Password update input",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdatePasswordRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Password updated successfully",
+                        "description": "Successful response containing the user information",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Error message including details on failure",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "This endpoint deletes a user by providing user id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "DeleteUser deletes an existing user",
+                "responses": {
+                    "200": {
+                        "description": "user username",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessResponse"
+                        }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Interval error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            }
-        },
-        "/user/username": {
-            "put": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint allows a user to update their username.",
+                "description": "This endpoint updates a user by providing username, email, password, and role ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1299,21 +4930,21 @@ const docTemplate = `{
                 "tags": [
                     "users"
                 ],
-                "summary": "Update username",
+                "summary": "Update updates an existing user",
                 "parameters": [
                     {
-                        "description": "Username update input",
+                        "description": "User update input",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateUsernameRequest"
+                            "$ref": "#/definitions/model.UserCreateInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Username updated successfully",
+                        "description": "user username",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
@@ -1325,7 +4956,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Interval error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -1333,14 +4964,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/users/{id}/followers": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves a filtered and paginated list of users from the database based on query parameters.",
+                "description": "Returns the followers of a user, newest first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1348,62 +4974,149 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "follows"
                 ],
-                "summary": "List all users",
+                "summary": "List followers of a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "eq:test",
-                        "description": "Filter users by username",
-                        "name": "username",
-                        "in": "query"
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "example": "eq:test@test.com",
-                        "description": "Filter users by email",
-                        "name": "email",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of followers returned",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "eq:1",
-                        "description": "Filter users by role ID",
-                        "name": "role_id",
+                        "description": "Number of followers to skip for pagination",
+                        "name": "skip",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Followers retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is blocked",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Followers retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/following": {
+            "get": {
+                "description": "Returns the users followed by a user, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "follows"
+                ],
+                "summary": "List users a user follows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "example": "10",
                         "description": "Limit the number of users returned",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "0",
                         "description": "Number of users to skip for pagination",
                         "name": "skip",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Followed users retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is blocked",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Followed users retrieval failed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "example": "desc:created_at",
-                        "description": "Order by column (prefix with asc: or desc:)",
-                        "name": "order",
-                        "in": "query"
+                        "ApiKeyAuth": []
                     }
                 ],
+                "description": "Returns the webhooks of the owner. failure_count is the failed attempts in a row, disabled_at is set when the webhook was disabled for failing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
                 "responses": {
                     "200": {
-                        "description": "Successful response containing the list of users",
+                        "description": "Webhooks",
                         "schema": {
-                            "$ref": "#/definitions/controller.SuccessResponse"
+                            "$ref": "#/definitions/controller.SuccessListResponse"
                         }
                     },
                     "500": {
-                        "description": "Interval error",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -1416,7 +5129,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint creates a new user by providing username, email, password, and role ID.",
+                "description": "This endpoint registers an https endpoint the chosen events are posted to. The response holds the signing secret, it is not shown again. Every request carries X-Lifery-Event, X-Lifery-Delivery, X-Lifery-Timestamp and X-Lifery-Signature headers, the signature is sha256= followed by the hex HMAC-SHA256 of timestamp + \".\" + body with the secret.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1424,35 +5137,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Create creates a new user",
+                "summary": "Register a webhook",
                 "parameters": [
                     {
-                        "description": "User creation input",
-                        "name": "body",
+                        "description": "Webhook endpoint and events",
+                        "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserCreateInput"
+                            "$ref": "#/definitions/model.WebhookCreateInput"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "user username",
+                        "description": "Webhook with its signing secret",
                         "schema": {
-                            "$ref": "#/definitions/controller.SuccessResponse"
+                            "$ref": "#/definitions/model.WebhookCreated"
                         }
                     },
                     "400": {
-                        "description": "Error message including details on failure",
+                        "description": "Invalid request data",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
                     "500": {
-                        "description": "Interval error",
+                        "description": "Webhook registration failed",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -1460,14 +5173,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/search": {
-            "get": {
+        "/webhooks/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a filtered and paginated list of users from the database based on query parameters.",
+                "description": "This endpoint deletes a webhook of the owner with its delivery log.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1475,63 +5188,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Search all users",
+                "summary": "Delete a webhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "eq:test",
-                        "description": "Filter users by username",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "10",
-                        "description": "Limit the number of users returned",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "0",
-                        "description": "Number of users to skip for pagination",
-                        "name": "skip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "desc:created_at",
-                        "description": "Order by column (prefix with asc: or desc:)",
-                        "name": "order",
-                        "in": "query"
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response containing the list of users",
+                        "description": "Webhook deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Interval error",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Fetches a user by their unique ID from the database.",
+                "description": "This endpoint changes the url or events of a webhook of the owner. A webhook is disabled after failing too many times in a row, setting enabled to true turns it on again and sends its pending deliveries.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1539,40 +5235,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Retrieve user by ID",
+                "summary": "Update a webhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Webhook changes",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookUpdateInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response containing the user information",
+                        "description": "Webhook updated successfully",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint deletes a user by providing user id.",
+                "description": "Returns the delivery log of a webhook of the owner, latest first. Status is 100 pending, 101 succeeded or 102 failed after running out of attempts, response_status and response_body are the answer of the last attempt.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1580,31 +5299,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limit the number of deliveries returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of deliveries to skip for pagination",
+                        "name": "skip",
+                        "in": "query"
+                    }
                 ],
-                "summary": "DeleteUser deletes an existing user",
                 "responses": {
                     "200": {
-                        "description": "user username",
+                        "description": "Webhook deliveries",
                         "schema": {
-                            "$ref": "#/definitions/controller.SuccessResponse"
+                            "$ref": "#/definitions/controller.SuccessListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
                     "500": {
-                        "description": "Interval error",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "This endpoint updates a user by providing username, email, password, and role ID.",
+                "description": "This endpoint sends a succeeded or failed delivery of a webhook of the owner again with its attempts reset. It is signed with a new timestamp.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1612,35 +5360,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Update updates an existing user",
+                "summary": "Redeliver a webhook delivery",
                 "parameters": [
                     {
-                        "description": "User update input",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UserCreateInput"
-                        }
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "user username",
+                    "202": {
+                        "description": "Delivery queued",
                         "schema": {
                             "$ref": "#/definitions/controller.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Error message including details on failure",
+                        "description": "Delivery is pending already",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook or delivery not found",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
                     },
                     "500": {
-                        "description": "Interval error",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/controller.FailureResponse"
                         }
@@ -1702,6 +5461,66 @@ const docTemplate = `{
                 }
             }
         },
+        "model.BlockCreateInput": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CoOwnerCreateInput": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CoOwnerUpdateInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/model.RequestStatus"
+                }
+            }
+        },
+        "model.CommentCreateInput": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommentUpdateInput": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
         "model.ConnectCreateInput": {
             "type": "object",
             "required": [
@@ -1710,6 +5529,10 @@ const docTemplate = `{
             "properties": {
                 "friend_id": {
                     "type": "string"
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 500
                 }
             }
         },
@@ -1724,6 +5547,90 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ContactImportInput": {
+            "type": "object",
+            "required": [
+                "contacts"
+            ],
+            "properties": {
+                "contacts": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.ContactInput"
+                    }
+                }
+            }
+        },
+        "model.ContactInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DigestFrequency": {
+            "type": "string",
+            "enum": [
+                "none",
+                "weekly",
+                "monthly"
+            ],
+            "x-enum-varnames": [
+                "DigestFrequencyNone",
+                "DigestFrequencyWeekly",
+                "DigestFrequencyMonthly"
+            ]
+        },
+        "model.DomainEventType": {
+            "type": "string",
+            "enum": [
+                "connect.requested",
+                "connect.approved",
+                "connect.rejected",
+                "connect.cancelled",
+                "connect.expired",
+                "event.created",
+                "event.updated",
+                "event.published",
+                "event.unlocked",
+                "era.created",
+                "comment.created",
+                "mention.created",
+                "mention.approved",
+                "co_owner.invited",
+                "co_owner.approved",
+                "follow.created",
+                "push.queued"
+            ],
+            "x-enum-varnames": [
+                "DomainEventConnectRequested",
+                "DomainEventConnectApproved",
+                "DomainEventConnectRejected",
+                "DomainEventConnectCancelled",
+                "DomainEventConnectExpired",
+                "DomainEventEventCreated",
+                "DomainEventEventUpdated",
+                "DomainEventEventPublished",
+                "DomainEventEventUnlocked",
+                "DomainEventEraCreated",
+                "DomainEventCommentCreated",
+                "DomainEventMentionCreated",
+                "DomainEventMentionApproved",
+                "DomainEventCoOwnerInvited",
+                "DomainEventCoOwnerApproved",
+                "DomainEventFollowCreated",
+                "DomainEventPushQueued"
+            ]
+        },
         "model.EraCreateInput": {
             "type": "object",
             "required": [
@@ -1766,9 +5673,6 @@ const docTemplate = `{
         },
         "model.EventCreateInput": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
                 "date": {
                     "type": "string"
@@ -1776,21 +5680,51 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
-                "items": {
+                "draft": {
+                    "description": "Draft keeps the event to the owner until it is published, a draft may have no name yet",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.EventItem"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "publish_at": {
+                    "description": "PublishAt schedules publishing, it makes the event a draft until then",
+                    "type": "string"
+                },
+                "recipient_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sealed": {
+                    "description": "Sealed makes the event a time capsule that opens at UnlockAt",
+                    "type": "boolean"
+                },
+                "sealed_for_owner": {
+                    "type": "boolean"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.EventItem"
+                        "type": "string"
                     }
                 },
-                "name": {
-                    "type": "string"
-                },
                 "time_end": {
                     "type": "string"
                 },
                 "time_start": {
                     "type": "string"
                 },
+                "unlock_at": {
+                    "type": "string"
+                },
                 "visibility": {
                     "$ref": "#/definitions/model.Visibility"
                 }
@@ -1824,12 +5758,10 @@ const docTemplate = `{
         },
         "model.EventUpdateInput": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
                 "date": {
-                    "type": "string"
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "description": {
                     "type": "string"
@@ -1843,17 +5775,58 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "publish_at": {
+                    "description": "PublishAt schedules publishing a draft, null unschedules it",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "recipient_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sealed": {
+                    "description": "only drafts can be sealed or get a new unlock time, published capsules can only be unsealed after they opened",
+                    "type": "boolean"
+                },
+                "sealed_for_owner": {
+                    "type": "boolean"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "time_end": {
-                    "type": "string"
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "time_start": {
-                    "type": "string"
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "unlock_at": {
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "visibility": {
                     "$ref": "#/definitions/model.Visibility"
                 }
             }
         },
+        "model.FollowCreateInput": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "model.ForgotPassword": {
             "type": "object",
             "required": [
@@ -1876,6 +5849,19 @@ const docTemplate = `{
                 }
             }
         },
+        "model.Language": {
+            "type": "string",
+            "enum": [
+                "tr",
+                "en",
+                "tr"
+            ],
+            "x-enum-varnames": [
+                "LanguageTurkish",
+                "LanguageEnglish",
+                "DefaultLanguage"
+            ]
+        },
         "model.LinkedInAuthRequest": {
             "type": "object",
             "required": [
@@ -1902,6 +5888,147 @@ const docTemplate = `{
                 }
             }
         },
+        "model.MentionCreateInput": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MentionUpdateInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/model.RequestStatus"
+                }
+            }
+        },
+        "model.MuteCreateInput": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "link": {
+                    "description": "Link is the path of the ui page the notification leads to",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message is rendered in the language of the user, notifications from before payloads keep their stored message",
+                    "type": "string"
+                },
+                "payload": {
+                    "$ref": "#/definitions/model.NotificationPayload"
+                },
+                "read": {
+                    "$ref": "#/definitions/model.NotificationStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/model.NotificationType"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NotificationChannel": {
+            "type": "string",
+            "enum": [
+                "in_app",
+                "email",
+                "none"
+            ],
+            "x-enum-varnames": [
+                "NotificationChannelInApp",
+                "NotificationChannelEmail",
+                "NotificationChannelNone"
+            ]
+        },
+        "model.NotificationDeleteInput": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.NotificationPayload": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "count": {
+                    "description": "Count is how many actions are batched in the notification, like reactions",
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_name": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "$ref": "#/definitions/model.NotificationTarget"
+                }
+            }
+        },
+        "model.NotificationPreferencesInput": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "description": "Channels holds the channel of each notification type, types left out are delivered in app",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.NotificationChannel"
+                    }
+                },
+                "daily_digest": {
+                    "type": "boolean"
+                },
+                "quiet_hours_end": {
+                    "type": "string",
+                    "example": "08:00"
+                },
+                "quiet_hours_start": {
+                    "description": "QuietHoursStart and QuietHoursEnd are set together or left empty to turn quiet hours off",
+                    "type": "string",
+                    "example": "22:00"
+                }
+            }
+        },
         "model.NotificationStatus": {
             "type": "integer",
             "enum": [
@@ -1913,6 +6040,56 @@ const docTemplate = `{
                 "NotificationStatusRead"
             ]
         },
+        "model.NotificationTarget": {
+            "type": "string",
+            "enum": [
+                "user",
+                "connect",
+                "event",
+                "era"
+            ],
+            "x-enum-varnames": [
+                "NotificationTargetUser",
+                "NotificationTargetConnect",
+                "NotificationTargetEvent",
+                "NotificationTargetEra"
+            ]
+        },
+        "model.NotificationType": {
+            "type": "string",
+            "enum": [
+                "connect_request",
+                "connect_response",
+                "connect_rejected",
+                "connect_cancelled",
+                "connect_expired",
+                "event_comment",
+                "event_reaction",
+                "event_mention",
+                "event_mention_approved",
+                "co_owner_request",
+                "co_owner_approved",
+                "new_follower",
+                "event_published",
+                "capsule_opened"
+            ],
+            "x-enum-varnames": [
+                "NotificationTypeConnectRequest",
+                "NotificationTypeConnectApproved",
+                "NotificationTypeConnectRejected",
+                "NotificationTypeConnectCancelled",
+                "NotificationTypeConnectExpired",
+                "NotificationTypeEventComment",
+                "NotificationTypeEventReaction",
+                "NotificationTypeEventMention",
+                "NotificationTypeMentionApproved",
+                "NotificationTypeCoOwnerRequest",
+                "NotificationTypeCoOwnerApproved",
+                "NotificationTypeNewFollower",
+                "NotificationTypeEventPublished",
+                "NotificationTypeCapsuleOpened"
+            ]
+        },
         "model.NotificationUpdateInput": {
             "type": "object",
             "properties": {
@@ -1921,6 +6098,52 @@ const docTemplate = `{
                 }
             }
         },
+        "model.PushSubscriptionInput": {
+            "type": "object",
+            "required": [
+                "endpoint",
+                "keys"
+            ],
+            "properties": {
+                "endpoint": {
+                    "type": "string"
+                },
+                "expirationTime": {
+                    "description": "ExpirationTime is in unix milliseconds, null if the subscription does not expire",
+                    "type": "integer"
+                },
+                "keys": {
+                    "$ref": "#/definitions/model.PushSubscriptionKeysInput"
+                }
+            }
+        },
+        "model.PushSubscriptionKeysInput": {
+            "type": "object",
+            "required": [
+                "auth",
+                "p256dh"
+            ],
+            "properties": {
+                "auth": {
+                    "type": "string"
+                },
+                "p256dh": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReactionSetInput": {
+            "type": "object",
+            "required": [
+                "emoji"
+            ],
+            "properties": {
+                "emoji": {
+                    "type": "string",
+                    "example": "❤️"
+                }
+            }
+        },
         "model.Register": {
             "type": "object",
             "required": [
@@ -1949,12 +6172,14 @@ const docTemplate = `{
             "enum": [
                 100,
                 101,
-                102
+                102,
+                103
             ],
             "x-enum-varnames": [
                 "RequestStatusPending",
                 "RequestStatusApproved",
-                "RequestStatusRejected"
+                "RequestStatusRejected",
+                "RequestStatusCancelled"
             ]
         },
         "model.ResetPassword": {
@@ -1976,6 +6201,35 @@ const docTemplate = `{
                 }
             }
         },
+        "model.TagCreateInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TagUpdateInput": {
+            "type": "object",
+            "required": [
+                "color",
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "model.UpdatePasswordRequest": {
             "type": "object",
             "required": [
@@ -1991,6 +6245,45 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdatePreferencesRequest": {
+            "type": "object",
+            "required": [
+                "digest_frequency",
+                "timezone"
+            ],
+            "properties": {
+                "digest_frequency": {
+                    "enum": [
+                        "none",
+                        "weekly",
+                        "monthly"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DigestFrequency"
+                        }
+                    ],
+                    "example": "weekly"
+                },
+                "language": {
+                    "description": "Language is kept as it is if it is left empty",
+                    "enum": [
+                        "tr",
+                        "en"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Language"
+                        }
+                    ],
+                    "example": "tr"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Istanbul"
+                }
+            }
+        },
         "model.UpdateUsernameRequest": {
             "type": "object",
             "required": [
@@ -2040,6 +6333,89 @@ const docTemplate = `{
                 "EventVisibilityPrivate",
                 "EventVisibilityJustMe"
             ]
+        },
+        "model.WebhookCreateInput": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.DomainEventType"
+                    },
+                    "example": [
+                        "event.created"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/lifery"
+                }
+            }
+        },
+        "model.WebhookCreated": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "description": "DisabledAt is when the webhook was disabled after failing too often, zero if it was not",
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DomainEventType"
+                    }
+                },
+                "failure_count": {
+                    "description": "FailureCount is the failed attempts in a row, a success resets it",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WebhookUpdateInput": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Enabled turns a webhook on again after it was disabled, its failure count starts over",
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.DomainEventType"
+                    },
+                    "example": [
+                        "event.created"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/lifery"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -6,12 +6,15 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
+	_ "time/tzdata" // user timezones are loaded on images without a zoneinfo database
 
 	"github.com/fleimkeipa/lifery/controller"
 	_ "github.com/fleimkeipa/lifery/docs" // which is the generated folder after swag init
 	"github.com/fleimkeipa/lifery/pkg"
 	"github.com/fleimkeipa/lifery/pkg/logger"
 	"github.com/fleimkeipa/lifery/repositories"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
 	"github.com/fleimkeipa/lifery/uc"
 	"github.com/fleimkeipa/lifery/util"
 
@@ -52,25 +55,82 @@ func serveApplication() {
 	dbClient := initDB()
 	defer dbClient.Close() // Clean up db connections at the end
 
+	// Shared by all use cases, it has to be a single instance for invalidation to work
+	cacheRepo := repositories.NewCacheRepository(getInterval("STATS_CACHE_TTL", 10*time.Minute))
+
+	// Emails of every use case go through the same queue
+	emailUC := initEmailUC(dbClient)
+
+	pushUC := initPushUC(dbClient)
+	pushHandlers := controller.NewPushHandlers(pushUC)
+
+	// Shared by all use cases, it has to be a single instance for the hub to reach every open stream
+	notificationUC := initNotificationUC(dbClient, initNotificationHub(dbClient), pushUC, emailUC)
+	notificationController := controller.NewNotificationHandlers(notificationUC)
+
 	userUC := initUserUC(dbClient)
 	userController := controller.NewUserHandlers(userUC)
 
 	eraUC := initEraUC(dbClient)
 	eraController := controller.NewEraController(eraUC)
 
-	eventUC := initEventUC(dbClient)
+	eventUC := initEventUC(dbClient, cacheRepo, notificationUC)
 	eventController := controller.NewEventController(eventUC)
 
-	connectUC := initConnectUC(dbClient)
+	tagUC := initTagUC(dbClient)
+	tagHandlers := controller.NewTagHandlers(tagUC)
+
+	timelineUC := initTimelineUC(dbClient, cacheRepo, notificationUC)
+	timelineHandlers := controller.NewTimelineHandlers(timelineUC)
+
+	commentUC := initCommentUC(dbClient, cacheRepo, notificationUC)
+	commentHandlers := controller.NewCommentHandlers(commentUC)
+
+	reactionUC := initReactionUC(dbClient, cacheRepo, notificationUC)
+	reactionHandlers := controller.NewReactionHandlers(reactionUC)
+
+	mentionUC := initMentionUC(dbClient, cacheRepo, notificationUC)
+	mentionHandlers := controller.NewMentionHandlers(mentionUC)
+
+	coOwnerUC := initCoOwnerUC(dbClient, cacheRepo, notificationUC)
+	coOwnerHandlers := controller.NewCoOwnerHandlers(coOwnerUC)
+
+	blockUC := initBlockUC(dbClient)
+	blockHandlers := controller.NewBlockHandlers(blockUC)
+
+	followUC := initFollowUC(dbClient, notificationUC)
+	followHandlers := controller.NewFollowHandlers(followUC)
+
+	contactUC := initContactUC(dbClient)
+	contactHandlers := controller.NewContactHandlers(contactUC)
+
+	feedUC := initFeedUC(dbClient)
+	feedHandlers := controller.NewFeedHandlers(feedUC)
+
+	statsUC := initStatsUC(dbClient, cacheRepo)
+	statsHandlers := controller.NewStatsHandlers(statsUC)
+
+	connectUC := initConnectUC(dbClient, notificationUC)
 	connectController := controller.NewConnectHandlers(connectUC, userUC)
 
-	notificationUC := initNotificationUC(dbClient)
-	notificationController := controller.NewNotificationHandlers(notificationUC)
+	// Side effects of domain events are delivered by the outbox dispatcher after their transaction commits
+	outboxUC := initOutboxUC(dbClient)
+	outboxHandlers := controller.NewOutboxHandlers(outboxUC)
+	connectUC.RegisterOutboxHandlers(outboxUC)
+	notificationUC.RegisterOutboxHandlers(outboxUC)
+	eventUC.RegisterOutboxHandlers(outboxUC)
+	pushUC.RegisterOutboxHandlers(outboxUC)
+
+	webhookUC := initWebhookUC(dbClient)
+	webhookHandlers := controller.NewWebhookHandlers(webhookUC)
+	webhookUC.RegisterOutboxHandlers(outboxUC)
 
 	oauthUC := initOAuthUC(dbClient)
 	oauthHandlers := controller.NewOAuthHandlers(oauthUC)
 
-	emailUC := initEmailUC()
+	memoryUC := initMemoryUC(dbClient, emailUC)
+	memoryHandlers := controller.NewMemoryHandlers(memoryUC)
+
 	authHandlers := controller.NewAuthHandlers(userUC, emailUC)
 
 	// Define authentication routes and handlers
@@ -103,17 +163,72 @@ func serveApplication() {
 	userUpdateRoutes := userRoutes.Group("/user")
 	userUpdateRoutes.PUT("/username", userController.UpdateUsername)
 	userUpdateRoutes.PUT("/password", userController.UpdatePassword)
+	userUpdateRoutes.GET("/preferences", userController.GetPreferences)
+	userUpdateRoutes.PUT("/preferences", userController.UpdatePreferences)
 
 	// Define events routes
 	eventsRoutes := userRoutes.Group("/events")
 	eventsRoutes.POST("", eventController.Create)
 	eventsRoutes.PATCH("/:id", eventController.Update)
 	eventsRoutes.DELETE("/:id", eventController.Delete)
+	eventsRoutes.POST("/:id/publish", eventController.Publish)
+	eventsRoutes.GET("/on-this-day", memoryHandlers.OnThisDay)
+	eventsRoutes.POST("/:id/comments", commentHandlers.Create)
+	eventsRoutes.PUT("/:id/reactions", reactionHandlers.Set)
+	eventsRoutes.DELETE("/:id/reactions", reactionHandlers.Delete)
+	eventsRoutes.POST("/:id/mentions", mentionHandlers.Create)
+	eventsRoutes.DELETE("/:id/mentions/:user_id", mentionHandlers.Delete)
+	eventsRoutes.POST("/:id/owners", coOwnerHandlers.InviteToEvent)
+	eventsRoutes.DELETE("/:id/owners/:user_id", coOwnerHandlers.RemoveFromEvent)
 	eventsRoutes.GET("/:id", eventController.GetByID)
 
 	// Define public events routes
 	publicEventsRoutes := viewerRoutes.Group("/events")
 	publicEventsRoutes.GET("", eventController.List)
+	publicEventsRoutes.GET("/:id/comments", commentHandlers.List)
+	publicEventsRoutes.GET("/:id/reactions", reactionHandlers.List)
+	publicEventsRoutes.GET("/:id/mentions", mentionHandlers.List)
+	publicEventsRoutes.GET("/:id/owners", coOwnerHandlers.ListEvent)
+
+	// Define comments routes
+	commentsRoutes := userRoutes.Group("/comments")
+	commentsRoutes.PATCH("/:id", commentHandlers.Update)
+	commentsRoutes.DELETE("/:id", commentHandlers.Delete)
+
+	// Define mentions routes
+	mentionsRoutes := userRoutes.Group("/mentions")
+	mentionsRoutes.GET("", mentionHandlers.ListOwn)
+	mentionsRoutes.PATCH("/:id", mentionHandlers.Update)
+
+	// Define co-owners routes
+	coOwnersRoutes := userRoutes.Group("/co-owners")
+	coOwnersRoutes.GET("", coOwnerHandlers.ListOwn)
+	coOwnersRoutes.PATCH("/:id", coOwnerHandlers.Update)
+
+	// Define blocks and mutes routes
+	blocksRoutes := userRoutes.Group("/blocks")
+	blocksRoutes.GET("", blockHandlers.ListBlocked)
+	blocksRoutes.POST("", blockHandlers.Block)
+	blocksRoutes.DELETE("/:user_id", blockHandlers.Unblock)
+
+	mutesRoutes := userRoutes.Group("/mutes")
+	mutesRoutes.GET("", blockHandlers.ListMuted)
+	mutesRoutes.POST("", blockHandlers.Mute)
+	mutesRoutes.DELETE("/:user_id", blockHandlers.Unmute)
+
+	// Define follows routes
+	followsRoutes := userRoutes.Group("/follows")
+	followsRoutes.POST("", followHandlers.Create)
+	followsRoutes.DELETE("/:user_id", followHandlers.Delete)
+
+	// Define public timeline routes
+	viewerRoutes.GET("/timeline", timelineHandlers.Get)
+
+	// Define feed routes
+	userRoutes.GET("/feed", feedHandlers.Get)
+
+	// Define stats routes
+	userRoutes.GET("/stats", statsHandlers.Get)
 
 	// Define eras routes
 	erasRoutes := userRoutes.Group("/eras")
@@ -121,10 +236,23 @@ func serveApplication() {
 	erasRoutes.PATCH("/:id", eraController.Update)
 	erasRoutes.DELETE("/:id", eraController.Delete)
 	erasRoutes.GET("/:id", eraController.GetByID)
+	erasRoutes.POST("/:id/owners", coOwnerHandlers.InviteToEra)
+	erasRoutes.DELETE("/:id/owners/:user_id", coOwnerHandlers.RemoveFromEra)
 
 	// Define public eras routes
 	publicErasRoutes := viewerRoutes.Group("/eras")
 	publicErasRoutes.GET("", eraController.List)
+	publicErasRoutes.GET("/:id/owners", coOwnerHandlers.ListEra)
+
+	// Define tags routes
+	tagsRoutes := userRoutes.Group("/tags")
+	tagsRoutes.POST("", tagHandlers.Create)
+	tagsRoutes.GET("", tagHandlers.List)
+	tagsRoutes.GET("/autocomplete", tagHandlers.Autocomplete)
+	tagsRoutes.GET("/counts", tagHandlers.Counts)
+	tagsRoutes.GET("/:id", tagHandlers.GetByID)
+	tagsRoutes.PATCH("/:id", tagHandlers.Update)
+	tagsRoutes.DELETE("/:id", tagHandlers.Delete)
 
 	// Define connects routes
 	connectsRoutes := userRoutes.Group("/connects")
@@ -132,15 +260,55 @@ func serveApplication() {
 	connectsRoutes.PATCH("/:id", connectController.Update)
 	connectsRoutes.DELETE("/:id", connectController.Delete)
 	connectsRoutes.GET("", connectController.ConnectsRequests)
+	connectsRoutes.GET("/suggestions", connectController.Suggestions)
+
+	// Define contacts routes
+	contactsRoutes := userRoutes.Group("/contacts")
+	contactsRoutes.GET("", contactHandlers.List)
+	contactsRoutes.POST("", contactHandlers.Import)
+	contactsRoutes.DELETE("/:id", contactHandlers.Delete)
 
 	// Define notifications routes
 	notificationsRoutes := userRoutes.Group("/notifications")
 	notificationsRoutes.GET("", notificationController.List)
+	notificationsRoutes.GET("/unread-count", notificationController.UnreadCount)
+	notificationsRoutes.GET("/preferences", notificationController.GetPreferences)
+	notificationsRoutes.PUT("/preferences", notificationController.UpdatePreferences)
+	notificationsRoutes.PATCH("/read", notificationController.MarkAllRead)
 	notificationsRoutes.PATCH("/:id", notificationController.Update)
+	notificationsRoutes.DELETE("", notificationController.DeleteMany)
+	notificationsRoutes.DELETE("/:id", notificationController.Delete)
+
+	// Define web push routes
+	pushRoutes := userRoutes.Group("/push")
+	pushRoutes.GET("/public-key", pushHandlers.PublicKey)
+	pushRoutes.GET("/subscriptions", pushHandlers.List)
+	pushRoutes.POST("/subscriptions", pushHandlers.Subscribe)
+	pushRoutes.DELETE("/subscriptions/:id", pushHandlers.Unsubscribe)
+
+	// Define webhook routes
+	webhookRoutes := userRoutes.Group("/webhooks")
+	webhookRoutes.GET("", webhookHandlers.List)
+	webhookRoutes.POST("", webhookHandlers.Create)
+	webhookRoutes.PATCH("/:id", webhookHandlers.Update)
+	webhookRoutes.DELETE("/:id", webhookHandlers.Delete)
+	webhookRoutes.GET("/:id/deliveries", webhookHandlers.Deliveries)
+	webhookRoutes.POST("/:id/deliveries/:delivery_id/redeliver", webhookHandlers.Redeliver)
+
+	// EventSource cannot set the Authorization header, so the stream is opened with a single use ticket instead
+	notificationsRoutes.POST("/stream/ticket", notificationController.StreamTicket)
+	e.GET("/notifications/stream", notificationController.Stream)
 
 	// Define public user search routes
 	publicUsersSearchRoutes := viewerRoutes.Group("/users")
 	publicUsersSearchRoutes.GET("/search", userController.Search)
+	publicUsersSearchRoutes.GET("/:id/followers", followHandlers.Followers)
+	publicUsersSearchRoutes.GET("/:id/following", followHandlers.Following)
+
+	// Define outbox routes
+	outboxRoutes := adminRoutes.Group("/outbox")
+	outboxRoutes.GET("/dead-letters", outboxHandlers.ListDeadLetters)
+	outboxRoutes.POST("/dead-letters/:id/retry", outboxHandlers.RetryDeadLetter)
 
 	// Define user routes
 	usersRoutes := adminRoutes.Group("/users")
@@ -150,6 +318,31 @@ func serveApplication() {
 	usersRoutes.PATCH("/:id", userController.Update)
 	usersRoutes.DELETE("/:id", userController.DeleteUser)
 
+	// Start background jobs
+	go pkg.RunEvery(context.Background(), "outbox dispatch", getInterval("OUTBOX_INTERVAL", 5*time.Second), outboxUC.Dispatch)
+	go pkg.RunEvery(context.Background(), "email queue", getInterval("EMAIL_QUEUE_INTERVAL", 10*time.Second), emailUC.Deliver)
+	go pkg.RunEvery(context.Background(), "webhook deliveries", getInterval("WEBHOOK_INTERVAL", 10*time.Second), webhookUC.Dispatch)
+	go pkg.RunEvery(context.Background(), "draft publishing", getInterval("EVENT_PUBLISH_INTERVAL", time.Minute), eventUC.PublishDue)
+	go pkg.RunEvery(context.Background(), "time capsule opening", getInterval("CAPSULE_OPEN_INTERVAL", time.Minute), eventUC.OpenCapsules)
+	go pkg.RunEvery(context.Background(), "digest emails", getInterval("DIGEST_INTERVAL", 15*time.Minute), memoryUC.SendDigests)
+	go pkg.RunEvery(context.Background(), "reaction notifications", getInterval("REACTION_NOTIFY_INTERVAL", 5*time.Minute), reactionUC.NotifyOwners)
+
+	connectRequestTTL := getInterval("CONNECT_REQUEST_TTL", 30*24*time.Hour)
+	go pkg.RunEvery(context.Background(), "connect request expiry", getInterval("CONNECT_EXPIRY_INTERVAL", time.Hour), func(ctx context.Context) error {
+		return connectUC.ExpireRequests(ctx, connectRequestTTL)
+	})
+
+	go pkg.RunEvery(context.Background(), "notification emails", getInterval("NOTIFICATION_EMAIL_INTERVAL", 5*time.Minute), notificationUC.SendPendingEmails)
+
+	go pkg.RunEvery(context.Background(), "push subscription expiry", getInterval("PUSH_EXPIRY_INTERVAL", 24*time.Hour), pushUC.DeleteExpired)
+
+	go pkg.RunEvery(context.Background(), "cache cleanup", getInterval("CACHE_CLEANUP_INTERVAL", 10*time.Minute), cacheRepo.DeleteExpired)
+
+	notificationReadTTL := getInterval("NOTIFICATION_READ_TTL", 90*24*time.Hour)
+	go pkg.RunEvery(context.Background(), "read notification cleanup", getInterval("NOTIFICATION_CLEANUP_INTERVAL", 24*time.Hour), func(ctx context.Context) error {
+		return notificationUC.CleanupRead(ctx, notificationReadTTL)
+	})
+
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
 		port = "8080"
@@ -177,6 +370,16 @@ func loadConfig() {
 	}
 }
 
+// getInterval reads a duration like 15m from the environment, fallback is used if it is missing or invalid
+func getInterval(key string, fallback time.Duration) time.Duration {
+	interval, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || interval <= 0 {
+		return fallback
+	}
+
+	return interval
+}
+
 // Configures the Echo instance
 func configureEcho(e *echo.Echo) {
 	e.HideBanner = true
@@ -198,7 +401,7 @@ func configureCORS(e *echo.Echo) {
 		AllowCredentials:                         true,
 		AllowOrigins:                             []string{"*"},
 		AllowMethods:                             []string{echo.GET, echo.POST, echo.PATCH, echo.PUT, echo.DELETE},
-		AllowHeaders:                             []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders:                             []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "Last-Event-ID"},
 	})
 
 	e.Use(corsConfig)
@@ -243,37 +446,162 @@ func initUserUC(db *pg.DB) *uc.UserUC {
 	return uc.NewUserUC(userDBRepo)
 }
 
-func initConnectUC(db *pg.DB) *uc.ConnectsUC {
+func initConnectUC(db *pg.DB, notificationUC *uc.NotificationUC) *uc.ConnectsUC {
 	userDBRepo := repositories.NewUserRepository(db)
 	connectDBRepo := repositories.NewConnectRepository(db)
-	notificationDBRepo := repositories.NewNotificationRepository(db)
+	blockDBRepo := repositories.NewBlockRepository(db)
 
 	userUC := uc.NewUserUC(userDBRepo)
-	notificationUC := uc.NewNotificationUC(notificationDBRepo)
-	return uc.NewConnectsUC(userUC, connectDBRepo, notificationUC)
+	return uc.NewConnectsUC(userUC, connectDBRepo, blockDBRepo, notificationUC)
 }
 
-func initEventUC(db *pg.DB) *uc.EventUC {
+func initEventUC(db *pg.DB, cacheRepo *repositories.CacheRepository, notificationUC *uc.NotificationUC) *uc.EventUC {
 	userDBRepo := repositories.NewUserRepository(db)
 	connectDBRepo := repositories.NewConnectRepository(db)
+	blockDBRepo := repositories.NewBlockRepository(db)
 	eventDBRepo := repositories.NewEventRepository(db)
-	notificationDBRepo := repositories.NewNotificationRepository(db)
+	tagDBRepo := repositories.NewTagRepository(db)
 
 	userUC := uc.NewUserUC(userDBRepo)
-	notificationUC := uc.NewNotificationUC(notificationDBRepo)
-	connectsUC := uc.NewConnectsUC(userUC, connectDBRepo, notificationUC)
+	connectsUC := uc.NewConnectsUC(userUC, connectDBRepo, blockDBRepo, notificationUC)
+	tagUC := uc.NewTagUC(tagDBRepo)
+
+	return uc.NewEventUC(eventDBRepo, connectsUC, tagUC, userUC, notificationUC, cacheRepo)
+}
 
-	return uc.NewEventUC(eventDBRepo, connectsUC)
+func initTimelineUC(db *pg.DB, cacheRepo *repositories.CacheRepository, notificationUC *uc.NotificationUC) *uc.TimelineUC {
+	eventDBRepo := repositories.NewEventRepository(db)
+	eraDBRepo := repositories.NewEraRepository(db)
+	eventUC := initEventUC(db, cacheRepo, notificationUC)
+	return uc.NewTimelineUC(eventDBRepo, eraDBRepo, eventUC)
 }
 
-func initNotificationUC(db *pg.DB) *uc.NotificationUC {
+func initCommentUC(db *pg.DB, cacheRepo *repositories.CacheRepository, notificationUC *uc.NotificationUC) *uc.CommentUC {
+	commentDBRepo := repositories.NewCommentRepository(db)
+	eventUC := initEventUC(db, cacheRepo, notificationUC)
+	return uc.NewCommentUC(commentDBRepo, eventUC)
+}
+
+func initReactionUC(db *pg.DB, cacheRepo *repositories.CacheRepository, notificationUC *uc.NotificationUC) *uc.ReactionUC {
+	reactionDBRepo := repositories.NewReactionRepository(db)
+	eventUC := initEventUC(db, cacheRepo, notificationUC)
+	return uc.NewReactionUC(reactionDBRepo, eventUC, notificationUC)
+}
+
+func initMentionUC(db *pg.DB, cacheRepo *repositories.CacheRepository, notificationUC *uc.NotificationUC) *uc.MentionUC {
+	mentionDBRepo := repositories.NewMentionRepository(db)
+	connectsUC := initConnectUC(db, notificationUC)
+	eventUC := initEventUC(db, cacheRepo, notificationUC)
+	return uc.NewMentionUC(mentionDBRepo, eventUC, connectsUC)
+}
+
+func initCoOwnerUC(db *pg.DB, cacheRepo *repositories.CacheRepository, notificationUC *uc.NotificationUC) *uc.CoOwnerUC {
+	coOwnerDBRepo := repositories.NewCoOwnerRepository(db)
+	connectsUC := initConnectUC(db, notificationUC)
+	eventUC := initEventUC(db, cacheRepo, notificationUC)
+	eraUC := initEraUC(db)
+	return uc.NewCoOwnerUC(coOwnerDBRepo, eventUC, eraUC, connectsUC)
+}
+
+func initBlockUC(db *pg.DB) *uc.BlockUC {
+	blockDBRepo := repositories.NewBlockRepository(db)
+	userUC := initUserUC(db)
+	return uc.NewBlockUC(blockDBRepo, userUC)
+}
+
+func initFollowUC(db *pg.DB, notificationUC *uc.NotificationUC) *uc.FollowUC {
+	followDBRepo := repositories.NewFollowRepository(db)
+	userUC := initUserUC(db)
+	connectsUC := initConnectUC(db, notificationUC)
+	return uc.NewFollowUC(followDBRepo, userUC, connectsUC)
+}
+
+func initContactUC(db *pg.DB) *uc.ContactUC {
+	contactDBRepo := repositories.NewContactRepository(db)
+	return uc.NewContactUC(contactDBRepo)
+}
+
+func initFeedUC(db *pg.DB) *uc.FeedUC {
+	eventDBRepo := repositories.NewEventRepository(db)
+	return uc.NewFeedUC(eventDBRepo)
+}
+
+func initStatsUC(db *pg.DB, cacheRepo *repositories.CacheRepository) *uc.StatsUC {
+	eventDBRepo := repositories.NewEventRepository(db)
+	eraDBRepo := repositories.NewEraRepository(db)
+	tagDBRepo := repositories.NewTagRepository(db)
+	connectDBRepo := repositories.NewConnectRepository(db)
+	return uc.NewStatsUC(eventDBRepo, eraDBRepo, tagDBRepo, connectDBRepo, cacheRepo)
+}
+
+func initTagUC(db *pg.DB) *uc.TagUC {
+	tagDBRepo := repositories.NewTagRepository(db)
+	return uc.NewTagUC(tagDBRepo)
+}
+
+func initNotificationUC(db *pg.DB, hub interfaces.NotificationHub, pushUC *uc.PushUC, emailUC *uc.EmailUC) *uc.NotificationUC {
 	notificationDBRepo := repositories.NewNotificationRepository(db)
-	return uc.NewNotificationUC(notificationDBRepo)
+	notificationPreferenceDBRepo := repositories.NewNotificationPreferenceRepository(db)
+	streamTicketDBRepo := repositories.NewStreamTicketRepository(db)
+	userUC := initUserUC(db)
+	return uc.NewNotificationUC(notificationDBRepo, notificationPreferenceDBRepo, hub, streamTicketDBRepo, emailUC, pushUC, userUC)
+}
+
+func initOutboxUC(db *pg.DB) *uc.OutboxUC {
+	outboxDBRepo := repositories.NewOutboxRepository(db)
+	return uc.NewOutboxUC(outboxDBRepo)
+}
+
+func initWebhookUC(db *pg.DB) *uc.WebhookUC {
+	webhookDBRepo := repositories.NewWebhookRepository(db)
+	webhookSenderRepo := repositories.NewWebhookSenderRepository()
+	return uc.NewWebhookUC(webhookDBRepo, webhookSenderRepo)
+}
+
+func initPushUC(db *pg.DB) *uc.PushUC {
+	pushSubscriptionDBRepo := repositories.NewPushSubscriptionRepository(db)
+	webPushRepo := repositories.NewWebPushRepository()
+	outboxDBRepo := repositories.NewOutboxRepository(db)
+	return uc.NewPushUC(pushSubscriptionDBRepo, webPushRepo, outboxDBRepo)
+}
+
+// Initializes the notification hub, postgres is needed when more than one instance of the api runs
+func initNotificationHub(db *pg.DB) interfaces.NotificationHub {
+	if os.Getenv("NOTIFICATION_HUB") == "postgres" {
+		return repositories.NewPGNotificationHub(context.Background(), db)
+	}
+
+	return repositories.NewMemoryNotificationHub()
+}
+
+func initMemoryUC(db *pg.DB, emailUC *uc.EmailUC) *uc.MemoryUC {
+	eventDBRepo := repositories.NewEventRepository(db)
+	userUC := initUserUC(db)
+	return uc.NewMemoryUC(eventDBRepo, emailUC, userUC)
+}
+
+func initEmailUC(db *pg.DB) *uc.EmailUC {
+	emailQueueDBRepo := repositories.NewEmailQueueRepository(db)
+
+	// links in emails point to the ui
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:8081"
+	}
+
+	return uc.NewEmailUC(emailQueueDBRepo, initEmailTransport(), frontendURL)
 }
 
-func initEmailUC() *uc.EmailUC {
-	emailRepo := repositories.NewEmailRepository()
-	return uc.NewEmailUC(emailRepo)
+// Initializes the email transport, file writes the emails to EMAIL_FILE_DIR and log only logs them for local development
+func initEmailTransport() interfaces.EmailTransport {
+	switch os.Getenv("EMAIL_TRANSPORT") {
+	case "file":
+		return repositories.NewFileEmailTransport(os.Getenv("EMAIL_FILE_DIR"))
+	case "log":
+		return repositories.NewLogEmailTransport()
+	default:
+		return repositories.NewSMTPEmailTransport()
+	}
 }
 
 func initOAuthUC(db *pg.DB) *uc.OAuthUC {
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Block cuts every relationship between two users and hides them from each other
+type Block struct {
+	CreatedAt time.Time `json:"created_at"`
+	Blocked   *User     `json:"blocked,omitempty"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	BlockedID string    `json:"blocked_id"`
+}
+
+type BlockCreateInput struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type BlockList struct {
+	Blocks []Block `json:"blocks"`
+	Total  int     `json:"total"`
+	PaginationOpts
+}
+
+// Mute hides the events of a user from the feed without the user knowing
+type Mute struct {
+	CreatedAt time.Time `json:"created_at"`
+	Muted     *User     `json:"muted,omitempty"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	MutedID   string    `json:"muted_id"`
+}
+
+type MuteCreateInput struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type MuteList struct {
+	Mutes []Mute `json:"mutes"`
+	Total int    `json:"total"`
+	PaginationOpts
+}
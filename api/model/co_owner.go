@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// CoOwner lets another user edit and delete an event or era together with its owner once the invitation is approved.
+// Exactly one of EventID and EraID is set.
+type CoOwner struct {
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	User        *User         `json:"user,omitempty"`
+	ID          string        `json:"id"`
+	EventID     string        `json:"event_id,omitempty"`
+	EraID       string        `json:"era_id,omitempty"`
+	UserID      string        `json:"user_id"`
+	InvitedByID string        `json:"invited_by_id"`
+	Status      RequestStatus `json:"status"`
+}
+
+type CoOwnerCreateInput struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+// CoOwnerUpdateInput approves or rejects an invitation, a rejected invitation is removed
+type CoOwnerUpdateInput struct {
+	Status RequestStatus `json:"status" validate:"required"`
+}
+
+type CoOwnerList struct {
+	CoOwners []CoOwner `json:"co_owners"`
+	Total    int       `json:"total"`
+	PaginationOpts
+}
+
+type CoOwnerFindOpts struct {
+	EventID Filter
+	EraID   Filter
+	UserID  Filter
+	Status  Filter
+	PaginationOpts
+}
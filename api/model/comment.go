@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type Comment struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      *User     `json:"user,omitempty"`
+	Body      string    `json:"body"`
+	ID        string    `json:"id"`
+	EventID   string    `json:"event_id"`
+	// ParentID is the comment this one replies to, empty for top level comments
+	ParentID   string `json:"parent_id,omitempty"`
+	UserID     string `json:"user_id"`
+	ReplyCount int    `json:"reply_count"`
+}
+
+type CommentCreateInput struct {
+	Body     string `json:"body" validate:"required,max=2000"`
+	ParentID string `json:"parent_id"`
+}
+
+type CommentUpdateInput struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+type CommentList struct {
+	Comments []Comment `json:"comments"`
+	Total    int       `json:"total"`
+	PaginationOpts
+}
+
+type CommentFindOpts struct {
+	OrderByOpts
+	EventID Filter
+	// ParentID lists the replies of a comment, top level comments are listed if it is not sended
+	ParentID Filter
+	PaginationOpts
+}
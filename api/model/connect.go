@@ -1,12 +1,17 @@
 package model
 
+import "time"
+
 type Connect struct {
-	ID       string        `json:"id"`
-	UserID   string        `json:"user_id"`
-	FriendID string        `json:"friend_id"`
-	User     User          `json:"user"`
-	Friend   User          `json:"friend"`
-	Status   RequestStatus `json:"status"`
+	CreatedAt  time.Time     `json:"created_at"`
+	ApprovedAt time.Time     `json:"approved_at"`
+	ID         string        `json:"id"`
+	UserID     string        `json:"user_id"`
+	FriendID   string        `json:"friend_id"`
+	User       User          `json:"user"`
+	Friend     User          `json:"friend"`
+	Message    string        `json:"message"`
+	Status     RequestStatus `json:"status"`
 }
 
 type ConnectList struct {
@@ -17,6 +22,7 @@ type ConnectList struct {
 
 type ConnectCreateInput struct {
 	FriendID string `json:"friend_id" validate:"required"`
+	Message  string `json:"message" validate:"max=500"`
 }
 
 type RequestStatus int
@@ -25,6 +31,8 @@ const (
 	RequestStatusPending RequestStatus = 100 + iota
 	RequestStatusApproved
 	RequestStatusRejected
+	// RequestStatusCancelled is set by the sender to take back a pending connect request
+	RequestStatusCancelled
 )
 
 type ConnectUpdateInput struct {
@@ -43,3 +51,17 @@ type ConnectFindOpts struct {
 	FieldsOpts
 	PaginationOpts
 }
+
+// Suggestion is a user the owner may know, ranked by the score of the shared relationships
+type Suggestion struct {
+	User              User `json:"user"`
+	MutualConnections int  `json:"mutual_connections"`
+	SharedEvents      int  `json:"shared_events"`
+	InContacts        bool `json:"in_contacts"`
+	Score             int  `json:"score"`
+}
+
+type SuggestionList struct {
+	Suggestions []Suggestion `json:"suggestions"`
+	PaginationOpts
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Contact is an address book entry imported by the user, used to suggest connections
+type Contact struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+}
+
+type ContactInput struct {
+	Name  string `json:"name"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ContactImportInput struct {
+	Contacts []ContactInput `json:"contacts" validate:"required,min=1,max=1000,dive"`
+}
+
+type ContactList struct {
+	Contacts []Contact `json:"contacts"`
+	Total    int       `json:"total"`
+	PaginationOpts
+}
+
+type ContactFindOpts struct {
+	UserID string
+	PaginationOpts
+}
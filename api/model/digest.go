@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Digest is the summary of a users memories sent by the periodic digest email
+type Digest struct {
+	From      time.Time
+	To        time.Time
+	Username  string
+	Frequency DigestFrequency
+	// NewEvents are the events added in the period
+	NewEvents []Event
+	// NewEventCount is the number of all events added in the period, NewEvents may hold only the latest of them
+	NewEventCount int
+	// OnThisDay are the events from the day the digest is sent in earlier years
+	OnThisDay []Event
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// EmailKind is a type of email, each has its templates in every language
+type EmailKind string
+
+const (
+	EmailKindPasswordReset EmailKind = "password_reset"
+	EmailKindMemoryDigest  EmailKind = "memory_digest"
+	EmailKindNotification  EmailKind = "notification"
+)
+
+// EmailMessage is an email rendered in the language of its recipient, ready to be sent
+type EmailMessage struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	Kind    EmailKind
+}
+
+// QueuedEmail is an email waiting to be sent, failed attempts are retried with backoff
+type QueuedEmail struct {
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	// FailedAt is set when the email ran out of attempts, it is kept in the queue to be looked into
+	FailedAt time.Time
+	EmailMessage
+	ID        string
+	LastError string
+	Attempts  int
+}
+
+type PasswordResetEmail struct {
+	Username   string
+	ResetToken string
+}
@@ -3,15 +3,16 @@ package model
 import "time"
 
 type Era struct {
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	User      *User     `json:"user"`
-	TimeStart time.Time `json:"time_start"`
-	TimeEnd   time.Time `json:"time_end"`
-	Name      string    `json:"name"`
-	Color     string    `json:"color"`
-	UserID    string    `json:"user_id"`
-	ID        string    `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	User        *User     `json:"user"`
+	TimeStart   time.Time `json:"time_start"`
+	TimeEnd     time.Time `json:"time_end"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	UserID      string    `json:"user_id"`
+	ID          string    `json:"id"`
+	UpdatedByID string    `json:"updated_by_id,omitempty"`
 }
 
 type EraCreateInput struct {
@@ -36,7 +37,11 @@ type EraList struct {
 
 type EraFindOpts struct {
 	OrderByOpts
-	Name   Filter
-	UserID Filter
+	Name      Filter
+	UserID    Filter
+	TimeStart Filter
+	TimeEnd   Filter
+	// SharedWith adds the eras the user co-owns on top of the ones matching UserID
+	SharedWith Filter
 	PaginationOpts
 }
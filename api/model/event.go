@@ -1,20 +1,57 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
 
 type Event struct {
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
-	DeletedAt   time.Time   `json:"deleted_at"`
-	Date        time.Time   `json:"date"`
-	TimeStart   time.Time   `json:"time_start"`
-	TimeEnd     time.Time   `json:"time_end"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	ID          string      `json:"id"`
-	UserID      string      `json:"user_id"`
-	Items       []EventItem `json:"items"`
-	Visibility  Visibility  `json:"visibility"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	DeletedAt   time.Time       `json:"deleted_at"`
+	User        *User           `json:"user,omitempty"`
+	Date        time.Time       `json:"date"`
+	TimeStart   time.Time       `json:"time_start"`
+	TimeEnd     time.Time       `json:"time_end"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	ID          string          `json:"id"`
+	UserID      string          `json:"user_id"`
+	UpdatedByID string          `json:"updated_by_id,omitempty"`
+	Items       []EventItem     `json:"items"`
+	Tags        []Tag           `json:"tags"`
+	Reactions   []ReactionCount `json:"reactions"`
+	Visibility  Visibility      `json:"visibility"`
+	// Draft events are seen only by their owner until they are published
+	Draft bool `json:"draft"`
+	// PublishAt is when a draft is published by the scheduler, zero if it is not scheduled
+	PublishAt time.Time `json:"publish_at"`
+	// Sealed makes the event a time capsule, its contents are redacted until UnlockAt
+	Sealed bool `json:"sealed"`
+	// SealedForOwner keeps the contents from the owner too until the capsule opens
+	SealedForOwner bool      `json:"sealed_for_owner"`
+	UnlockAt       time.Time `json:"unlock_at"`
+	// OpenedAt is when the owner and recipients were notified that the capsule opened
+	OpenedAt time.Time `json:"opened_at"`
+	// RecipientIDs are the connections the capsule is addressed to, only they and the owner see it
+	RecipientIDs []string `json:"recipient_ids"`
+	// Redacted tells the contents were left out as the capsule is not open yet
+	Redacted bool `json:"redacted"`
+}
+
+// Locked reports whether the event is a published time capsule that has not opened yet by now
+func (e *Event) Locked(now time.Time) bool {
+	return e.Sealed && !e.Draft && now.Before(e.UnlockAt)
+}
+
+// ReachesUser reports whether the recipients of the event let the user see it, events without recipients reach everyone
+func (e *Event) ReachesUser(userID string) bool {
+	if len(e.RecipientIDs) == 0 || e.UserID == userID {
+		return true
+	}
+
+	return slices.Contains(e.RecipientIDs, userID)
 }
 
 type Visibility int
@@ -43,20 +80,56 @@ type EventCreateInput struct {
 	Date        time.Time   `json:"date"`
 	TimeStart   time.Time   `json:"time_start"`
 	TimeEnd     time.Time   `json:"time_end"`
-	Name        string      `json:"name" validate:"required"`
+	Name        string      `json:"name" validate:"required_unless=Draft true"`
 	Description string      `json:"description"`
 	Items       []EventItem `json:"items"`
+	Tags        []string    `json:"tags"`
 	Visibility  Visibility  `json:"visibility"`
+	// Draft keeps the event to the owner until it is published, a draft may have no name yet
+	Draft bool `json:"draft"`
+	// PublishAt schedules publishing, it makes the event a draft until then
+	PublishAt time.Time `json:"publish_at"`
+	// Sealed makes the event a time capsule that opens at UnlockAt
+	Sealed         bool      `json:"sealed"`
+	SealedForOwner bool      `json:"sealed_for_owner"`
+	UnlockAt       time.Time `json:"unlock_at" validate:"required_if=Sealed true"`
+	RecipientIDs   []string  `json:"recipient_ids"`
 }
 
+// EventUpdateInput changes only the fields sent, so drafts can be autosaved with the fields being edited.
+// Tags and items are replaced when sent, an empty list clears them.
 type EventUpdateInput struct {
-	Date        time.Time   `json:"date"`
-	TimeStart   time.Time   `json:"time_start"`
-	TimeEnd     time.Time   `json:"time_end"`
-	Name        string      `json:"name" validate:"required"`
-	Description string      `json:"description"`
-	Items       []EventItem `json:"items"`
-	Visibility  Visibility  `json:"visibility"`
+	Date        OptionalTime `json:"date" swaggertype:"string" format:"date-time"`
+	TimeStart   OptionalTime `json:"time_start" swaggertype:"string" format:"date-time"`
+	TimeEnd     OptionalTime `json:"time_end" swaggertype:"string" format:"date-time"`
+	Name        *string      `json:"name"`
+	Description *string      `json:"description"`
+	Items       []EventItem  `json:"items"`
+	Tags        []string     `json:"tags"`
+	Visibility  *Visibility  `json:"visibility"`
+	// PublishAt schedules publishing a draft, null unschedules it
+	PublishAt OptionalTime `json:"publish_at" swaggertype:"string" format:"date-time"`
+	// only drafts can be sealed or get a new unlock time, published capsules can only be unsealed after they opened
+	Sealed         *bool        `json:"sealed"`
+	SealedForOwner *bool        `json:"sealed_for_owner"`
+	UnlockAt       OptionalTime `json:"unlock_at" swaggertype:"string" format:"date-time"`
+	RecipientIDs   []string     `json:"recipient_ids"`
+}
+
+// OptionalTime tells a time left out of a json body from one sent, null clears the time
+type OptionalTime struct {
+	Time time.Time
+	Set  bool
+}
+
+func (t *OptionalTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	return json.Unmarshal(data, &t.Time)
 }
 
 type EventList struct {
@@ -70,5 +143,25 @@ type EventFindOpts struct {
 	UserID     Filter
 	Name       Filter
 	Visibility Filter
+	Date       Filter
+	CreatedAt  Filter
+	TimeStart  Filter
+	TimeEnd    Filter
+	// Tags matches events having any of the given comma separated tag names
+	Tags Filter
+	// Search is matched against name and description with full-text search
+	Search Filter
+	// SharedWith adds the events the user approved being tagged in, limited to SharedVisibility,
+	// on top of the events matching UserID and Visibility
+	SharedWith       Filter
+	SharedVisibility Filter
+	// Draft filters the drafts of DraftsOf
+	Draft Filter
+	// DraftsOf is the user whose drafts are listed too, drafts of everyone else are left out
+	DraftsOf string
+	// Recipient leaves out the time capsules addressed to others than its value, it is sended for readers other than the owner
+	Recipient Filter
+	// HideLocked leaves out the time capsules not opened yet
+	HideLocked bool
 	PaginationOpts
 }
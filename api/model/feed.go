@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Feed is a page of recent events of the users connections and followed users, newest first
+type Feed struct {
+	Events []Event `json:"events"`
+	// NextCursor is passed as cursor to get the next page, empty on the last page
+	NextCursor string `json:"next_cursor"`
+}
+
+// FeedCursor points to the last event of a feed page
+type FeedCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+type FeedFindOpts struct {
+	UserID string
+	// After continues the feed with the events older than the cursor, nil starts from the newest
+	After *FeedCursor
+	Limit int
+}
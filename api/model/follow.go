@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Follow is a one-way relationship, the follower sees the public events of the followed user in the feed
+// without needing an approval
+type Follow struct {
+	CreatedAt  time.Time `json:"created_at"`
+	User       *User     `json:"user,omitempty"`
+	Followed   *User     `json:"followed,omitempty"`
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	FollowedID string    `json:"followed_id"`
+}
+
+type FollowCreateInput struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type FollowList struct {
+	Follows []Follow `json:"follows"`
+	Total   int      `json:"total"`
+	PaginationOpts
+}
+
+type FollowFindOpts struct {
+	// UserID lists the users the user follows
+	UserID Filter
+	// FollowedID lists the followers of the user
+	FollowedID Filter
+	// VisibleTo hides the users who blocked or are blocked by the user
+	VisibleTo Filter
+	PaginationOpts
+}
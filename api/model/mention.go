@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Mention tags a connection of the event owner in the event. The tagged user has to approve it
+// before the event shows up on their timeline as a shared memory.
+type Mention struct {
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	User      *User         `json:"user,omitempty"`
+	Event     *Event        `json:"event,omitempty"`
+	ID        string        `json:"id"`
+	EventID   string        `json:"event_id"`
+	UserID    string        `json:"user_id"`
+	Status    RequestStatus `json:"status"`
+}
+
+type MentionCreateInput struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+// MentionUpdateInput approves or rejects a mention, rejecting an approved one removes the event from the timeline
+type MentionUpdateInput struct {
+	Status RequestStatus `json:"status" validate:"required"`
+}
+
+type MentionList struct {
+	Mentions []Mention `json:"mentions"`
+	Total    int       `json:"total"`
+	PaginationOpts
+}
+
+type MentionFindOpts struct {
+	OrderByOpts
+	EventID Filter
+	UserID  Filter
+	Status  Filter
+	PaginationOpts
+}
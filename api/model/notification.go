@@ -9,13 +9,63 @@ const (
 	NotificationStatusRead
 )
 
+// NotificationType is the kind of a notification, every type has its messages and link in the notification registry
+type NotificationType string
+
+const (
+	NotificationTypeConnectRequest   NotificationType = "connect_request"
+	NotificationTypeConnectApproved  NotificationType = "connect_response"
+	NotificationTypeConnectRejected  NotificationType = "connect_rejected"
+	NotificationTypeConnectCancelled NotificationType = "connect_cancelled"
+	NotificationTypeConnectExpired   NotificationType = "connect_expired"
+	NotificationTypeEventComment     NotificationType = "event_comment"
+	NotificationTypeEventReaction    NotificationType = "event_reaction"
+	NotificationTypeEventMention     NotificationType = "event_mention"
+	NotificationTypeMentionApproved  NotificationType = "event_mention_approved"
+	NotificationTypeCoOwnerRequest   NotificationType = "co_owner_request"
+	NotificationTypeCoOwnerApproved  NotificationType = "co_owner_approved"
+	NotificationTypeNewFollower      NotificationType = "new_follower"
+	NotificationTypeEventPublished   NotificationType = "event_published"
+	NotificationTypeCapsuleOpened    NotificationType = "capsule_opened"
+)
+
+// NotificationTarget is the type of the entity a notification is about
+type NotificationTarget string
+
+const (
+	NotificationTargetUser    NotificationTarget = "user"
+	NotificationTargetConnect NotificationTarget = "connect"
+	NotificationTargetEvent   NotificationTarget = "event"
+	NotificationTargetEra     NotificationTarget = "era"
+)
+
+// NotificationPayload is what a notification is about, its message is rendered from it when it is read.
+// The names are kept as they were when the notification was created.
+type NotificationPayload struct {
+	ActorID    string             `json:"actor_id,omitempty"`
+	ActorName  string             `json:"actor_name,omitempty"`
+	TargetType NotificationTarget `json:"target_type,omitempty"`
+	TargetID   string             `json:"target_id,omitempty"`
+	TargetName string             `json:"target_name,omitempty"`
+	// Count is how many actions are batched in the notification, like reactions
+	Count int `json:"count,omitempty"`
+}
+
 type Notification struct {
-	ID        string             `json:"id"`
-	UserID    string             `json:"user_id"`
-	Type      string             `json:"type"`
-	Message   string             `json:"message"`
+	ID      string              `json:"id"`
+	UserID  string              `json:"user_id"`
+	Type    NotificationType    `json:"type"`
+	Payload NotificationPayload `json:"payload"`
+	// Message is rendered in the language of the user, notifications from before payloads keep their stored message
+	Message string `json:"message"`
+	// Link is the path of the ui page the notification leads to
+	Link      string             `json:"link"`
 	Read      NotificationStatus `json:"read"`
 	CreatedAt time.Time          `json:"created_at"`
+	// EmailPending is set until the notification is emailed to a user who gets its type by email
+	EmailPending bool `json:"-"`
+	// SourceID is the id of the outbox event the notification is created for, a retried event does not notify twice
+	SourceID string `json:"-"`
 }
 
 type NotificationList struct {
@@ -25,19 +75,117 @@ type NotificationList struct {
 }
 
 type NotificationCreateInput struct {
-	UserID  string `json:"user_id" validate:"required"`
-	Type    string `json:"type" validate:"required"`
-	Message string `json:"message" validate:"required"`
+	UserID  string              `json:"user_id" validate:"required"`
+	Type    NotificationType    `json:"type" validate:"required"`
+	Payload NotificationPayload `json:"payload"`
+	// SourceID is the id of the outbox event creating the notification, if any
+	SourceID string `json:"-"`
 }
 
 type NotificationUpdateInput struct {
 	Read NotificationStatus `json:"read"`
 }
 
+// NotificationDeleteInput is the notifications of the user to delete at once
+type NotificationDeleteInput struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100,dive,numeric"`
+}
+
+// NotificationUnreadCount is the number of unread notifications of a user
+type NotificationUnreadCount struct {
+	Count int `json:"count"`
+}
+
+// StreamTicket opens one notification stream of its owner, it is given in place of the token EventSource cannot send
+type StreamTicket struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Ticket    string    `json:"ticket"`
+}
+
 type NotificationFindOpts struct {
 	OrderByOpts
-	UserID Filter
-	Read   Filter
+	ID           Filter
+	UserID       Filter
+	Read         Filter
+	EmailPending Filter
 	FieldsOpts
 	PaginationOpts
 }
+
+// NotificationChannel is how notifications of a type reach the user
+type NotificationChannel string
+
+const (
+	// NotificationChannelInApp notifications are listed and streamed in the app only
+	NotificationChannelInApp NotificationChannel = "in_app"
+	// NotificationChannelEmail notifications are emailed as well as shown in the app
+	NotificationChannelEmail NotificationChannel = "email"
+	// NotificationChannelNone notifications are not created at all
+	NotificationChannelNone NotificationChannel = "none"
+)
+
+// quietHoursLayout is the layout of the quiet hours bounds
+const quietHoursLayout = "15:04"
+
+// NotificationPreferences are the channels a user chose for each notification type, their quiet hours and daily digest option
+type NotificationPreferences struct {
+	// Channels holds the channel of each notification type, types left out are delivered in app
+	Channels map[NotificationType]NotificationChannel `json:"channels"`
+	// QuietHoursStart and QuietHoursEnd are HH:MM in the timezone of the user, emails are held back in between.
+	// Quiet hours are off when they are empty.
+	QuietHoursStart string `json:"quiet_hours_start" example:"22:00"`
+	QuietHoursEnd   string `json:"quiet_hours_end" example:"08:00"`
+	// DailyDigest sends notification emails together once a day instead of one by one
+	DailyDigest  bool      `json:"daily_digest"`
+	DigestSentAt time.Time `json:"-"`
+}
+
+// Channel returns the channel the user chose for the type, in app if they did not choose one
+func (p *NotificationPreferences) Channel(notificationType NotificationType) NotificationChannel {
+	channel, ok := p.Channels[notificationType]
+	if !ok || channel == "" {
+		return NotificationChannelInApp
+	}
+
+	return channel
+}
+
+// InQuietHours reports whether local, the time in the timezone of the user, is in the quiet hours.
+// Quiet hours may pass midnight like 22:00 to 08:00.
+func (p *NotificationPreferences) InQuietHours(local time.Time) bool {
+	start, err := time.Parse(quietHoursLayout, p.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+
+	end, err := time.Parse(quietHoursLayout, p.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute
+	}
+
+	return minute >= startMinute || minute < endMinute
+}
+
+type NotificationPreferencesInput struct {
+	// Channels holds the channel of each notification type, types left out are delivered in app
+	Channels map[NotificationType]NotificationChannel `json:"channels" validate:"dive,oneof=in_app email none"`
+	// QuietHoursStart and QuietHoursEnd are set together or left empty to turn quiet hours off
+	QuietHoursStart string `json:"quiet_hours_start" validate:"omitempty,datetime=15:04" example:"22:00"`
+	QuietHoursEnd   string `json:"quiet_hours_end" validate:"omitempty,datetime=15:04" example:"08:00"`
+	DailyDigest     bool   `json:"daily_digest"`
+}
+
+// NotificationEmail is an email of notifications, one notification or the daily digest of them
+type NotificationEmail struct {
+	Username      string
+	Notifications []Notification
+	Digest        bool
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DomainEventType is the kind of a state change whose side effects are delivered through the outbox
+type DomainEventType string
+
+const (
+	DomainEventConnectRequested DomainEventType = "connect.requested"
+	DomainEventConnectApproved  DomainEventType = "connect.approved"
+	DomainEventConnectRejected  DomainEventType = "connect.rejected"
+	DomainEventConnectCancelled DomainEventType = "connect.cancelled"
+	DomainEventConnectExpired   DomainEventType = "connect.expired"
+	DomainEventEventCreated     DomainEventType = "event.created"
+	DomainEventEventUpdated     DomainEventType = "event.updated"
+	// DomainEventEventPublished is written when a draft becomes visible, after its event.created
+	DomainEventEventPublished DomainEventType = "event.published"
+	// DomainEventEventUnlocked is written when a time capsule opens
+	DomainEventEventUnlocked DomainEventType = "event.unlocked"
+	DomainEventEraCreated    DomainEventType = "era.created"
+	// the payload of the comment, mention, co-owner and follow events is the NotificationCreateInput of their receiver
+	DomainEventCommentCreated  DomainEventType = "comment.created"
+	DomainEventMentionCreated  DomainEventType = "mention.created"
+	DomainEventMentionApproved DomainEventType = "mention.approved"
+	DomainEventCoOwnerInvited  DomainEventType = "co_owner.invited"
+	DomainEventCoOwnerApproved DomainEventType = "co_owner.approved"
+	DomainEventFollowCreated   DomainEventType = "follow.created"
+	// DomainEventPushQueued is a web push message to one subscription, its payload is a PushDelivery
+	DomainEventPushQueued DomainEventType = "push.queued"
+)
+
+// DomainEvent is written to the outbox in the transaction of its state change,
+// the outbox dispatcher delivers its side effects like notifications after the commit.
+type DomainEvent struct {
+	CreatedAt     time.Time       `json:"created_at"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	ID            string          `json:"id"`
+	Type          DomainEventType `json:"type"`
+	// AggregateID is the id of the changed entity, it is set on write if the entity is created in the same transaction
+	AggregateID string `json:"aggregate_id"`
+	// Payload is the json of the event data, its shape depends on Type
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+	// Delivered holds the handlers that are done with the event, retries skip them
+	Delivered []string `json:"delivered"`
+	LastError string   `json:"last_error"`
+	Attempts  int      `json:"attempts"`
+}
+
+// NewDomainEvent returns an event of the type with the json of the payload
+func NewDomainEvent(eventType DomainEventType, aggregateID string, payload any) (*DomainEvent, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DomainEvent{
+		CreatedAt:   time.Now(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     raw,
+	}, nil
+}
+
+// ConnectEventPayload is the payload of connect events, names are read when the event is delivered
+type ConnectEventPayload struct {
+	SenderID   string `json:"sender_id"`
+	ReceiverID string `json:"receiver_id"`
+	// ActorID is who made the change, the receiver for expired requests
+	ActorID string `json:"actor_id"`
+}
+
+// DeadLetter is a domain event given up on after the outbox dispatcher ran out of attempts
+type DeadLetter struct {
+	DomainEvent
+	DeadAt time.Time `json:"dead_at"`
+}
+
+type DeadLetterList struct {
+	DeadLetters []DeadLetter `json:"dead_letters"`
+	Total       int          `json:"total"`
+	PaginationOpts
+}
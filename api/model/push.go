@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+// PushSubscription is a browser of the user subscribed to web push notifications, one per device
+type PushSubscription struct {
+	CreatedAt time.Time `json:"created_at"`
+	// ExpiresAt is when the browser drops the subscription, zero if it does not expire
+	ExpiresAt time.Time `json:"expires_at"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Endpoint  string    `json:"endpoint"`
+	// P256dh and Auth are the keys of the browser payloads are encrypted with
+	P256dh    string `json:"-"`
+	Auth      string `json:"-"`
+	UserAgent string `json:"user_agent"`
+}
+
+// Expired reports whether the browser dropped the subscription by now
+func (s *PushSubscription) Expired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt)
+}
+
+// PushSubscriptionInput is the PushSubscription json of the browser
+type PushSubscriptionInput struct {
+	Endpoint string `json:"endpoint" validate:"required,url"`
+	// ExpirationTime is in unix milliseconds, null if the subscription does not expire
+	ExpirationTime *int64                    `json:"expirationTime"`
+	Keys           PushSubscriptionKeysInput `json:"keys" validate:"required"`
+}
+
+type PushSubscriptionKeysInput struct {
+	P256dh string `json:"p256dh" validate:"required"`
+	Auth   string `json:"auth" validate:"required"`
+}
+
+// PushMessage is the payload of a web push notification shown by the service worker of the ui
+type PushMessage struct {
+	ID    string           `json:"id"`
+	Type  NotificationType `json:"type"`
+	Title string           `json:"title"`
+	Body  string           `json:"body"`
+	// Link is the path of the ui page the notification leads to
+	Link string `json:"link"`
+}
+
+// PushDelivery is a push message queued for a subscription of the user
+type PushDelivery struct {
+	UserID         string      `json:"user_id"`
+	SubscriptionID string      `json:"subscription_id"`
+	Message        PushMessage `json:"message"`
+}
+
+// PushPublicKey is the VAPID public key browsers subscribe with as applicationServerKey
+type PushPublicKey struct {
+	PublicKey string `json:"public_key"`
+}
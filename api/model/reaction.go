@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// ReactionEmojis are the emojis users can react to events with
+var ReactionEmojis = []string{"👍", "❤️", "😂", "😮", "😢", "👏"}
+
+type Reaction struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      *User     `json:"user,omitempty"`
+	Emoji     string    `json:"emoji"`
+	ID        string    `json:"id"`
+	EventID   string    `json:"event_id"`
+	UserID    string    `json:"user_id"`
+}
+
+type ReactionSetInput struct {
+	Emoji string `json:"emoji" validate:"required" example:"❤️"`
+}
+
+type ReactionList struct {
+	Reactions []Reaction `json:"reactions"`
+	Total     int        `json:"total"`
+	PaginationOpts
+}
+
+type ReactionFindOpts struct {
+	EventID Filter
+	Emoji   Filter
+	PaginationOpts
+}
+
+// ReactionCount is the number of users reacted to an event with the emoji
+type ReactionCount struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// ReactionBatch collects the reactions to an event its owner is not notified about yet
+type ReactionBatch struct {
+	EventID   string
+	EventName string
+	OwnerID   string
+	// LastID is the newest reaction of the batch, newer ones are left for the next batch
+	LastID string
+	Count  int
+}
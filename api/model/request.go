@@ -27,6 +27,7 @@ const (
 	OperandLike         Operand = "like"
 	OperandIn           Operand = "in"
 	OperandNotIn        Operand = "nin"
+	OperandBetween      Operand = "btw"
 )
 
 type Filter struct {
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// Stats are the insights computed over a users timeline
+type Stats struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	LongestEra  *Era           `json:"longest_era"`
+	GroupBy     TimelinePeriod `json:"group_by"`
+	// EventsPerPeriod counts the dated events per year or month
+	EventsPerPeriod []PeriodCount `json:"events_per_period"`
+	// MediaCounts counts the items of the events by their type
+	MediaCounts []EventTypeCount  `json:"media_counts"`
+	Visibility  []VisibilityCount `json:"visibility"`
+	// Gaps are the longest periods without any events, longest first
+	Gaps    []Gap      `json:"gaps"`
+	TopTags []TagCount `json:"top_tags"`
+	// ConnectionGrowth counts the connections approved per year or month
+	ConnectionGrowth []PeriodCount `json:"connection_growth"`
+	EventCount       int           `json:"event_count"`
+	LongestEraDays   int           `json:"longest_era_days"`
+}
+
+type StatsFindOpts struct {
+	UserID  string
+	GroupBy TimelinePeriod
+}
+
+type EventTypeCount struct {
+	Type  EventType `json:"type"`
+	Count int       `json:"count"`
+}
+
+type VisibilityCount struct {
+	Visibility Visibility `json:"visibility"`
+	Count      int        `json:"count"`
+}
+
+// Gap is a period between two consecutive events
+type Gap struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Days  int       `json:"days"`
+}
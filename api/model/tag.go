@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type Tag struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	UserID    string    `json:"user_id"`
+	ID        string    `json:"id"`
+}
+
+type TagCreateInput struct {
+	Name  string `json:"name" validate:"required"`
+	Color string `json:"color" validate:"omitempty,iscolor"`
+}
+
+type TagUpdateInput struct {
+	Name  string `json:"name" validate:"required"`
+	Color string `json:"color" validate:"required,iscolor"`
+}
+
+type TagList struct {
+	Tags  []Tag `json:"tags"`
+	Total int   `json:"total"`
+	PaginationOpts
+}
+
+// TagCount is a tag with the number of events it is attached to, used to build tag clouds
+type TagCount struct {
+	Tag
+	Count int `json:"count"`
+}
+
+type TagFindOpts struct {
+	OrderByOpts
+	Name   Filter
+	UserID Filter
+	PaginationOpts
+}
+
+// DefaultTagColor is used for tags created on the fly from event inputs
+const DefaultTagColor = "#6B7280"
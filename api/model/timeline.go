@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+type TimelinePeriod string
+
+const (
+	TimelinePeriodYear  TimelinePeriod = "year"
+	TimelinePeriodMonth TimelinePeriod = "month"
+)
+
+// Timeline is a page of a users events and eras grouped into periods
+type Timeline struct {
+	Buckets []TimelineBucket `json:"buckets"`
+	// NextCursor is passed as cursor to get the next page, empty on the last page
+	NextCursor string `json:"next_cursor"`
+}
+
+type TimelineBucket struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Period     string    `json:"period"`
+	Events     []Event   `json:"events,omitempty"`
+	Eras       []Era     `json:"eras,omitempty"`
+	EventCount int       `json:"event_count"`
+	EraCount   int       `json:"era_count"`
+}
+
+type TimelineFindOpts struct {
+	UserID  string
+	From    string
+	To      string
+	Cursor  string
+	GroupBy TimelinePeriod
+	Limit   int
+	// CountsOnly leaves events and eras out of the buckets for zoomed-out views
+	CountsOnly bool
+	Ascending  bool
+}
+
+// PeriodCount is the number of records starting in the period truncated to Period
+type PeriodCount struct {
+	Period time.Time `json:"period"`
+	Count  int       `json:"count"`
+}
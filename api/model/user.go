@@ -12,15 +12,61 @@ const (
 	AuthTypeLinkedIn AuthType = "linkedin"
 )
 
+// DigestFrequency is how often a user gets the digest email of their memories
+type DigestFrequency string
+
+const (
+	DigestFrequencyNone    DigestFrequency = "none"
+	DigestFrequencyWeekly  DigestFrequency = "weekly"
+	DigestFrequencyMonthly DigestFrequency = "monthly"
+)
+
+// Language is the language notifications and emails are rendered in for the user
+type Language string
+
+const (
+	LanguageTurkish Language = "tr"
+	LanguageEnglish Language = "en"
+	// DefaultLanguage is used for users who did not choose a language, the same as the ui
+	DefaultLanguage = LanguageTurkish
+)
+
 type User struct {
-	CreatedAt time.Time  `json:"created_at"`
-	Username  string     `json:"username"`
-	Email     string     `json:"email"`
-	Password  string     `json:"password"`
-	ID        string     `json:"id"`
-	Connects  []*Connect `json:"connects"`
-	RoleID    UserRole   `json:"role_id"`
-	AuthType  AuthType   `json:"auth_type"`
+	CreatedAt       time.Time       `json:"created_at"`
+	DigestSentAt    time.Time       `json:"digest_sent_at"`
+	Username        string          `json:"username"`
+	Email           string          `json:"email"`
+	Password        string          `json:"password"`
+	ID              string          `json:"id"`
+	Timezone        string          `json:"timezone"`
+	Connects        []*Connect      `json:"connects"`
+	RoleID          UserRole        `json:"role_id"`
+	AuthType        AuthType        `json:"auth_type"`
+	DigestFrequency DigestFrequency `json:"digest_frequency"`
+	Language        Language        `json:"language"`
+}
+
+// LanguageOrDefault returns the language of the user, DefaultLanguage if it is not set
+func (u *User) LanguageOrDefault() Language {
+	if u.Language == "" {
+		return DefaultLanguage
+	}
+
+	return u.Language
+}
+
+// Location returns the timezone of the user, UTC if it is not set or unknown
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
 }
 
 type UserList struct {
@@ -42,6 +88,10 @@ type UserFindOpts struct {
 	Username Filter
 	Email    Filter
 	RoleID   Filter
+	// DigestFrequency filters users by their digest email subscription
+	DigestFrequency Filter
+	// VisibleTo hides the users who blocked or are blocked by the user
+	VisibleTo Filter
 	FieldsOpts
 	PaginationOpts
 }
@@ -61,3 +111,16 @@ type UpdatePasswordRequest struct {
 	CurrentPassword string `json:"current_password" validate:"required"`
 	NewPassword     string `json:"new_password" validate:"required"`
 }
+
+type UserPreferences struct {
+	Timezone        string          `json:"timezone" example:"Europe/Istanbul"`
+	DigestFrequency DigestFrequency `json:"digest_frequency" example:"weekly"`
+	Language        Language        `json:"language" example:"tr"`
+}
+
+type UpdatePreferencesRequest struct {
+	Timezone        string          `json:"timezone" validate:"required" example:"Europe/Istanbul"`
+	DigestFrequency DigestFrequency `json:"digest_frequency" validate:"required,oneof=none weekly monthly" example:"weekly"`
+	// Language is kept as it is if it is left empty
+	Language Language `json:"language" validate:"omitempty,oneof=tr en" example:"tr"`
+}
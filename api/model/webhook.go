@@ -0,0 +1,116 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEventTypes are the domain events users can get webhooks for
+var WebhookEventTypes = []DomainEventType{
+	DomainEventEventCreated,
+	DomainEventEventUpdated,
+	DomainEventConnectApproved,
+	DomainEventEraCreated,
+}
+
+// WebhookDisableAfter is after how many failed attempts in a row a webhook is disabled
+const WebhookDisableAfter = 20
+
+type WebhookDeliveryStatus int
+
+const (
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = 100 + iota
+	WebhookDeliveryStatusSucceeded
+	// WebhookDeliveryStatusFailed deliveries ran out of attempts
+	WebhookDeliveryStatusFailed
+)
+
+// Webhook is an endpoint of the user the domain events they subscribed to are posted to
+type Webhook struct {
+	CreatedAt time.Time `json:"created_at"`
+	// DisabledAt is when the webhook was disabled after failing too often, zero if it was not
+	DisabledAt time.Time         `json:"disabled_at"`
+	ID         string            `json:"id"`
+	UserID     string            `json:"user_id"`
+	URL        string            `json:"url"`
+	Secret     string            `json:"-"`
+	Events     []DomainEventType `json:"events"`
+	Enabled    bool              `json:"enabled"`
+	// FailureCount is the failed attempts in a row, a success resets it
+	FailureCount int `json:"failure_count"`
+}
+
+// RecordAttempt counts a delivery attempt of the webhook. A success resets the failures,
+// failing WebhookDisableAfter times in a row disables the webhook at now.
+func (w *Webhook) RecordAttempt(succeeded bool, now time.Time) {
+	if succeeded {
+		w.FailureCount = 0
+		return
+	}
+
+	w.FailureCount++
+
+	if w.Enabled && w.FailureCount >= WebhookDisableAfter {
+		w.Enabled = false
+		w.DisabledAt = now
+	}
+}
+
+// WebhookCreated is returned once when a webhook is created, it is the only time its signing secret is shown
+type WebhookCreated struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+type WebhookCreateInput struct {
+	URL    string            `json:"url" validate:"required,url" example:"https://example.com/lifery"`
+	Events []DomainEventType `json:"events" validate:"required,min=1,dive,oneof=event.created event.updated connect.approved era.created" example:"event.created"`
+}
+
+type WebhookUpdateInput struct {
+	URL    string            `json:"url" validate:"omitempty,url" example:"https://example.com/lifery"`
+	Events []DomainEventType `json:"events" validate:"omitempty,min=1,dive,oneof=event.created event.updated connect.approved era.created" example:"event.created"`
+	// Enabled turns a webhook on again after it was disabled, its failure count starts over
+	Enabled *bool `json:"enabled"`
+}
+
+// WebhookDelivery is a domain event posted to a webhook, it is kept as the delivery log of the webhook
+type WebhookDelivery struct {
+	CreatedAt     time.Time `json:"created_at"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	DeliveredAt   time.Time `json:"delivered_at"`
+	// Webhook is loaded only for the deliveries being sent
+	Webhook   *Webhook        `json:"-"`
+	ID        string          `json:"id"`
+	WebhookID string          `json:"webhook_id"`
+	EventID   string          `json:"event_id"`
+	EventType DomainEventType `json:"event_type"`
+	// Payload is the body posted to the webhook
+	Payload        json.RawMessage       `json:"payload" swaggertype:"object"`
+	ResponseBody   string                `json:"response_body"`
+	LastError      string                `json:"last_error"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	ResponseStatus int                   `json:"response_status"`
+	Attempts       int                   `json:"attempts"`
+}
+
+type WebhookDeliveryList struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Total      int               `json:"total"`
+	PaginationOpts
+}
+
+// WebhookPayload is the body posted to webhooks, data is the payload of the domain event
+type WebhookPayload struct {
+	CreatedAt   time.Time       `json:"created_at"`
+	ID          string          `json:"id"`
+	Type        DomainEventType `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// WebhookResponse is the answer of a webhook endpoint to a delivery
+type WebhookResponse struct {
+	Body       string
+	StatusCode int
+}
@@ -62,7 +62,8 @@ func Middleware() echo.MiddlewareFunc {
 			// Basic fields for every log
 			fields := []any{
 				"method", req.Method,
-				"uri", req.RequestURI,
+				// the route pattern only, query params may carry secrets like the stream ticket
+				"path", c.Path(),
 				"status", status,
 				"latency", latency.String(),
 			}
@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	"github.com/fleimkeipa/lifery/pkg/logger"
+)
+
+// RunEvery runs job every interval until ctx is done, errors are logged and do not stop the schedule.
+// It blocks, so it is meant to be started in its own goroutine.
+func RunEvery(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				logger.Log.Errorf("scheduled job %s failed: %v", name, err)
+			}
+		}
+	}
+}
+
+// ExponentialBackoff returns the wait before the next attempt after the given number of failed attempts,
+// base doubles after each of them up to max
+func ExponentialBackoff(attempts int, base, max time.Duration) time.Duration {
+	backoff := base
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= max {
+			return max
+		}
+	}
+
+	return backoff
+}
@@ -0,0 +1,265 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// blockedUsersSQL selects the users who blocked or are blocked by the user, which is given twice as parameter
+const blockedUsersSQL = "SELECT block.blocked_id FROM blocks AS block WHERE block.user_id = ? " +
+	"UNION SELECT block.user_id FROM blocks AS block WHERE block.blocked_id = ?"
+
+type BlockRepository struct {
+	db *pg.DB
+}
+
+func NewBlockRepository(db *pg.DB) *BlockRepository {
+	rc := &BlockRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Block blocks the user and removes every connect and follow between the two users in the same transaction.
+func (rc *BlockRepository) Block(ctx context.Context, newBlock *model.Block) (*model.Block, error) {
+	sqlBlock := rc.blockToSQL(newBlock)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(sqlBlock).
+			OnConflict("(user_id, blocked_id) DO UPDATE").
+			Set("created_at = EXCLUDED.created_at").
+			Returning("*").
+			Insert()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model((*connect)(nil)).
+			Where("(user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)",
+				sqlBlock.UserID, sqlBlock.BlockedID, sqlBlock.BlockedID, sqlBlock.UserID).
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model((*follow)(nil)).
+			Where("(user_id = ? AND followed_id = ?) OR (user_id = ? AND followed_id = ?)",
+				sqlBlock.UserID, sqlBlock.BlockedID, sqlBlock.BlockedID, sqlBlock.UserID).
+			Delete()
+
+		return err
+	})
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to block user", http.StatusInternalServerError)
+	}
+
+	return rc.blockToInternal(sqlBlock), nil
+}
+
+func (rc *BlockRepository) Unblock(ctx context.Context, userID, blockedID string) error {
+	result, err := rc.db.Model((*block)(nil)).Where("user_id = ? AND blocked_id = ?", userID, blockedID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to unblock user", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "user is not blocked: "+blockedID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsBlocked reports whether one of the users blocked the other.
+func (rc *BlockRepository) IsBlocked(ctx context.Context, userID, otherID string) (bool, error) {
+	exists, err := rc.db.Model((*block)(nil)).
+		Where("(block.user_id = ? AND block.blocked_id = ?) OR (block.user_id = ? AND block.blocked_id = ?)",
+			userID, otherID, otherID, userID).
+		Exists()
+	if err != nil {
+		return false, pkg.NewError(err, "failed to check block", http.StatusInternalServerError)
+	}
+
+	return exists, nil
+}
+
+func (rc *BlockRepository) ListBlocked(ctx context.Context, userID string, opts model.PaginationOpts) (*model.BlockList, error) {
+	blocks := make([]block, 0)
+
+	query := rc.db.Model(&blocks).
+		Relation("Blocked.id").
+		Relation("Blocked.username").
+		Where("block.user_id = ?", userID).
+		OrderExpr("block.created_at DESC, block.id DESC")
+
+	query = applyStandardQueries(query, opts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list blocked users", http.StatusInternalServerError)
+	}
+
+	internalBlocks := make([]model.Block, 0, len(blocks))
+	for _, v := range blocks {
+		internalBlocks = append(internalBlocks, *rc.blockToInternal(&v))
+	}
+
+	return &model.BlockList{
+		Blocks:         internalBlocks,
+		Total:          count,
+		PaginationOpts: opts,
+	}, nil
+}
+
+func (rc *BlockRepository) Mute(ctx context.Context, newMute *model.Mute) (*model.Mute, error) {
+	sqlMute := rc.muteToSQL(newMute)
+
+	_, err := rc.db.Model(sqlMute).
+		OnConflict("(user_id, muted_id) DO UPDATE").
+		Set("created_at = EXCLUDED.created_at").
+		Returning("*").
+		Insert()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to mute user", http.StatusInternalServerError)
+	}
+
+	return rc.muteToInternal(sqlMute), nil
+}
+
+func (rc *BlockRepository) Unmute(ctx context.Context, userID, mutedID string) error {
+	result, err := rc.db.Model((*mute)(nil)).Where("user_id = ? AND muted_id = ?", userID, mutedID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to unmute user", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "user is not muted: "+mutedID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *BlockRepository) ListMuted(ctx context.Context, userID string, opts model.PaginationOpts) (*model.MuteList, error) {
+	mutes := make([]mute, 0)
+
+	query := rc.db.Model(&mutes).
+		Relation("Muted.id").
+		Relation("Muted.username").
+		Where("mute.user_id = ?", userID).
+		OrderExpr("mute.created_at DESC, mute.id DESC")
+
+	query = applyStandardQueries(query, opts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list muted users", http.StatusInternalServerError)
+	}
+
+	internalMutes := make([]model.Mute, 0, len(mutes))
+	for _, v := range mutes {
+		internalMutes = append(internalMutes, *rc.muteToInternal(&v))
+	}
+
+	return &model.MuteList{
+		Mutes:          internalMutes,
+		Total:          count,
+		PaginationOpts: opts,
+	}, nil
+}
+
+func (rc *BlockRepository) blockToSQL(newBlock *model.Block) *block {
+	bID, _ := strconv.Atoi(newBlock.ID)
+	userID, _ := strconv.Atoi(newBlock.UserID)
+	blockedID, _ := strconv.Atoi(newBlock.BlockedID)
+
+	return &block{
+		CreatedAt: newBlock.CreatedAt,
+		ID:        bID,
+		UserID:    userID,
+		BlockedID: blockedID,
+	}
+}
+
+func (rc *BlockRepository) blockToInternal(newBlock *block) *model.Block {
+	var blocked *model.User
+	if newBlock.Blocked != nil {
+		blocked = &model.User{
+			ID:       strconv.Itoa(newBlock.Blocked.ID),
+			Username: newBlock.Blocked.Username,
+		}
+	}
+
+	return &model.Block{
+		CreatedAt: newBlock.CreatedAt,
+		Blocked:   blocked,
+		ID:        strconv.Itoa(newBlock.ID),
+		UserID:    strconv.Itoa(newBlock.UserID),
+		BlockedID: strconv.Itoa(newBlock.BlockedID),
+	}
+}
+
+func (rc *BlockRepository) muteToSQL(newMute *model.Mute) *mute {
+	mID, _ := strconv.Atoi(newMute.ID)
+	userID, _ := strconv.Atoi(newMute.UserID)
+	mutedID, _ := strconv.Atoi(newMute.MutedID)
+
+	return &mute{
+		CreatedAt: newMute.CreatedAt,
+		ID:        mID,
+		UserID:    userID,
+		MutedID:   mutedID,
+	}
+}
+
+func (rc *BlockRepository) muteToInternal(newMute *mute) *model.Mute {
+	var muted *model.User
+	if newMute.Muted != nil {
+		muted = &model.User{
+			ID:       strconv.Itoa(newMute.Muted.ID),
+			Username: newMute.Muted.Username,
+		}
+	}
+
+	return &model.Mute{
+		CreatedAt: newMute.CreatedAt,
+		Muted:     muted,
+		ID:        strconv.Itoa(newMute.ID),
+		UserID:    strconv.Itoa(newMute.UserID),
+		MutedID:   strconv.Itoa(newMute.MutedID),
+	}
+}
+
+func (rc *BlockRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*block)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create block table", http.StatusInternalServerError)
+	}
+
+	if err := db.Model((*mute)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create mute table", http.StatusInternalServerError)
+	}
+
+	// lookups from the blocked side, the unique constraint covers the blocker side
+	indexQuery := "CREATE INDEX IF NOT EXISTS block_blocked_idx ON blocks (blocked_id)"
+	if _, err := db.Exec(indexQuery); err != nil {
+		return pkg.NewError(err, "failed to create block index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
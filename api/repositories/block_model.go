@@ -0,0 +1,21 @@
+package repositories
+
+import "time"
+
+type block struct {
+	CreatedAt time.Time `json:"created_at"`
+	User      *user     `json:"user" pg:"rel:has-one"`
+	Blocked   *user     `json:"blocked" pg:"rel:has-one"`
+	ID        int       `json:"id" pg:",pk"`
+	UserID    int       `json:"user_id" pg:",notnull,unique:block_user_blocked,on_delete:CASCADE"`
+	BlockedID int       `json:"blocked_id" pg:",notnull,unique:block_user_blocked,on_delete:CASCADE"`
+}
+
+type mute struct {
+	CreatedAt time.Time `json:"created_at"`
+	User      *user     `json:"user" pg:"rel:has-one"`
+	Muted     *user     `json:"muted" pg:"rel:has-one"`
+	ID        int       `json:"id" pg:",pk"`
+	UserID    int       `json:"user_id" pg:",notnull,unique:mute_user_muted,on_delete:CASCADE"`
+	MutedID   int       `json:"muted_id" pg:",notnull,unique:mute_user_muted,on_delete:CASCADE"`
+}
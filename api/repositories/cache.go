@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fleimkeipa/lifery/pkg"
+)
+
+// CacheRepository is an in-process key value cache, entries expire after the ttl given to NewCacheRepository.
+// It is shared by every use case of the process, so it has to be created once.
+// Expired entries are removed when they are read or by DeleteExpired.
+type CacheRepository struct {
+	items map[string]cacheItem
+	ttl   time.Duration
+	mu    sync.RWMutex
+}
+
+type cacheItem struct {
+	expiresAt time.Time
+	value     string
+}
+
+func NewCacheRepository(ttl time.Duration) *CacheRepository {
+	return &CacheRepository{
+		items: make(map[string]cacheItem),
+		ttl:   ttl,
+	}
+}
+
+func (rc *CacheRepository) Set(ctx context.Context, key string, value string) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.items[key] = cacheItem{
+		value:     value,
+		expiresAt: time.Now().Add(rc.ttl),
+	}
+
+	return nil
+}
+
+func (rc *CacheRepository) Get(ctx context.Context, key string) (string, error) {
+	rc.mu.RLock()
+	item, ok := rc.items[key]
+	rc.mu.RUnlock()
+
+	if ok && rc.expired(item) {
+		rc.deleteIfExpired(key)
+	}
+
+	if !ok || rc.expired(item) {
+		return "", pkg.NewError(nil, "cache miss: "+key, http.StatusNotFound)
+	}
+
+	return item.value, nil
+}
+
+func (rc *CacheRepository) Exists(ctx context.Context, keys ...string) (int64, error) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	var count int64
+	for _, key := range keys {
+		if item, ok := rc.items[key]; ok && !rc.expired(item) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (rc *CacheRepository) Delete(ctx context.Context, keys ...string) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, key := range keys {
+		delete(rc.items, key)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the expired entries, it is run periodically so keys that are not read again do not pile up.
+func (rc *CacheRepository) DeleteExpired(ctx context.Context) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key, item := range rc.items {
+		if rc.expired(item) {
+			delete(rc.items, key)
+		}
+	}
+
+	return nil
+}
+
+// deleteIfExpired removes the entry unless it was set again since it was read
+func (rc *CacheRepository) deleteIfExpired(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if item, ok := rc.items[key]; ok && rc.expired(item) {
+		delete(rc.items, key)
+	}
+}
+
+func (rc *CacheRepository) expired(item cacheItem) bool {
+	return time.Now().After(item.expiresAt)
+}
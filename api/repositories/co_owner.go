@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type CoOwnerRepository struct {
+	db *pg.DB
+}
+
+func NewCoOwnerRepository(db *pg.DB) *CoOwnerRepository {
+	rc := &CoOwnerRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Create stores the invitation and writes the events about it to the outbox in the same transaction.
+func (rc *CoOwnerRepository) Create(ctx context.Context, newCoOwner *model.CoOwner, events ...*model.DomainEvent) (*model.CoOwner, error) {
+	sqlCoOwner := rc.internalToSQL(newCoOwner)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Model(sqlCoOwner).Insert(); err != nil {
+			return err
+		}
+
+		return insertOutboxEvents(tx, strconv.Itoa(sqlCoOwner.ID), events)
+	})
+	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.IntegrityViolation() {
+			return nil, pkg.NewError(err, "user is already invited", http.StatusConflict)
+		}
+
+		return nil, pkg.NewError(err, "failed to create co-owner", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlCoOwner), nil
+}
+
+// UpdateStatus changes the status of the invitation and writes the events about it to the outbox in the same transaction.
+func (rc *CoOwnerRepository) UpdateStatus(ctx context.Context, coOwnerID string, status model.RequestStatus, events ...*model.DomainEvent) error {
+	var rowsAffected int
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		result, err := tx.Model((*coOwner)(nil)).
+			Set("status = ?", int(status)).
+			Set("updated_at = now()").
+			Where("id = ?", coOwnerID).
+			Update()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected = result.RowsAffected()
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		return insertOutboxEvents(tx, coOwnerID, events)
+	})
+	if err != nil {
+		return pkg.NewError(err, "failed to update co-owner "+coOwnerID, http.StatusInternalServerError)
+	}
+
+	if rowsAffected == 0 {
+		return pkg.NewError(nil, "no co-owner updated: "+coOwnerID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *CoOwnerRepository) Delete(ctx context.Context, coOwnerID string) error {
+	result, err := rc.db.Model(&coOwner{}).Where("id = ?", coOwnerID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete co-owner", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no co-owner deleted: "+coOwnerID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *CoOwnerRepository) List(ctx context.Context, opts *model.CoOwnerFindOpts) (*model.CoOwnerList, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	coOwners := make([]coOwner, 0)
+
+	query := rc.db.Model(&coOwners).
+		Relation("User.id").
+		Relation("User.username").
+		OrderExpr("co_owner.created_at DESC, co_owner.id DESC")
+
+	query = applyStandardQueries(query, opts.PaginationOpts)
+
+	query = rc.fillFilter(query, opts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list co-owners", http.StatusInternalServerError)
+	}
+
+	internalCoOwners := make([]model.CoOwner, 0, len(coOwners))
+	for _, v := range coOwners {
+		internalCoOwners = append(internalCoOwners, *rc.sqlToInternal(&v))
+	}
+
+	return &model.CoOwnerList{
+		CoOwners: internalCoOwners,
+		Total:    count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+func (rc *CoOwnerRepository) GetByID(ctx context.Context, coOwnerID string) (*model.CoOwner, error) {
+	if coOwnerID == "" || coOwnerID == "0" {
+		return nil, pkg.NewError(nil, "invalid co-owner ID: "+coOwnerID, http.StatusBadRequest)
+	}
+
+	resp := new(coOwner)
+
+	if err := rc.db.Model(resp).Where("co_owner.id = ?", coOwnerID).Select(); err != nil {
+		return nil, pkg.NewError(err, "failed to find co-owner by ID "+coOwnerID, http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(resp), nil
+}
+
+// GetByTarget returns the invitation of the user to the event or era, the one of eventID and eraID which is not empty.
+func (rc *CoOwnerRepository) GetByTarget(ctx context.Context, eventID, eraID, userID string) (*model.CoOwner, error) {
+	resp := new(coOwner)
+
+	query := rc.db.Model(resp).Where("co_owner.user_id = ?", userID)
+
+	if eventID != "" {
+		query = query.Where("co_owner.event_id = ?", eventID)
+	} else {
+		query = query.Where("co_owner.era_id = ?", eraID)
+	}
+
+	if err := query.Select(); err != nil {
+		if err == pg.ErrNoRows {
+			return nil, pkg.NewError(err, "user is not a co-owner", http.StatusNotFound)
+		}
+
+		return nil, pkg.NewError(err, "failed to find co-owner", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(resp), nil
+}
+
+func (rc *CoOwnerRepository) fillFilter(tx *orm.Query, opts *model.CoOwnerFindOpts) *orm.Query {
+	if opts.EventID.IsSended {
+		tx = applyFilterWithOperand(tx, "co_owner.event_id", opts.EventID)
+	}
+
+	if opts.EraID.IsSended {
+		tx = applyFilterWithOperand(tx, "co_owner.era_id", opts.EraID)
+	}
+
+	if opts.UserID.IsSended {
+		tx = applyFilterWithOperand(tx, "co_owner.user_id", opts.UserID)
+	}
+
+	if opts.Status.IsSended {
+		tx = applyFilterWithOperand(tx, "co_owner.status", opts.Status)
+	}
+
+	return tx
+}
+
+func (rc *CoOwnerRepository) internalToSQL(newCoOwner *model.CoOwner) *coOwner {
+	cID, _ := strconv.Atoi(newCoOwner.ID)
+	eventID, _ := strconv.Atoi(newCoOwner.EventID)
+	eraID, _ := strconv.Atoi(newCoOwner.EraID)
+	userID, _ := strconv.Atoi(newCoOwner.UserID)
+	invitedByID, _ := strconv.Atoi(newCoOwner.InvitedByID)
+
+	return &coOwner{
+		CreatedAt:   newCoOwner.CreatedAt,
+		UpdatedAt:   newCoOwner.UpdatedAt,
+		ID:          cID,
+		EventID:     eventID,
+		EraID:       eraID,
+		UserID:      userID,
+		InvitedByID: invitedByID,
+		Status:      int(newCoOwner.Status),
+	}
+}
+
+func (rc *CoOwnerRepository) sqlToInternal(newCoOwner *coOwner) *model.CoOwner {
+	var invited *model.User
+	if newCoOwner.User != nil {
+		invited = &model.User{
+			ID:       strconv.Itoa(newCoOwner.User.ID),
+			Username: newCoOwner.User.Username,
+		}
+	}
+
+	internalCoOwner := &model.CoOwner{
+		CreatedAt:   newCoOwner.CreatedAt,
+		UpdatedAt:   newCoOwner.UpdatedAt,
+		User:        invited,
+		ID:          strconv.Itoa(newCoOwner.ID),
+		UserID:      strconv.Itoa(newCoOwner.UserID),
+		InvitedByID: strconv.Itoa(newCoOwner.InvitedByID),
+		Status:      model.RequestStatus(newCoOwner.Status),
+	}
+
+	if newCoOwner.EventID != 0 {
+		internalCoOwner.EventID = strconv.Itoa(newCoOwner.EventID)
+	}
+
+	if newCoOwner.EraID != 0 {
+		internalCoOwner.EraID = strconv.Itoa(newCoOwner.EraID)
+	}
+
+	return internalCoOwner
+}
+
+func (rc *CoOwnerRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*coOwner)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create co-owner table", http.StatusInternalServerError)
+	}
+
+	indexQueries := []string{
+		"CREATE UNIQUE INDEX IF NOT EXISTS co_owner_event_user_idx ON co_owners (event_id, user_id) WHERE event_id IS NOT NULL",
+		"CREATE UNIQUE INDEX IF NOT EXISTS co_owner_era_user_idx ON co_owners (era_id, user_id) WHERE era_id IS NOT NULL",
+		"CREATE INDEX IF NOT EXISTS co_owner_user_status_idx ON co_owners (user_id, status)",
+	}
+	for _, query := range indexQueries {
+		if _, err := db.Exec(query); err != nil {
+			return pkg.NewError(err, "failed to create co-owner index", http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// applyOwnersFilter matches the rows owned by the user or co-owned by the user through an approved invitation.
+// targetColumn is the column of co_owners referencing the table, event_id or era_id.
+func applyOwnersFilter(tx *orm.Query, targetColumn, ownerID string) *orm.Query {
+	return tx.Where("(user_id = ? OR id IN (SELECT co_owner.? FROM co_owners AS co_owner WHERE co_owner.user_id = ? AND co_owner.status = ?))",
+		ownerID, pg.Ident(targetColumn), ownerID, int(model.RequestStatusApproved))
+}
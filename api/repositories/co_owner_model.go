@@ -0,0 +1,17 @@
+package repositories
+
+import "time"
+
+type coOwner struct {
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	User        *user     `json:"user" pg:"rel:has-one"`
+	Event       *event    `json:"event" pg:"rel:has-one"`
+	Era         *era      `json:"era" pg:"rel:has-one"`
+	ID          int       `json:"id" pg:",pk"`
+	EventID     int       `json:"event_id" pg:",on_delete:CASCADE"`
+	EraID       int       `json:"era_id" pg:",on_delete:CASCADE"`
+	UserID      int       `json:"user_id" pg:",notnull,on_delete:CASCADE"`
+	InvitedByID int       `json:"invited_by_id" pg:",notnull"`
+	Status      int       `json:"status" pg:",notnull"`
+}
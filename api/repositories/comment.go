@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/fleimkeipa/lifery/util"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type CommentRepository struct {
+	db *pg.DB
+}
+
+func NewCommentRepository(db *pg.DB) *CommentRepository {
+	rc := &CommentRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Create stores the comment and writes the events about it to the outbox in the same transaction.
+func (rc *CommentRepository) Create(ctx context.Context, newComment *model.Comment, events ...*model.DomainEvent) (*model.Comment, error) {
+	sqlComment := rc.internalToSQL(newComment)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Model(sqlComment).Insert(); err != nil {
+			return err
+		}
+
+		return insertOutboxEvents(tx, strconv.Itoa(sqlComment.ID), events)
+	})
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to create comment", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlComment), nil
+}
+
+// Update changes the body of the comment, only its author can update it.
+func (rc *CommentRepository) Update(ctx context.Context, commentID string, newComment *model.Comment) (*model.Comment, error) {
+	if commentID == "" || commentID == "0" {
+		return nil, pkg.NewError(nil, "invalid comment id "+commentID, http.StatusBadRequest)
+	}
+
+	newComment.ID = commentID
+
+	sqlComment := rc.internalToSQL(newComment)
+
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	result, err := rc.db.Model(sqlComment).
+		Column("body", "updated_at").
+		Where("id = ? AND user_id = ?", commentID, ownerID).
+		Update()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to update comment "+commentID, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, pkg.NewError(nil, "no comment updated: "+commentID, http.StatusBadRequest)
+	}
+
+	return rc.sqlToInternal(sqlComment), nil
+}
+
+// Delete removes the comment with its replies, callers check who is allowed to delete it.
+func (rc *CommentRepository) Delete(ctx context.Context, commentID string) error {
+	result, err := rc.db.Model(&comment{}).Where("id = ?", commentID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete comment "+commentID, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no comment deleted: "+commentID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *CommentRepository) List(ctx context.Context, opts *model.CommentFindOpts) (*model.CommentList, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	comments := make([]commentWithReplies, 0)
+
+	query := rc.db.Model(&comments).
+		ColumnExpr("comment.*").
+		ColumnExpr("(SELECT count(*) FROM comments AS reply WHERE reply.parent_id = comment.id) AS reply_count").
+		Relation("User.id").
+		Relation("User.username")
+
+	if opts.OrderByOpts.IsSended {
+		query = applyOrderBy(query, opts.OrderByOpts)
+	} else {
+		query = query.OrderExpr("comment.created_at ASC, comment.id ASC")
+	}
+
+	query = applyStandardQueries(query, opts.PaginationOpts)
+
+	query = rc.fillFilter(query, opts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list comments", http.StatusInternalServerError)
+	}
+
+	internalComments := make([]model.Comment, 0, len(comments))
+	for _, v := range comments {
+		internalComment := rc.sqlToInternal(&v.comment)
+		internalComment.ReplyCount = v.ReplyCount
+
+		internalComments = append(internalComments, *internalComment)
+	}
+
+	return &model.CommentList{
+		Comments: internalComments,
+		Total:    count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+func (rc *CommentRepository) GetByID(ctx context.Context, commentID string) (*model.Comment, error) {
+	if commentID == "" || commentID == "0" {
+		return nil, pkg.NewError(nil, "invalid comment ID: "+commentID, http.StatusBadRequest)
+	}
+
+	resp := new(comment)
+
+	if err := rc.db.Model(resp).Where("comment.id = ?", commentID).Select(); err != nil {
+		return nil, pkg.NewError(err, "failed to find comment by ID "+commentID, http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(resp), nil
+}
+
+func (rc *CommentRepository) fillFilter(tx *orm.Query, opts *model.CommentFindOpts) *orm.Query {
+	if opts.EventID.IsSended {
+		tx = applyFilterWithOperand(tx, "comment.event_id", opts.EventID)
+	}
+
+	if opts.ParentID.IsSended {
+		tx = applyFilterWithOperand(tx, "comment.parent_id", opts.ParentID)
+	} else {
+		tx = tx.Where("comment.parent_id IS NULL")
+	}
+
+	return tx
+}
+
+func (rc *CommentRepository) internalToSQL(newComment *model.Comment) *comment {
+	cID, _ := strconv.Atoi(newComment.ID)
+	eventID, _ := strconv.Atoi(newComment.EventID)
+	parentID, _ := strconv.Atoi(newComment.ParentID)
+	userID, _ := strconv.Atoi(newComment.UserID)
+
+	return &comment{
+		CreatedAt: newComment.CreatedAt,
+		UpdatedAt: newComment.UpdatedAt,
+		Body:      newComment.Body,
+		ID:        cID,
+		EventID:   eventID,
+		ParentID:  parentID,
+		UserID:    userID,
+	}
+}
+
+func (rc *CommentRepository) sqlToInternal(newComment *comment) *model.Comment {
+	var parentID string
+	if newComment.ParentID != 0 {
+		parentID = strconv.Itoa(newComment.ParentID)
+	}
+
+	var author *model.User
+	if newComment.User != nil {
+		author = &model.User{
+			ID:       strconv.Itoa(newComment.User.ID),
+			Username: newComment.User.Username,
+		}
+	}
+
+	return &model.Comment{
+		CreatedAt: newComment.CreatedAt,
+		UpdatedAt: newComment.UpdatedAt,
+		User:      author,
+		Body:      newComment.Body,
+		ID:        strconv.Itoa(newComment.ID),
+		EventID:   strconv.Itoa(newComment.EventID),
+		ParentID:  parentID,
+		UserID:    strconv.Itoa(newComment.UserID),
+	}
+}
+
+func (rc *CommentRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*comment)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create comment table", http.StatusInternalServerError)
+	}
+
+	indexQuery := "CREATE INDEX IF NOT EXISTS comment_event_parent_idx ON comments (event_id, parent_id, created_at)"
+	if _, err := db.Exec(indexQuery); err != nil {
+		return pkg.NewError(err, "failed to create comment index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
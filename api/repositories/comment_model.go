@@ -0,0 +1,22 @@
+package repositories
+
+import "time"
+
+type comment struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      *user     `json:"user" pg:"rel:has-one"`
+	Event     *event    `json:"event" pg:"rel:has-one"`
+	Parent    *comment  `json:"parent" pg:"rel:has-one"`
+	Body      string    `json:"body" pg:",notnull"`
+	ID        int       `json:"id" pg:",pk"`
+	EventID   int       `json:"event_id" pg:",notnull,on_delete:CASCADE"`
+	ParentID  int       `json:"parent_id" pg:",on_delete:CASCADE"`
+	UserID    int       `json:"user_id" pg:",notnull,on_delete:CASCADE"`
+}
+
+// commentWithReplies is the scan target of comment listings
+type commentWithReplies struct {
+	comment    `pg:",inherit"`
+	ReplyCount int `json:"reply_count"`
+}
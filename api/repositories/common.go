@@ -6,6 +6,7 @@ import (
 
 	"github.com/fleimkeipa/lifery/model"
 
+	"github.com/go-pg/pg/v10"
 	"github.com/go-pg/pg/v10/orm"
 )
 
@@ -27,6 +28,10 @@ func applyStandardQueries(tx *orm.Query, pagination model.PaginationOpts) *orm.Q
 }
 
 func applyFilterWithOperand(tx *orm.Query, key string, filter model.Filter) *orm.Query {
+	if filter.Operand == model.OperandBetween {
+		return applyBetweenFilter(tx, key, filter)
+	}
+
 	if strings.Contains(filter.Value, ",") {
 		values := strings.Split(filter.Value, ",")
 
@@ -64,6 +69,25 @@ func applyFilterWithOperand(tx *orm.Query, key string, filter model.Filter) *orm
 	}
 }
 
+// applyBetweenFilter filters an inclusive range given as "from,to".
+// Leaving one side empty makes the range open on that side.
+// example: date=btw:2020-01-01,2020-12-31 or date=btw:2020-01-01,
+func applyBetweenFilter(tx *orm.Query, key string, filter model.Filter) *orm.Query {
+	from, to, _ := strings.Cut(filter.Value, ",")
+	from = strings.TrimSpace(from)
+	to = strings.TrimSpace(to)
+
+	if from != "" {
+		tx = tx.Where(fmt.Sprintf("%s>=?", key), from)
+	}
+
+	if to != "" {
+		tx = tx.Where(fmt.Sprintf("%s<=?", key), to)
+	}
+
+	return tx
+}
+
 func applyOrderBy(tx *orm.Query, orderBy model.OrderByOpts) *orm.Query {
 	if orderBy.IsSended {
 		if orderBy.OrderBy == "" {
@@ -74,3 +98,23 @@ func applyOrderBy(tx *orm.Query, orderBy model.OrderByOpts) *orm.Query {
 
 	return tx
 }
+
+// addMissingColumns adds the columns of the model which the table does not have yet.
+// CreateTable with IfNotExists leaves existing tables as they are, so fields added to a model later
+// would be missing on older databases. The columns are added as nullable with their defaults.
+func addMissingColumns(db *pg.DB, model interface{}) error {
+	table := db.Model(model).TableModel().Table()
+
+	for _, field := range table.Fields {
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", table.SQLName, field.Column, field.SQLType)
+		if field.Default != "" {
+			query += " DEFAULT " + string(field.Default)
+		}
+
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,9 +2,11 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 	"github.com/fleimkeipa/lifery/pkg"
@@ -30,20 +32,30 @@ func NewConnectRepository(db *pg.DB) *ConnectRepository {
 	return rc
 }
 
-func (rc *ConnectRepository) Create(ctx context.Context, connect *model.Connect) (*model.Connect, error) {
+// Create stores the connect and writes the events about it to the outbox in the same transaction.
+func (rc *ConnectRepository) Create(ctx context.Context, connect *model.Connect, events ...*model.DomainEvent) (*model.Connect, error) {
 	sqlConnect := rc.internalToSQL(connect)
 
-	q := rc.db.Model(sqlConnect)
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Model(sqlConnect).Insert(); err != nil {
+			return err
+		}
 
-	_, err := q.Insert()
+		return insertOutboxEvents(tx, strconv.Itoa(sqlConnect.ID), events)
+	})
 	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.IntegrityViolation() {
+			return nil, pkg.NewError(err, "already connected", http.StatusConflict)
+		}
+
 		return nil, pkg.NewError(err, "failed to create connect ID "+connect.ID, http.StatusInternalServerError)
 	}
 
 	return rc.sqlToInternal(sqlConnect), nil
 }
 
-func (rc *ConnectRepository) Update(ctx context.Context, connectID string, connect *model.Connect) (*model.Connect, error) {
+// Update stores the connect and writes the events about it to the outbox in the same transaction.
+func (rc *ConnectRepository) Update(ctx context.Context, connectID string, connect *model.Connect, events ...*model.DomainEvent) (*model.Connect, error) {
 	if connectID == "" || connectID == "0" {
 		return nil, pkg.NewError(nil, "connect id is empty", http.StatusBadRequest)
 	}
@@ -52,26 +64,54 @@ func (rc *ConnectRepository) Update(ctx context.Context, connectID string, conne
 
 	sqlConnect := rc.internalToSQL(connect)
 
-	q := rc.db.Model(sqlConnect).WherePK()
+	var rowsAffected int
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		result, err := tx.Model(sqlConnect).WherePK().Update()
+		if err != nil {
+			return err
+		}
 
-	result, err := q.Update()
+		rowsAffected = result.RowsAffected()
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		return insertOutboxEvents(tx, connectID, events)
+	})
 	if err != nil {
 		return nil, pkg.NewError(err, "failed to update connect ID "+connectID, http.StatusInternalServerError)
 	}
 
-	if result.RowsAffected() == 0 {
+	if rowsAffected == 0 {
 		return nil, pkg.NewError(nil, "no connect updated", http.StatusBadRequest)
 	}
 
 	return rc.sqlToInternal(sqlConnect), nil
 }
 
-func (rc *ConnectRepository) Delete(ctx context.Context, id string) error {
-	result, err := rc.db.Model(&connect{}).Where("id = ?", id).Delete()
+// Delete removes the connect and writes the events about it to the outbox in the same transaction.
+func (rc *ConnectRepository) Delete(ctx context.Context, id string, events ...*model.DomainEvent) error {
+	var rowsAffected int
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		result, err := tx.Model(&connect{}).Where("id = ?", id).Delete()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected = result.RowsAffected()
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		return insertOutboxEvents(tx, id, events)
+	})
 	if err != nil {
 		return pkg.NewError(err, "failed to delete connect", http.StatusInternalServerError)
 	}
-	if result.RowsAffected() == 0 {
+
+	if rowsAffected == 0 {
 		return pkg.NewError(nil, "no connect deleted", http.StatusBadRequest)
 	}
 
@@ -158,6 +198,230 @@ func (rc *ConnectRepository) GetByID(ctx context.Context, connectID string) (*mo
 	return rc.sqlToInternal(connect), nil
 }
 
+// Exists reports whether there is a connect between the users in either direction with one of the statuses.
+func (rc *ConnectRepository) Exists(ctx context.Context, userID, otherID string, statuses ...model.RequestStatus) (bool, error) {
+	// the ids are compared as numbers to match the pair index
+	uID, _ := strconv.Atoi(userID)
+	oID, _ := strconv.Atoi(otherID)
+
+	query := rc.db.Model((*connect)(nil)).
+		Where("LEAST(connect.user_id, connect.friend_id) = LEAST(?::bigint, ?::bigint)", uID, oID).
+		Where("GREATEST(connect.user_id, connect.friend_id) = GREATEST(?::bigint, ?::bigint)", uID, oID)
+
+	if len(statuses) > 0 {
+		values := make([]int, 0, len(statuses))
+		for _, v := range statuses {
+			values = append(values, int(v))
+		}
+
+		query = query.WhereIn("connect.status IN (?)", values)
+	}
+
+	exists, err := query.Exists()
+	if err != nil {
+		return false, pkg.NewError(err, "failed to check connect", http.StatusInternalServerError)
+	}
+
+	return exists, nil
+}
+
+// ConnectionIDs returns the ids of the users having an approved connect with the user.
+func (rc *ConnectRepository) ConnectionIDs(ctx context.Context, userID string) ([]string, error) {
+	ids := make([]int, 0)
+
+	err := rc.db.Model((*connect)(nil)).
+		ColumnExpr("CASE WHEN connect.user_id = ? THEN connect.friend_id ELSE connect.user_id END", userID).
+		Where("connect.user_id = ? OR connect.friend_id = ?", userID, userID).
+		Where("connect.status = ?", int(model.RequestStatusApproved)).
+		Select(&ids)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list connections", http.StatusInternalServerError)
+	}
+
+	connectionIDs := make([]string, 0, len(ids))
+	for _, v := range ids {
+		connectionIDs = append(connectionIDs, strconv.Itoa(v))
+	}
+
+	return connectionIDs, nil
+}
+
+// ExpirePending deletes the pending connects created before the given time and returns them.
+// A connect.expired event is written to the outbox for each of them in the same transaction.
+func (rc *ConnectRepository) ExpirePending(ctx context.Context, createdBefore time.Time) ([]model.Connect, error) {
+	connects := make([]connect, 0)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&connects).
+			Where("status = ?", int(model.RequestStatusPending)).
+			Where("created_at < ?", createdBefore).
+			Returning("*").
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		events := make([]*model.DomainEvent, 0, len(connects))
+		for _, v := range connects {
+			event, err := model.NewDomainEvent(model.DomainEventConnectExpired, strconv.Itoa(v.ID), model.ConnectEventPayload{
+				SenderID:   strconv.Itoa(v.UserID),
+				ReceiverID: strconv.Itoa(v.FriendID),
+				ActorID:    strconv.Itoa(v.FriendID),
+			})
+			if err != nil {
+				return err
+			}
+
+			events = append(events, event)
+		}
+
+		return insertOutboxEvents(tx, "", events)
+	})
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to expire connects", http.StatusInternalServerError)
+	}
+
+	internalConnects := make([]model.Connect, 0, len(connects))
+	for _, v := range connects {
+		internalConnects = append(internalConnects, *rc.sqlToInternal(&v))
+	}
+
+	return internalConnects, nil
+}
+
+// CountByPeriod counts the approved connections of the user grouped by their approval time truncated to period.
+// Connections approved before the approval time was recorded are left out.
+func (rc *ConnectRepository) CountByPeriod(ctx context.Context, userID string, period model.TimelinePeriod) ([]model.PeriodCount, error) {
+	if userID == "" || userID == "0" {
+		return nil, pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	counts := make([]model.PeriodCount, 0)
+
+	err := rc.db.Model((*connect)(nil)).
+		ColumnExpr("date_trunc(?, connect.approved_at) AS period", string(period)).
+		ColumnExpr("count(*) AS count").
+		Where("connect.user_id = ? OR connect.friend_id = ?", userID, userID).
+		Where("connect.status = ?", int(model.RequestStatusApproved)).
+		Where("connect.approved_at IS NOT NULL").
+		Group("period").
+		Order("period").
+		Select(&counts)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to count connects", http.StatusInternalServerError)
+	}
+
+	return counts, nil
+}
+
+// suggestion weights, a shared contact counts more than a single mutual connection or shared event
+const (
+	suggestionMutualWeight  = 2
+	suggestionEventWeight   = 3
+	suggestionContactWeight = 5
+)
+
+// Suggestions ranks the users the user may know by mutual connections, events both are in and imported contacts.
+// Users already connected, with a pending request or blocked either way are left out.
+func (rc *ConnectRepository) Suggestions(ctx context.Context, userID string, opts model.PaginationOpts) (*model.SuggestionList, error) {
+	if userID == "" || userID == "0" {
+		return nil, pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	// the other side of every approved connect
+	friends := rc.db.Model((*connect)(nil)).
+		ColumnExpr("CASE WHEN connect.user_id = ? THEN connect.friend_id ELSE connect.user_id END AS id", userID).
+		Where("connect.user_id = ? OR connect.friend_id = ?", userID, userID).
+		Where("connect.status = ?", int(model.RequestStatusApproved))
+
+	// the other side of every connect in any status, rejected ones are deleted
+	related := rc.db.Model((*connect)(nil)).
+		ColumnExpr("CASE WHEN connect.user_id = ? THEN connect.friend_id ELSE connect.user_id END", userID).
+		Where("connect.user_id = ? OR connect.friend_id = ?", userID, userID)
+
+	// events the user owns, co-owns or is tagged in
+	sharedEvents := rc.db.Model((*event)(nil)).
+		ColumnExpr("event.id").
+		Where("event.user_id = ?", userID).
+		UnionAll(rc.db.Model((*mention)(nil)).
+			ColumnExpr("mention.event_id").
+			Where("mention.user_id = ? AND mention.status = ?", userID, int(model.RequestStatusApproved))).
+		UnionAll(rc.db.Model((*coOwner)(nil)).
+			ColumnExpr("co_owner.event_id").
+			Where("co_owner.user_id = ? AND co_owner.status = ? AND co_owner.event_id IS NOT NULL", userID, int(model.RequestStatusApproved)))
+
+	// every candidate row carries the reason it was found
+	candidates := rc.db.Model((*connect)(nil)).
+		ColumnExpr("CASE WHEN connect.user_id = friend.id THEN connect.friend_id ELSE connect.user_id END AS user_id").
+		ColumnExpr("friend.id AS friend_id, NULL::bigint AS event_id, false AS in_contacts").
+		Join("JOIN friends AS friend ON friend.id IN (connect.user_id, connect.friend_id)").
+		Where("connect.status = ?", int(model.RequestStatusApproved)).
+		UnionAll(rc.db.Model((*event)(nil)).
+			ColumnExpr("event.user_id, NULL, event.id, false").
+			Join("JOIN shared_events AS shared_event ON shared_event.id = event.id")).
+		UnionAll(rc.db.Model((*mention)(nil)).
+			ColumnExpr("mention.user_id, NULL, mention.event_id, false").
+			Join("JOIN shared_events AS shared_event ON shared_event.id = mention.event_id").
+			Where("mention.status = ?", int(model.RequestStatusApproved))).
+		UnionAll(rc.db.Model((*coOwner)(nil)).
+			ColumnExpr("co_owner.user_id, NULL, co_owner.event_id, false").
+			Join("JOIN shared_events AS shared_event ON shared_event.id = co_owner.event_id").
+			Where("co_owner.status = ?", int(model.RequestStatusApproved))).
+		UnionAll(rc.db.Model((*contact)(nil)).
+			ColumnExpr(`"user".id, NULL, NULL, true`).
+			Join(`JOIN users AS "user" ON lower("user".email) = contact.email`).
+			Where("contact.user_id = ?", userID))
+
+	rows := make([]suggestion, 0)
+
+	query := rc.db.Model().
+		With("friends", friends).
+		With("shared_events", sharedEvents).
+		With("candidates", candidates).
+		TableExpr("candidates AS candidate").
+		Join(`JOIN users AS "user" ON "user".id = candidate.user_id`).
+		ColumnExpr(`"user".id, "user".username`).
+		ColumnExpr("count(DISTINCT candidate.friend_id) AS mutual_connections").
+		ColumnExpr("count(DISTINCT candidate.event_id) AS shared_events").
+		ColumnExpr("bool_or(candidate.in_contacts) AS in_contacts").
+		ColumnExpr("count(DISTINCT candidate.friend_id) * ? + count(DISTINCT candidate.event_id) * ? + "+
+			"CASE WHEN bool_or(candidate.in_contacts) THEN ? ELSE 0 END AS score",
+			suggestionMutualWeight, suggestionEventWeight, suggestionContactWeight).
+		Where("candidate.user_id != ?", userID).
+		Where("candidate.user_id NOT IN (?)", related).
+		Where("candidate.user_id NOT IN ("+blockedUsersSQL+")", userID, userID).
+		GroupExpr(`"user".id`).
+		OrderExpr(`score DESC, "user".id`)
+
+	query = applyStandardQueries(query, opts)
+
+	if err := query.Select(&rows); err != nil {
+		return nil, pkg.NewError(err, "failed to list connect suggestions", http.StatusInternalServerError)
+	}
+
+	suggestions := make([]model.Suggestion, 0, len(rows))
+	for _, v := range rows {
+		suggestions = append(suggestions, model.Suggestion{
+			User: model.User{
+				ID:       strconv.Itoa(v.ID),
+				Username: v.Username,
+			},
+			MutualConnections: v.MutualConnections,
+			SharedEvents:      v.SharedEvents,
+			InContacts:        v.InContacts,
+			Score:             v.Score,
+		})
+	}
+
+	return &model.SuggestionList{
+		Suggestions: suggestions,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
 func (rc *ConnectRepository) fillConnectsRequestsFilter(tx *orm.Query, opts *model.ConnectFindOpts) *orm.Query {
 	if opts.Status.IsSended {
 		tx = applyFilterWithOperand(tx, "status", opts.Status)
@@ -183,6 +447,8 @@ func (rc *ConnectRepository) fillFields(tx *orm.Query, opts *model.ConnectFindOp
 			"connect.status",
 			"connect.user_id",
 			"connect.friend_id",
+			"connect.message",
+			"connect.created_at",
 		)
 	}
 
@@ -201,10 +467,13 @@ func (rc *ConnectRepository) internalToSQL(newConnect *model.Connect) *connect {
 	friendID, _ := strconv.Atoi(newConnect.FriendID)
 
 	return &connect{
-		ID:       cID,
-		Status:   int(newConnect.Status),
-		UserID:   userID,
-		FriendID: friendID,
+		CreatedAt:  newConnect.CreatedAt,
+		ApprovedAt: newConnect.ApprovedAt,
+		ID:         cID,
+		Message:    newConnect.Message,
+		Status:     int(newConnect.Status),
+		UserID:     userID,
+		FriendID:   friendID,
 		User: &user{
 			ID:       userID,
 			Username: newConnect.User.Username,
@@ -245,26 +514,63 @@ func (rc *ConnectRepository) sqlToInternal(newConnect *connect) *model.Connect {
 	}
 
 	return &model.Connect{
-		ID:       cID,
-		Status:   model.RequestStatus(newConnect.Status),
-		UserID:   userID,
-		FriendID: friendID,
-		User:     user,
-		Friend:   friend,
+		CreatedAt:  newConnect.CreatedAt,
+		ApprovedAt: newConnect.ApprovedAt,
+		ID:         cID,
+		Message:    newConnect.Message,
+		Status:     model.RequestStatus(newConnect.Status),
+		UserID:     userID,
+		FriendID:   friendID,
+		User:       user,
+		Friend:     friend,
 	}
 }
 
 func (rc *ConnectRepository) createSchema(db *pg.DB) error {
-	model := (*connect)(nil)
+	table := (*connect)(nil)
 
 	opts := &orm.CreateTableOptions{
 		IfNotExists:   true,
 		FKConstraints: true,
 	}
 
-	if err := db.Model(model).CreateTable(opts); err != nil {
+	if err := db.Model(table).CreateTable(opts); err != nil {
 		return pkg.NewError(err, "failed to create connect table", http.StatusInternalServerError)
 	}
 
+	if err := addMissingColumns(db, table); err != nil {
+		return pkg.NewError(err, "failed to add connect columns", http.StatusInternalServerError)
+	}
+
+	var pairIndexExists bool
+	if _, err := db.QueryOne(pg.Scan(&pairIndexExists), "SELECT to_regclass('connect_pair_idx') IS NOT NULL"); err != nil {
+		return pkg.NewError(err, "failed to find connect index", http.StatusInternalServerError)
+	}
+
+	// one connect per pair of users in either direction, duplicates created before the index
+	// are dropped once keeping the approved one or else the oldest
+	if !pairIndexExists {
+		dedupeQuery := fmt.Sprintf("DELETE FROM connects WHERE id IN (SELECT id FROM (SELECT id, row_number() OVER ("+
+			"PARTITION BY LEAST(user_id, friend_id), GREATEST(user_id, friend_id) ORDER BY status = %d DESC, id"+
+			") AS n FROM connects) AS duplicate WHERE duplicate.n > 1)", model.RequestStatusApproved)
+		if _, err := db.Exec(dedupeQuery); err != nil {
+			return pkg.NewError(err, "failed to remove duplicate connects", http.StatusInternalServerError)
+		}
+	}
+
+	indexQueries := []string{
+		"CREATE UNIQUE INDEX IF NOT EXISTS connect_pair_idx ON connects (LEAST(user_id, friend_id), GREATEST(user_id, friend_id))",
+		// expiry sweeps over pending requests
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS connect_pending_idx ON connects (created_at) WHERE status = %d", model.RequestStatusPending),
+		// connect listings and feeds filter on either side of the pair
+		"CREATE INDEX IF NOT EXISTS connect_user_idx ON connects (user_id)",
+		"CREATE INDEX IF NOT EXISTS connect_friend_idx ON connects (friend_id)",
+	}
+	for _, query := range indexQueries {
+		if _, err := db.Exec(query); err != nil {
+			return pkg.NewError(err, "failed to create connect index", http.StatusInternalServerError)
+		}
+	}
+
 	return nil
 }
@@ -1,10 +1,25 @@
 package repositories
 
+import "time"
+
 type connect struct {
-	User     *user `json:"user" pg:"rel:has-one,on_delete:CASCADE"`
-	Friend   *user `json:"friend" pg:"rel:has-one,on_delete:CASCADE"`
-	ID       int   `json:"id" pg:",pk"`
-	Status   int   `json:"status"`
-	UserID   int   `json:"user_id" pg:",notnull"`
-	FriendID int   `json:"friend_id" pg:",notnull"`
+	CreatedAt  time.Time `json:"created_at"`
+	ApprovedAt time.Time `json:"approved_at"`
+	User       *user     `json:"user" pg:"rel:has-one,on_delete:CASCADE"`
+	Friend     *user     `json:"friend" pg:"rel:has-one,on_delete:CASCADE"`
+	Message    string    `json:"message"`
+	ID         int       `json:"id" pg:",pk"`
+	Status     int       `json:"status"`
+	UserID     int       `json:"user_id" pg:",notnull"`
+	FriendID   int       `json:"friend_id" pg:",notnull"`
+}
+
+// suggestion is a ranked row of the connect suggestions query
+type suggestion struct {
+	Username          string
+	ID                int
+	MutualConnections int
+	SharedEvents      int
+	Score             int
+	InContacts        bool
 }
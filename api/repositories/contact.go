@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type ContactRepository struct {
+	db *pg.DB
+}
+
+func NewContactRepository(db *pg.DB) *ContactRepository {
+	rc := &ContactRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Import stores the contacts of the user with lower cased emails, already imported emails only get their names updated.
+func (rc *ContactRepository) Import(ctx context.Context, contacts []model.Contact) (int, error) {
+	if len(contacts) == 0 {
+		return 0, nil
+	}
+
+	sqlContacts := make([]contact, 0, len(contacts))
+	seen := make(map[string]bool, len(contacts))
+	for _, v := range contacts {
+		sqlContact := rc.internalToSQL(&v)
+		// the same email twice in one insert fails the upsert
+		if seen[sqlContact.Email] {
+			continue
+		}
+
+		seen[sqlContact.Email] = true
+		sqlContacts = append(sqlContacts, *sqlContact)
+	}
+
+	result, err := rc.db.Model(&sqlContacts).
+		OnConflict("(user_id, email) DO UPDATE").
+		Set("name = EXCLUDED.name").
+		Insert()
+	if err != nil {
+		return 0, pkg.NewError(err, "failed to import contacts", http.StatusInternalServerError)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (rc *ContactRepository) Delete(ctx context.Context, userID, contactID string) error {
+	result, err := rc.db.Model((*contact)(nil)).Where("id = ? AND user_id = ?", contactID, userID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete contact", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no contact deleted", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *ContactRepository) List(ctx context.Context, opts *model.ContactFindOpts) (*model.ContactList, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	contacts := make([]contact, 0)
+
+	query := rc.db.Model(&contacts).
+		Where("contact.user_id = ?", opts.UserID).
+		OrderExpr("contact.name, contact.email")
+
+	query = applyStandardQueries(query, opts.PaginationOpts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list contacts", http.StatusInternalServerError)
+	}
+
+	internalContacts := make([]model.Contact, 0, len(contacts))
+	for _, v := range contacts {
+		internalContacts = append(internalContacts, *rc.sqlToInternal(&v))
+	}
+
+	return &model.ContactList{
+		Contacts: internalContacts,
+		Total:    count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+func (rc *ContactRepository) internalToSQL(newContact *model.Contact) *contact {
+	cID, _ := strconv.Atoi(newContact.ID)
+	userID, _ := strconv.Atoi(newContact.UserID)
+
+	return &contact{
+		CreatedAt: newContact.CreatedAt,
+		ID:        cID,
+		UserID:    userID,
+		Name:      strings.TrimSpace(newContact.Name),
+		Email:     strings.ToLower(strings.TrimSpace(newContact.Email)),
+	}
+}
+
+func (rc *ContactRepository) sqlToInternal(newContact *contact) *model.Contact {
+	return &model.Contact{
+		CreatedAt: newContact.CreatedAt,
+		ID:        strconv.Itoa(newContact.ID),
+		UserID:    strconv.Itoa(newContact.UserID),
+		Name:      newContact.Name,
+		Email:     newContact.Email,
+	}
+}
+
+func (rc *ContactRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*contact)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create contact table", http.StatusInternalServerError)
+	}
+
+	// contacts are matched to users by the lower cased email
+	indexQuery := "CREATE INDEX IF NOT EXISTS user_email_lower_idx ON users (lower(email))"
+	if _, err := db.Exec(indexQuery); err != nil {
+		return pkg.NewError(err, "failed to create user email index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "time"
+
+type contact struct {
+	CreatedAt time.Time `json:"created_at"`
+	User      *user     `json:"user" pg:"rel:has-one"`
+	ID        int       `json:"id" pg:",pk"`
+	UserID    int       `json:"user_id" pg:",notnull,unique:contact_user_email,on_delete:CASCADE"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email" pg:",notnull,unique:contact_user_email"`
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type EmailQueueRepository struct {
+	db *pg.DB
+}
+
+func NewEmailQueueRepository(db *pg.DB) *EmailQueueRepository {
+	rc := &EmailQueueRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Enqueue stores the email to be sent with the next delivery.
+func (rc *EmailQueueRepository) Enqueue(ctx context.Context, message *model.EmailMessage) error {
+	now := time.Now()
+
+	sqlEmail := rc.internalToSQL(&model.QueuedEmail{
+		CreatedAt:     now,
+		NextAttemptAt: now,
+		EmailMessage:  *message,
+	})
+
+	if _, err := rc.db.Model(sqlEmail).Insert(); err != nil {
+		return pkg.NewError(err, "failed to queue email", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ClaimDue returns the emails due by now, oldest first, and leases them so other instances skip them.
+// Emails that ran out of attempts are not claimed again.
+func (rc *EmailQueueRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.QueuedEmail, error) {
+	emails := make([]queuedEmail, 0)
+
+	due := rc.db.Model((*queuedEmail)(nil)).
+		Column("id").
+		Where("failed_at IS NULL").
+		Where("next_attempt_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	_, err := rc.db.Model(&emails).
+		Set("next_attempt_at = ?", now.Add(lease)).
+		Where("id IN (?)", due).
+		Returning("*").
+		Update()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to claim queued emails", http.StatusInternalServerError)
+	}
+
+	internalEmails := make([]model.QueuedEmail, 0, len(emails))
+	for _, v := range emails {
+		internalEmails = append(internalEmails, *rc.sqlToInternal(&v))
+	}
+
+	return internalEmails, nil
+}
+
+// Delete removes a sent email from the queue, its links may carry tokens that are not kept longer than needed.
+func (rc *EmailQueueRepository) Delete(ctx context.Context, emailID string) error {
+	_, err := rc.db.Model((*queuedEmail)(nil)).Where("id = ?", emailID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete queued email", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// Reschedule records a failed attempt of the email and when it is tried again, or when it was given up.
+func (rc *EmailQueueRepository) Reschedule(ctx context.Context, email *model.QueuedEmail) error {
+	sqlEmail := rc.internalToSQL(email)
+
+	_, err := rc.db.Model(sqlEmail).
+		Column("next_attempt_at", "failed_at", "last_error", "attempts").
+		WherePK().
+		Update()
+	if err != nil {
+		return pkg.NewError(err, "failed to reschedule queued email", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (rc *EmailQueueRepository) internalToSQL(email *model.QueuedEmail) *queuedEmail {
+	id, _ := strconv.Atoi(email.ID)
+
+	return &queuedEmail{
+		CreatedAt:     email.CreatedAt,
+		NextAttemptAt: email.NextAttemptAt,
+		FailedAt:      email.FailedAt,
+		ID:            id,
+		Kind:          string(email.Kind),
+		Recipient:     email.To,
+		Subject:       email.Subject,
+		HTML:          email.HTML,
+		Text:          email.Text,
+		LastError:     email.LastError,
+		Attempts:      email.Attempts,
+	}
+}
+
+func (rc *EmailQueueRepository) sqlToInternal(email *queuedEmail) *model.QueuedEmail {
+	return &model.QueuedEmail{
+		CreatedAt:     email.CreatedAt,
+		NextAttemptAt: email.NextAttemptAt,
+		FailedAt:      email.FailedAt,
+		EmailMessage: model.EmailMessage{
+			To:      email.Recipient,
+			Subject: email.Subject,
+			HTML:    email.HTML,
+			Text:    email.Text,
+			Kind:    model.EmailKind(email.Kind),
+		},
+		ID:        strconv.Itoa(email.ID),
+		LastError: email.LastError,
+		Attempts:  email.Attempts,
+	}
+}
+
+func (rc *EmailQueueRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*queuedEmail)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create email queue table", http.StatusInternalServerError)
+	}
+
+	if _, err := db.Exec("CREATE INDEX IF NOT EXISTS queued_email_due_idx ON queued_emails (next_attempt_at) WHERE failed_at IS NULL"); err != nil {
+		return pkg.NewError(err, "failed to create email queue index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
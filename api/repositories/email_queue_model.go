@@ -0,0 +1,17 @@
+package repositories
+
+import "time"
+
+type queuedEmail struct {
+	CreatedAt     time.Time `json:"created_at"`
+	NextAttemptAt time.Time `json:"next_attempt_at" pg:",notnull"`
+	FailedAt      time.Time `json:"failed_at"`
+	ID            int       `json:"id" pg:",pk"`
+	Kind          string    `json:"kind" pg:",notnull"`
+	Recipient     string    `json:"recipient" pg:",notnull"`
+	Subject       string    `json:"subject" pg:",notnull"`
+	HTML          string    `json:"html"`
+	Text          string    `json:"text"`
+	LastError     string    `json:"last_error"`
+	Attempts      int       `json:"attempts" pg:",notnull,use_zero"`
+}
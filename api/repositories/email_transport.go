@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"gopkg.in/gomail.v2"
+)
+
+// SMTPEmailTransport sends emails through the SMTP server of SMTP_HOST with the EMAIL_ADDRESS account.
+type SMTPEmailTransport struct {
+	dialer *gomail.Dialer
+	from   string
+}
+
+func NewSMTPEmailTransport() *SMTPEmailTransport {
+	smtpHost := os.Getenv("SMTP_HOST")
+	if smtpHost == "" {
+		smtpHost = "smtp.gmail.com"
+	}
+
+	smtpPort, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+	if err != nil {
+		smtpPort = 587
+	}
+
+	email := os.Getenv("EMAIL_ADDRESS")
+	password := os.Getenv("EMAIL_PASSWORD")
+
+	return &SMTPEmailTransport{
+		dialer: gomail.NewDialer(smtpHost, smtpPort, email, password),
+		from:   email,
+	}
+}
+
+func (rc *SMTPEmailTransport) Send(ctx context.Context, message *model.EmailMessage) error {
+	if err := rc.dialer.DialAndSend(newMailMessage(rc.from, message)); err != nil {
+		return pkg.NewError(err, "failed to send email", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// FileEmailTransport writes emails as .eml files to a directory instead of sending them, for local development and tests.
+// Mail clients open the files as they would have been received.
+type FileEmailTransport struct {
+	dir  string
+	from string
+}
+
+func NewFileEmailTransport(dir string) *FileEmailTransport {
+	if dir == "" {
+		dir = "mailbox"
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logger.Log.Fatalf("failed to create mailbox directory: %v", err)
+	}
+
+	return &FileEmailTransport{
+		dir:  dir,
+		from: os.Getenv("EMAIL_ADDRESS"),
+	}
+}
+
+// unsafeFileNameChars are replaced in the recipient part of mailbox file names
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]`)
+
+func (rc *FileEmailTransport) Send(ctx context.Context, message *model.EmailMessage) error {
+	name := fmt.Sprintf("%d-%s-%s.eml", time.Now().UnixNano(), message.Kind, unsafeFileNameChars.ReplaceAllString(message.To, "_"))
+
+	file, err := os.Create(filepath.Join(rc.dir, name))
+	if err != nil {
+		return pkg.NewError(err, "failed to create mailbox file", http.StatusInternalServerError)
+	}
+	defer file.Close()
+
+	if _, err := newMailMessage(rc.from, message).WriteTo(file); err != nil {
+		return pkg.NewError(err, "failed to write mailbox file", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// LogEmailTransport only logs the emails, for environments that must not send any.
+// Bodies are not logged, they carry password reset tokens.
+type LogEmailTransport struct{}
+
+func NewLogEmailTransport() *LogEmailTransport {
+	return &LogEmailTransport{}
+}
+
+func (rc *LogEmailTransport) Send(ctx context.Context, message *model.EmailMessage) error {
+	logger.Log.Infow("email not sent, log transport", "to", message.To, "kind", message.Kind, "subject", message.Subject)
+	return nil
+}
+
+// newMailMessage returns the email with its text body and html alternative
+func newMailMessage(from string, message *model.EmailMessage) *gomail.Message {
+	m := gomail.NewMessage()
+	m.SetHeader("From", from)
+	m.SetHeader("To", message.To)
+	m.SetHeader("Subject", message.Subject)
+	m.SetBody("text/plain", message.Text)
+	m.AddAlternative("text/html", message.HTML)
+
+	return m
+}
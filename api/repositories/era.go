@@ -33,9 +33,15 @@ func NewEraRepository(db *pg.DB) *EraRepository {
 func (rc *EraRepository) Create(ctx context.Context, era *model.Era) (*model.Era, error) {
 	sqlEra := rc.internalToSQL(era)
 
-	q := rc.db.Model(sqlEra)
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Model(sqlEra).Insert(); err != nil {
+			return err
+		}
+
+		created := rc.sqlToInternal(sqlEra)
 
-	_, err := q.Insert()
+		return insertEntityEvent(tx, model.DomainEventEraCreated, created.ID, created)
+	})
 	if err != nil {
 		return nil, pkg.NewError(err, "failed to create era", http.StatusInternalServerError)
 	}
@@ -56,7 +62,8 @@ func (rc *EraRepository) Update(ctx context.Context, eraID string, era *model.Er
 
 	ownerID := util.GetOwnerIDFromCtx(ctx)
 
-	q = q.Where("id = ? AND user_id = ?", eraID, ownerID)
+	q = q.Where("id = ?", eraID)
+	q = applyOwnersFilter(q, "era_id", ownerID)
 
 	result, err := q.Update()
 	if err != nil {
@@ -75,7 +82,8 @@ func (rc *EraRepository) Delete(ctx context.Context, id string) error {
 
 	ownerID := util.GetOwnerIDFromCtx(ctx)
 
-	q = q.Where("id = ? AND user_id = ?", id, ownerID)
+	q = q.Where("id = ?", id)
+	q = applyOwnersFilter(q, "era_id", ownerID)
 
 	result, err := q.Delete()
 	if err != nil {
@@ -128,6 +136,56 @@ func (rc *EraRepository) List(ctx context.Context, opts *model.EraFindOpts) (*mo
 	}, nil
 }
 
+// CountByPeriod counts the eras matching opts grouped by their start time truncated to period, eras without one are left out.
+func (rc *EraRepository) CountByPeriod(ctx context.Context, opts *model.EraFindOpts, period model.TimelinePeriod) ([]model.PeriodCount, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	counts := make([]model.PeriodCount, 0)
+
+	query := rc.db.Model((*era)(nil)).
+		ColumnExpr("date_trunc(?, era.time_start) AS period", string(period)).
+		ColumnExpr("count(*) AS count").
+		Where("era.time_start IS NOT NULL").
+		Group("period").
+		Order("period")
+
+	query = rc.fillFilter(query, opts)
+
+	if err := query.Select(&counts); err != nil {
+		return nil, pkg.NewError(err, "failed to count eras", http.StatusInternalServerError)
+	}
+
+	return counts, nil
+}
+
+// Longest returns the era matching opts spanning the longest time, nil if no era has both ends set.
+func (rc *EraRepository) Longest(ctx context.Context, opts *model.EraFindOpts) (*model.Era, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	resp := new(era)
+
+	query := rc.db.Model(resp).
+		Where("era.time_start IS NOT NULL AND era.time_end IS NOT NULL").
+		OrderExpr("era.time_end - era.time_start DESC").
+		Limit(1)
+
+	query = rc.fillFilter(query, opts)
+
+	if err := query.Select(); err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, pkg.NewError(err, "failed to find longest era", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(resp), nil
+}
+
 func (rc *EraRepository) GetByID(ctx context.Context, eraID string) (*model.Era, error) {
 	if eraID == "" || eraID == "0" {
 		return nil, pkg.NewError(nil, "invalid era ID: "+eraID, http.StatusBadRequest)
@@ -154,8 +212,27 @@ func (rc *EraRepository) fillFilter(tx *orm.Query, opts *model.EraFindOpts) *orm
 		tx = applyFilterWithOperand(tx, "name", opts.Name)
 	}
 
-	if opts.UserID.IsSended {
-		tx = applyFilterWithOperand(tx, "user_id", opts.UserID)
+	if opts.SharedWith.IsSended {
+		tx = tx.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			if opts.UserID.IsSended {
+				q = applyFilterWithOperand(q, "era.user_id", opts.UserID)
+			}
+
+			q = q.WhereOr("era.id IN (SELECT co_owner.era_id FROM co_owners AS co_owner WHERE co_owner.user_id = ? AND co_owner.status = ?)",
+				opts.SharedWith.Value, int(model.RequestStatusApproved))
+
+			return q, nil
+		})
+	} else if opts.UserID.IsSended {
+		tx = applyFilterWithOperand(tx, "era.user_id", opts.UserID)
+	}
+
+	if opts.TimeStart.IsSended {
+		tx = applyFilterWithOperand(tx, "era.time_start", opts.TimeStart)
+	}
+
+	if opts.TimeEnd.IsSended {
+		tx = applyFilterWithOperand(tx, "era.time_end", opts.TimeEnd)
 	}
 
 	return tx
@@ -164,16 +241,18 @@ func (rc *EraRepository) fillFilter(tx *orm.Query, opts *model.EraFindOpts) *orm
 func (rc *EraRepository) internalToSQL(newEra *model.Era) *era {
 	eID, _ := strconv.Atoi(newEra.ID)
 	userID, _ := strconv.Atoi(newEra.UserID)
+	updatedByID, _ := strconv.Atoi(newEra.UpdatedByID)
 	return &era{
-		TimeStart: newEra.TimeStart,
-		TimeEnd:   newEra.TimeEnd,
-		Name:      newEra.Name,
-		Color:     newEra.Color,
-		UserID:    userID,
-		ID:        eID,
-		User:      &user{},
-		CreatedAt: newEra.CreatedAt,
-		UpdatedAt: newEra.UpdatedAt,
+		TimeStart:   newEra.TimeStart,
+		TimeEnd:     newEra.TimeEnd,
+		Name:        newEra.Name,
+		Color:       newEra.Color,
+		UserID:      userID,
+		ID:          eID,
+		User:        &user{},
+		CreatedAt:   newEra.CreatedAt,
+		UpdatedAt:   newEra.UpdatedAt,
+		UpdatedByID: updatedByID,
 	}
 }
 
@@ -184,16 +263,21 @@ func (rc *EraRepository) sqlToInternal(newEra *era) *model.Era {
 	user.ID = userID
 	user.Username = newEra.User.Username
 	user.Email = newEra.User.Email
+	var updatedByID string
+	if newEra.UpdatedByID != 0 {
+		updatedByID = strconv.Itoa(newEra.UpdatedByID)
+	}
 	return &model.Era{
-		TimeStart: newEra.TimeStart,
-		TimeEnd:   newEra.TimeEnd,
-		Name:      newEra.Name,
-		Color:     newEra.Color,
-		UserID:    userID,
-		ID:        eID,
-		User:      user,
-		CreatedAt: newEra.CreatedAt,
-		UpdatedAt: newEra.UpdatedAt,
+		TimeStart:   newEra.TimeStart,
+		TimeEnd:     newEra.TimeEnd,
+		Name:        newEra.Name,
+		Color:       newEra.Color,
+		UserID:      userID,
+		ID:          eID,
+		User:        user,
+		CreatedAt:   newEra.CreatedAt,
+		UpdatedAt:   newEra.UpdatedAt,
+		UpdatedByID: updatedByID,
 	}
 }
 
@@ -209,5 +293,9 @@ func (rc *EraRepository) createSchema(db *pg.DB) error {
 		return pkg.NewError(err, "failed to create era table", http.StatusInternalServerError)
 	}
 
+	if err := addMissingColumns(db, model); err != nil {
+		return pkg.NewError(err, "failed to add era columns", http.StatusInternalServerError)
+	}
+
 	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 	"github.com/fleimkeipa/lifery/pkg"
@@ -14,6 +16,16 @@ import (
 	"github.com/go-pg/pg/v10/orm"
 )
 
+// eventSearchVector is the document used for full-text search over events,
+// it has to match the expression of the event_search_idx index to use it.
+const eventSearchVector = "to_tsvector('simple', coalesce(event.name, '') || ' ' || coalesce(event.description, ''))"
+
+// maxOnThisDayEvents caps the memories returned for a single day
+const maxOnThisDayEvents = 50
+
+// lockedCapsuleSQL matches the published time capsules that have not opened yet, like model.Event.Locked
+const lockedCapsuleSQL = "event.sealed AND NOT event.draft AND event.unlock_at > now()"
+
 type EventRepository struct {
 	db *pg.DB
 }
@@ -37,9 +49,24 @@ func (rc *EventRepository) Create(ctx context.Context, event *model.Event) (*mod
 
 	sqlEvent := rc.internalToSQL(event)
 
-	q := rc.db.Model(sqlEvent)
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Model(sqlEvent).Insert(); err != nil {
+			return err
+		}
+
+		if err := rc.insertTags(tx, sqlEvent); err != nil {
+			return err
+		}
 
-	_, err := q.Insert()
+		// drafts are announced when they are published
+		if sqlEvent.Draft {
+			return nil
+		}
+
+		created := rc.snapshot(sqlEvent)
+
+		return insertEntityEvent(tx, model.DomainEventEventCreated, created.ID, created)
+	})
 	if err != nil {
 		return nil, pkg.NewError(err, "failed to create event "+event.Name, http.StatusInternalServerError)
 	}
@@ -47,39 +74,233 @@ func (rc *EventRepository) Create(ctx context.Context, event *model.Event) (*mod
 	return rc.sqlToInternal(sqlEvent), nil
 }
 
-func (rc *EventRepository) Update(ctx context.Context, eventID string, event *model.Event) (*model.Event, error) {
+// Update stores the changes made to read, the event as it was read. The creation time and the draft state
+// belong to publishing and are left out. If the event was published or opened since it was read the update
+// fails with a conflict, so a late write cannot make it a draft or a closed capsule again.
+func (rc *EventRepository) Update(ctx context.Context, eventID string, newEvent, read *model.Event) (*model.Event, error) {
 	if eventID == "" || eventID == "0" {
 		return nil, pkg.NewError(nil, "event id is empty", http.StatusBadRequest)
 	}
 
-	event.ID = eventID
-
-	sqlEvent := rc.internalToSQL(event)
+	newEvent.ID = eventID
 
-	q := rc.db.Model(sqlEvent)
+	sqlEvent := rc.internalToSQL(newEvent)
 
 	ownerID := util.GetOwnerIDFromCtx(ctx)
 
-	q = q.Where("id = ? AND user_id = ?", eventID, ownerID)
-
-	result, err := q.Update()
+	var rowsAffected int
+	var changed bool
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		q := tx.Model(sqlEvent).
+			ExcludeColumn("created_at", "draft").
+			Where("id = ?", eventID).
+			Where("draft = ?", read.Draft).
+			Where("opened_at IS NOT DISTINCT FROM ?", pg.NullTime{Time: read.OpenedAt})
+		q = applyOwnersFilter(q, "event_id", ownerID)
+
+		result, err := q.Update()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected = result.RowsAffected()
+		if rowsAffected == 0 {
+			// the event is still editable by the user, so it was published or opened meanwhile
+			q := tx.Model((*event)(nil)).Where("id = ?", eventID)
+			changed, err = applyOwnersFilter(q, "event_id", ownerID).Exists()
+
+			return err
+		}
+
+		// nil tags are left untouched, an empty list clears them
+		if sqlEvent.Tags != nil {
+			if _, err := tx.Model((*eventTag)(nil)).Where("event_id = ?", sqlEvent.ID).Delete(); err != nil {
+				return err
+			}
+
+			if err := rc.insertTags(tx, sqlEvent); err != nil {
+				return err
+			}
+		}
+
+		// the event is read back as the update leaves out the kept tags and the creation time
+		sqlEvent.Tags = nil
+		if err := tx.Model(sqlEvent).Where("event.id = ?", eventID).Relation("Tags").Select(); err != nil {
+			return err
+		}
+
+		if sqlEvent.Draft {
+			return nil
+		}
+
+		return insertEntityEvent(tx, model.DomainEventEventUpdated, eventID, rc.snapshot(sqlEvent))
+	})
 	if err != nil {
 		return nil, pkg.NewError(err, "failed to update event "+sqlEvent.Name, http.StatusInternalServerError)
 	}
 
-	if result.RowsAffected() == 0 {
+	if changed {
+		return nil, pkg.NewError(nil, "event changed since it was read: "+eventID, http.StatusConflict)
+	}
+
+	if rowsAffected == 0 {
 		return nil, pkg.NewError(nil, "no event updated: "+eventID, http.StatusBadRequest)
 	}
 
 	return rc.sqlToInternal(sqlEvent), nil
 }
 
+// Publish makes the draft visible. It returns a not found error if the event is not a draft of the user.
+func (rc *EventRepository) Publish(ctx context.Context, eventID, userID string) (*model.Event, error) {
+	if eventID == "" || eventID == "0" {
+		return nil, pkg.NewError(nil, "invalid event ID: "+eventID, http.StatusBadRequest)
+	}
+
+	events, err := rc.publish(ctx, func(q *orm.Query) *orm.Query {
+		return q.Where("event.id = ?", eventID).Where("event.user_id = ?", userID)
+	})
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to publish event "+eventID, http.StatusInternalServerError)
+	}
+
+	if len(events) == 0 {
+		return nil, pkg.NewError(nil, "no draft published: "+eventID, http.StatusNotFound)
+	}
+
+	return &events[0], nil
+}
+
+// PublishDue publishes the drafts scheduled by now.
+func (rc *EventRepository) PublishDue(ctx context.Context, now time.Time) ([]model.Event, error) {
+	events, err := rc.publish(ctx, func(q *orm.Query) *orm.Query {
+		return q.Where("event.publish_at <= ?", now)
+	})
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to publish due events", http.StatusInternalServerError)
+	}
+
+	return events, nil
+}
+
+// publish turns the drafts matching where into events in one transaction and writes their created and published events.
+// The creation time becomes the publishing time, so the events are listed in feeds as new.
+func (rc *EventRepository) publish(ctx context.Context, where func(q *orm.Query) *orm.Query) ([]model.Event, error) {
+	events := make([]event, 0)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		q := tx.Model(&events).
+			Set("draft = false").
+			Set("publish_at = NULL").
+			Set("created_at = now()").
+			Where("event.draft").
+			Returning("event.id")
+
+		if _, err := where(q).Update(); err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]int, 0, len(events))
+		for _, v := range events {
+			ids = append(ids, v.ID)
+		}
+
+		// the events are read back with their tags
+		events = make([]event, 0, len(ids))
+		if err := tx.Model(&events).Where("event.id IN (?)", pg.In(ids)).Relation("Tags").Order("event.id").Select(); err != nil {
+			return err
+		}
+
+		for _, v := range events {
+			published := rc.snapshot(&v)
+
+			if err := insertEntityEvent(tx, model.DomainEventEventCreated, published.ID, published); err != nil {
+				return err
+			}
+
+			if err := insertEntityEvent(tx, model.DomainEventEventPublished, published.ID, published); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	internalEvents := make([]model.Event, 0, len(events))
+	for _, v := range events {
+		internalEvents = append(internalEvents, *rc.sqlToInternal(&v))
+	}
+
+	return internalEvents, nil
+}
+
+// OpenDue marks the time capsules unlocked by now as opened and writes an event.unlocked event for each of them,
+// so their owners and recipients are notified once. Drafts open after they are published.
+func (rc *EventRepository) OpenDue(ctx context.Context, now time.Time) ([]model.Event, error) {
+	events := make([]event, 0)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&events).
+			Set("opened_at = ?", now).
+			Where("event.sealed").
+			Where("NOT event.draft").
+			Where("event.opened_at IS NULL").
+			Where("event.unlock_at <= ?", now).
+			Returning("event.id").
+			Update()
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]int, 0, len(events))
+		for _, v := range events {
+			ids = append(ids, v.ID)
+		}
+
+		events = make([]event, 0, len(ids))
+		if err := tx.Model(&events).Where("event.id IN (?)", pg.In(ids)).Relation("Tags").Order("event.id").Select(); err != nil {
+			return err
+		}
+
+		for _, v := range events {
+			opened := rc.sqlToInternal(&v)
+
+			if err := insertEntityEvent(tx, model.DomainEventEventUnlocked, opened.ID, opened); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to open time capsules", http.StatusInternalServerError)
+	}
+
+	internalEvents := make([]model.Event, 0, len(events))
+	for _, v := range events {
+		internalEvents = append(internalEvents, *rc.sqlToInternal(&v))
+	}
+
+	return internalEvents, nil
+}
+
 func (rc *EventRepository) Delete(ctx context.Context, eventID string) error {
 	q := rc.db.Model(&event{})
 
 	ownerID := util.GetOwnerIDFromCtx(ctx)
 
-	q = q.Where("id = ? AND user_id = ?", eventID, ownerID)
+	q = q.Where("id = ?", eventID)
+	q = applyOwnersFilter(q, "event_id", ownerID)
 
 	result, err := q.Delete()
 	if err != nil {
@@ -109,6 +330,8 @@ func (rc *EventRepository) List(ctx context.Context, opts *model.EventFindOpts)
 
 	query = rc.fillFilter(query, opts)
 
+	query = query.Relation("Tags")
+
 	count, err := query.SelectAndCount()
 	if err != nil {
 		return nil, pkg.NewError(err, "failed to list events", http.StatusInternalServerError)
@@ -119,6 +342,12 @@ func (rc *EventRepository) List(ctx context.Context, opts *model.EventFindOpts)
 		internalEvents = append(internalEvents, *rc.sqlToInternal(&v))
 	}
 
+	rc.redactLocked(internalEvents, util.GetOwnerIDFromCtx(ctx))
+
+	if err := rc.attachReactions(ctx, internalEvents); err != nil {
+		return nil, err
+	}
+
 	return &model.EventList{
 		Events: internalEvents,
 		Total:  count,
@@ -129,6 +358,214 @@ func (rc *EventRepository) List(ctx context.Context, opts *model.EventFindOpts)
 	}, nil
 }
 
+// CountByPeriod counts the events matching opts grouped by their date truncated to period, undated events are left out.
+func (rc *EventRepository) CountByPeriod(ctx context.Context, opts *model.EventFindOpts, period model.TimelinePeriod) ([]model.PeriodCount, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	counts := make([]model.PeriodCount, 0)
+
+	query := rc.db.Model((*event)(nil)).
+		ColumnExpr("date_trunc(?, event.date) AS period", string(period)).
+		ColumnExpr("count(*) AS count").
+		Where("event.date IS NOT NULL").
+		Group("period").
+		Order("period")
+
+	query = rc.fillFilter(query, opts)
+
+	if err := query.Select(&counts); err != nil {
+		return nil, pkg.NewError(err, "failed to count events", http.StatusInternalServerError)
+	}
+
+	return counts, nil
+}
+
+// CountByItemType counts the items of the events matching opts grouped by their type.
+func (rc *EventRepository) CountByItemType(ctx context.Context, opts *model.EventFindOpts) ([]model.EventTypeCount, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	counts := make([]model.EventTypeCount, 0)
+
+	// rows written before items were always an array are skipped instead of failing the query
+	query := rc.db.Model((*event)(nil)).
+		ColumnExpr("(item->>'type')::int AS type").
+		ColumnExpr("count(*) AS count").
+		Join("CROSS JOIN LATERAL jsonb_array_elements(CASE WHEN jsonb_typeof(event.items) = 'array' THEN event.items ELSE '[]' END) AS item").
+		Group("type").
+		Order("type")
+
+	query = rc.fillFilter(query, opts)
+
+	if err := query.Select(&counts); err != nil {
+		return nil, pkg.NewError(err, "failed to count event items", http.StatusInternalServerError)
+	}
+
+	return counts, nil
+}
+
+// CountByVisibility counts the events matching opts grouped by their visibility.
+func (rc *EventRepository) CountByVisibility(ctx context.Context, opts *model.EventFindOpts) ([]model.VisibilityCount, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	counts := make([]model.VisibilityCount, 0)
+
+	query := rc.db.Model((*event)(nil)).
+		ColumnExpr("event.visibility").
+		ColumnExpr("count(*) AS count").
+		Group("event.visibility").
+		Order("event.visibility")
+
+	query = rc.fillFilter(query, opts)
+
+	if err := query.Select(&counts); err != nil {
+		return nil, pkg.NewError(err, "failed to count events by visibility", http.StatusInternalServerError)
+	}
+
+	return counts, nil
+}
+
+// Gaps returns the longest periods of at least minDays between consecutive dated events matching opts.
+func (rc *EventRepository) Gaps(ctx context.Context, opts *model.EventFindOpts, minDays, limit int) ([]model.Gap, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	gaps := make([]model.Gap, 0)
+
+	dates := rc.db.Model((*event)(nil)).
+		ColumnExpr("event.date").
+		ColumnExpr("lag(event.date) OVER (ORDER BY event.date) AS prev_date").
+		Where("event.date IS NOT NULL")
+
+	dates = rc.fillFilter(dates, opts)
+
+	err := rc.db.Model().
+		TableExpr("(?) AS gap", dates).
+		ColumnExpr("gap.prev_date AS start").
+		ColumnExpr(`gap.date AS "end"`).
+		ColumnExpr("gap.date::date - gap.prev_date::date AS days").
+		Where("gap.date::date - gap.prev_date::date >= ?", minDays).
+		OrderExpr("days DESC").
+		Limit(limit).
+		Select(&gaps)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to find event gaps", http.StatusInternalServerError)
+	}
+
+	return gaps, nil
+}
+
+// Feed returns the public and private events of the approved connections of the user
+// and the public events of the users followed by the user, newest first.
+// Muted users and users blocked in either direction are left out.
+// Pages are continued with the created time and id of the last event instead of an offset.
+func (rc *EventRepository) Feed(ctx context.Context, opts *model.FeedFindOpts) ([]model.Event, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	events := make([]event, 0)
+
+	// the other side of every approved connect
+	friends := rc.db.Model((*connect)(nil)).
+		ColumnExpr("CASE WHEN connect.user_id = ? THEN connect.friend_id ELSE connect.user_id END", opts.UserID).
+		Where("connect.user_id = ? OR connect.friend_id = ?", opts.UserID, opts.UserID).
+		Where("connect.status = ?", int(model.RequestStatusApproved))
+
+	followed := rc.db.Model((*follow)(nil)).
+		ColumnExpr("follow.followed_id").
+		Where("follow.user_id = ?", opts.UserID)
+
+	// only the id and username of the owners are loaded
+	query := rc.db.Model(&events).
+		Relation("User.id").
+		Relation("User.username").
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+				q = q.Where("event.user_id IN (?)", friends).
+					WhereIn("event.visibility IN (?)", []int{int(model.EventVisibilityPublic), int(model.EventVisibilityPrivate)})
+				return q, nil
+			})
+
+			q = q.WhereOrGroup(func(q *orm.Query) (*orm.Query, error) {
+				q = q.Where("event.user_id IN (?)", followed).
+					Where("event.visibility = ?", int(model.EventVisibilityPublic))
+				return q, nil
+			})
+
+			return q, nil
+		}).
+		Where("NOT event.draft").
+		Where("coalesce(cardinality(event.recipient_ids), 0) = 0 OR ? = ANY(event.recipient_ids)", opts.UserID).
+		Where("event.user_id NOT IN (SELECT mute.muted_id FROM mutes AS mute WHERE mute.user_id = ?)", opts.UserID).
+		Where("event.user_id NOT IN ("+blockedUsersSQL+")", opts.UserID, opts.UserID).
+		Relation("Tags").
+		OrderExpr("event.created_at DESC, event.id DESC").
+		Limit(opts.Limit)
+
+	if opts.After != nil {
+		query = query.Where("(event.created_at, event.id) < (?, ?)", opts.After.CreatedAt, opts.After.ID)
+	}
+
+	if err := query.Select(); err != nil {
+		return nil, pkg.NewError(err, "failed to list feed", http.StatusInternalServerError)
+	}
+
+	internalEvents := make([]model.Event, 0, len(events))
+	for _, v := range events {
+		internalEvents = append(internalEvents, *rc.sqlToInternal(&v))
+	}
+
+	rc.redactLocked(internalEvents, opts.UserID)
+
+	if err := rc.attachReactions(ctx, internalEvents); err != nil {
+		return nil, err
+	}
+
+	return internalEvents, nil
+}
+
+// OnThisDay returns the events of the user from the same calendar day as day in earlier years, newest first.
+// The day is compared in the location of day, so it matches the calendar of the user.
+func (rc *EventRepository) OnThisDay(ctx context.Context, userID string, day time.Time) ([]model.Event, error) {
+	if userID == "" || userID == "0" {
+		return nil, pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	events := make([]event, 0)
+
+	localDate := "(event.date AT TIME ZONE ?)"
+	tz := day.Location().String()
+
+	err := rc.db.Model(&events).
+		Where("event.user_id = ?", userID).
+		Where("NOT event.draft").
+		Where("NOT ("+lockedCapsuleSQL+")").
+		Where("extract(month FROM "+localDate+") = ?", tz, int(day.Month())).
+		Where("extract(day FROM "+localDate+") = ?", tz, day.Day()).
+		Where("extract(year FROM "+localDate+") < ?", tz, day.Year()).
+		Relation("Tags").
+		Order("date DESC").
+		Limit(maxOnThisDayEvents).
+		Select()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list events on this day", http.StatusInternalServerError)
+	}
+
+	internalEvents := make([]model.Event, 0, len(events))
+	for _, v := range events {
+		internalEvents = append(internalEvents, *rc.sqlToInternal(&v))
+	}
+
+	return internalEvents, nil
+}
+
 func (rc *EventRepository) GetByID(ctx context.Context, eventID string) (*model.Event, error) {
 	if eventID == "" || eventID == "0" {
 		return nil, pkg.NewError(nil, "invalid event ID: "+eventID, http.StatusBadRequest)
@@ -136,34 +573,253 @@ func (rc *EventRepository) GetByID(ctx context.Context, eventID string) (*model.
 
 	event := new(event)
 
-	query := rc.db.Model(event).Where("id = ?", eventID)
+	query := rc.db.Model(event).Where("event.id = ?", eventID).Relation("Tags")
 
 	if err := query.Select(); err != nil {
 		return nil, pkg.NewError(err, "failed to find event by ID "+eventID, http.StatusInternalServerError)
 	}
 
-	return rc.sqlToInternal(event), nil
+	internalEvent := rc.sqlToInternal(event)
+
+	events := []model.Event{*internalEvent}
+	rc.redactLocked(events, util.GetOwnerIDFromCtx(ctx))
+
+	if err := rc.attachReactions(ctx, events); err != nil {
+		return nil, err
+	}
+
+	return &events[0], nil
+}
+
+// attachReactions fills the reaction counts of the events with a single grouped query.
+func (rc *EventRepository) attachReactions(ctx context.Context, events []model.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventIDs := make([]string, 0, len(events))
+	for _, v := range events {
+		eventIDs = append(eventIDs, v.ID)
+	}
+
+	counts := make([]reactionCount, 0)
+
+	err := rc.db.Model((*reaction)(nil)).
+		ColumnExpr("reaction.event_id").
+		ColumnExpr("reaction.emoji").
+		ColumnExpr("count(*) AS count").
+		Where("reaction.event_id IN (?)", pg.In(eventIDs)).
+		Group("reaction.event_id", "reaction.emoji").
+		OrderExpr("count DESC, reaction.emoji").
+		Select(&counts)
+	if err != nil {
+		return pkg.NewError(err, "failed to count reactions", http.StatusInternalServerError)
+	}
+
+	countsByEvent := make(map[string][]model.ReactionCount)
+	for _, v := range counts {
+		eventID := strconv.Itoa(v.EventID)
+		countsByEvent[eventID] = append(countsByEvent[eventID], model.ReactionCount{
+			Emoji: v.Emoji,
+			Count: v.Count,
+		})
+	}
+
+	for i := range events {
+		events[i].Reactions = countsByEvent[events[i].ID]
+		if events[i].Reactions == nil {
+			events[i].Reactions = make([]model.ReactionCount, 0)
+		}
+	}
+
+	return nil
 }
 
 func (rc *EventRepository) fillFilter(tx *orm.Query, opts *model.EventFindOpts) *orm.Query {
-	if opts.UserID.IsSended {
-		tx = applyFilterWithOperand(tx, "user_id", opts.UserID)
+	if opts.SharedWith.IsSended {
+		tx = rc.applySharedFilter(tx, opts)
+	} else {
+		tx = rc.applyOwnerFilter(tx, opts)
 	}
 
-	if opts.Visibility.IsSended {
-		tx = applyFilterWithOperand(tx, "visibility", opts.Visibility)
+	// drafts are listed only to their owner
+	if opts.DraftsOf != "" {
+		tx = tx.Where("NOT event.draft OR event.user_id = ?", opts.DraftsOf)
+	} else {
+		tx = tx.Where("NOT event.draft")
+	}
+
+	if opts.Draft.IsSended {
+		tx = applyFilterWithOperand(tx, "event.draft", opts.Draft)
+	}
+
+	if opts.Recipient.IsSended {
+		tx = rc.applyRecipientFilter(tx, opts.Recipient)
+	}
+
+	// sealed contents are not searched until the capsules open
+	if opts.HideLocked || opts.Name.IsSended || opts.Tags.IsSended || opts.Search.IsSended {
+		tx = tx.Where("NOT (" + lockedCapsuleSQL + ")")
 	}
 
 	if opts.Name.IsSended {
 		tx = applyFilterWithOperand(tx, "name", opts.Name)
 	}
 
+	if opts.Date.IsSended {
+		tx = applyFilterWithOperand(tx, "date", opts.Date)
+	}
+
+	if opts.CreatedAt.IsSended {
+		tx = applyFilterWithOperand(tx, "created_at", opts.CreatedAt)
+	}
+
+	if opts.TimeStart.IsSended {
+		tx = applyFilterWithOperand(tx, "time_start", opts.TimeStart)
+	}
+
+	if opts.TimeEnd.IsSended {
+		tx = applyFilterWithOperand(tx, "time_end", opts.TimeEnd)
+	}
+
+	if opts.Tags.IsSended {
+		tx = rc.applyTagsFilter(tx, opts.Tags)
+	}
+
+	if opts.Search.IsSended {
+		tx = rc.applySearch(tx, opts)
+	}
+
+	return tx
+}
+
+// applyOwnerFilter matches the events of the UserID filter with one of the allowed visibilities.
+func (rc *EventRepository) applyOwnerFilter(tx *orm.Query, opts *model.EventFindOpts) *orm.Query {
+	if opts.UserID.IsSended {
+		tx = applyFilterWithOperand(tx, "event.user_id", opts.UserID)
+	}
+
+	if opts.Visibility.IsSended {
+		tx = applyFilterWithOperand(tx, "event.visibility", opts.Visibility)
+	}
+
 	return tx
 }
 
+// applySharedFilter matches the events of the owner filter or the ones the SharedWith user approved being tagged in or co-owns.
+func (rc *EventRepository) applySharedFilter(tx *orm.Query, opts *model.EventFindOpts) *orm.Query {
+	return tx.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+		q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			return rc.applyOwnerFilter(q, opts), nil
+		})
+
+		q = q.WhereOrGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.Where("event.id IN (SELECT mention.event_id FROM mentions AS mention WHERE mention.user_id = ? AND mention.status = ? "+
+				"UNION SELECT co_owner.event_id FROM co_owners AS co_owner WHERE co_owner.user_id = ? AND co_owner.status = ?)",
+				opts.SharedWith.Value, int(model.RequestStatusApproved), opts.SharedWith.Value, int(model.RequestStatusApproved))
+
+			if opts.SharedVisibility.IsSended {
+				q = applyFilterWithOperand(q, "event.visibility", opts.SharedVisibility)
+			}
+
+			return q, nil
+		})
+
+		return q, nil
+	})
+}
+
+// applyRecipientFilter leaves out the time capsules addressed to others than the filter value, an empty value leaves out all of them
+func (rc *EventRepository) applyRecipientFilter(tx *orm.Query, filter model.Filter) *orm.Query {
+	if filter.Value == "" {
+		return tx.Where("coalesce(cardinality(event.recipient_ids), 0) = 0")
+	}
+
+	return tx.Where("event.user_id = ? OR coalesce(cardinality(event.recipient_ids), 0) = 0 OR ? = ANY(event.recipient_ids)", filter.Value, filter.Value)
+}
+
+// applyTagsFilter keeps the events having any of the comma separated tag names, nin excludes them instead.
+func (rc *EventRepository) applyTagsFilter(tx *orm.Query, filter model.Filter) *orm.Query {
+	names := strings.Split(filter.Value, ",")
+
+	condition := "event.id IN (?)"
+	if filter.Operand == model.OperandNotIn || filter.Operand == model.OperandNot {
+		condition = "event.id NOT IN (?)"
+	}
+
+	subQuery := rc.db.Model((*eventTag)(nil)).
+		Column("event_tag.event_id").
+		Join("JOIN tags AS tag ON tag.id = event_tag.tag_id").
+		WhereIn("tag.name IN (?)", names)
+
+	return tx.Where(condition, subQuery)
+}
+
+// applySearch filters events by full-text search and, if no order is requested, ranks the best matches first.
+func (rc *EventRepository) applySearch(tx *orm.Query, opts *model.EventFindOpts) *orm.Query {
+	tx = tx.Where(eventSearchVector+" @@ websearch_to_tsquery('simple', ?)", opts.Search.Value)
+
+	if opts.OrderByOpts.IsSended {
+		return tx
+	}
+
+	return tx.OrderExpr("ts_rank("+eventSearchVector+", websearch_to_tsquery('simple', ?)) DESC", opts.Search.Value)
+}
+
+// redactLocked leaves out the contents of the time capsules not opened yet. Owners read their capsules
+// unless they sealed them for themselves too, an empty viewer reads none of them.
+func (rc *EventRepository) redactLocked(events []model.Event, viewerID string) {
+	now := time.Now()
+
+	for i := range events {
+		v := &events[i]
+		if !v.Locked(now) {
+			continue
+		}
+
+		if viewerID != "" && v.UserID == viewerID && !v.SealedForOwner {
+			continue
+		}
+
+		v.Name = ""
+		v.Description = ""
+		v.Items = []model.EventItem{}
+		v.Tags = []model.Tag{}
+		v.Redacted = true
+	}
+}
+
+// snapshot returns the event as written to the outbox, locked capsules are redacted as their events leave the app
+func (rc *EventRepository) snapshot(sqlEvent *event) *model.Event {
+	events := []model.Event{*rc.sqlToInternal(sqlEvent)}
+	rc.redactLocked(events, "")
+
+	return &events[0]
+}
+
+// insertTags links the tags of the event, tags have to exist already
+func (rc *EventRepository) insertTags(tx *pg.Tx, sqlEvent *event) error {
+	if len(sqlEvent.Tags) == 0 {
+		return nil
+	}
+
+	eventTags := make([]eventTag, 0, len(sqlEvent.Tags))
+	for _, v := range sqlEvent.Tags {
+		eventTags = append(eventTags, eventTag{
+			EventID: sqlEvent.ID,
+			TagID:   v.ID,
+		})
+	}
+
+	_, err := tx.Model(&eventTags).OnConflict("DO NOTHING").Insert()
+
+	return err
+}
+
 func (rc *EventRepository) internalToSQL(newEvent *model.Event) *event {
 	eID, _ := strconv.Atoi(newEvent.ID)
 	ownerID, _ := strconv.Atoi(newEvent.UserID)
+	updatedByID, _ := strconv.Atoi(newEvent.UpdatedByID)
 
 	items := []eventItem{}
 	for _, v := range newEvent.Items {
@@ -173,18 +829,42 @@ func (rc *EventRepository) internalToSQL(newEvent *model.Event) *event {
 		})
 	}
 
+	var recipientIDs []int
+	for _, v := range newEvent.RecipientIDs {
+		rID, _ := strconv.Atoi(v)
+		recipientIDs = append(recipientIDs, rID)
+	}
+
+	var tags []tag
+	if newEvent.Tags != nil {
+		tags = make([]tag, 0, len(newEvent.Tags))
+		for _, v := range newEvent.Tags {
+			tID, _ := strconv.Atoi(v.ID)
+			tags = append(tags, tag{ID: tID, Name: v.Name, Color: v.Color, UserID: ownerID})
+		}
+	}
+
 	return &event{
-		Date:        newEvent.Date,
-		TimeStart:   newEvent.TimeStart,
-		TimeEnd:     newEvent.TimeEnd,
-		Name:        newEvent.Name,
-		Description: newEvent.Description,
-		Items:       items,
-		ID:          eID,
-		UserID:      ownerID,
-		Visibility:  int(newEvent.Visibility),
-		CreatedAt:   newEvent.CreatedAt,
-		UpdatedAt:   newEvent.UpdatedAt,
+		Date:           newEvent.Date,
+		TimeStart:      newEvent.TimeStart,
+		TimeEnd:        newEvent.TimeEnd,
+		Name:           newEvent.Name,
+		Description:    newEvent.Description,
+		Items:          items,
+		Tags:           tags,
+		ID:             eID,
+		UserID:         ownerID,
+		UpdatedByID:    updatedByID,
+		Visibility:     int(newEvent.Visibility),
+		Draft:          newEvent.Draft,
+		PublishAt:      newEvent.PublishAt,
+		Sealed:         newEvent.Sealed,
+		UnlockAt:       newEvent.UnlockAt,
+		OpenedAt:       newEvent.OpenedAt,
+		CreatedAt:      newEvent.CreatedAt,
+		UpdatedAt:      newEvent.UpdatedAt,
+		SealedForOwner: newEvent.SealedForOwner,
+		RecipientIDs:   recipientIDs,
 	}
 }
 
@@ -200,18 +880,53 @@ func (rc *EventRepository) sqlToInternal(newEvent *event) *model.Event {
 		})
 	}
 
+	tags := []model.Tag{}
+	for _, v := range newEvent.Tags {
+		tags = append(tags, *tagToInternal(&v))
+	}
+
+	recipientIDs := make([]string, 0, len(newEvent.RecipientIDs))
+	for _, v := range newEvent.RecipientIDs {
+		recipientIDs = append(recipientIDs, strconv.Itoa(v))
+	}
+
+	// the owner is loaded only where the events of several users are listed together
+	var owner *model.User
+	if newEvent.User != nil {
+		owner = &model.User{
+			ID:       strconv.Itoa(newEvent.User.ID),
+			Username: newEvent.User.Username,
+		}
+	}
+
+	// events are not edited by anyone yet, or were last edited before editors were recorded
+	var updatedByID string
+	if newEvent.UpdatedByID != 0 {
+		updatedByID = strconv.Itoa(newEvent.UpdatedByID)
+	}
+
 	return &model.Event{
-		Date:        newEvent.Date,
-		TimeStart:   newEvent.TimeStart,
-		TimeEnd:     newEvent.TimeEnd,
-		Name:        newEvent.Name,
-		Description: newEvent.Description,
-		Items:       items,
-		ID:          eID,
-		UserID:      ownerID,
-		Visibility:  model.Visibility(newEvent.Visibility),
-		CreatedAt:   newEvent.CreatedAt,
-		UpdatedAt:   newEvent.UpdatedAt,
+		Date:           newEvent.Date,
+		TimeStart:      newEvent.TimeStart,
+		TimeEnd:        newEvent.TimeEnd,
+		Name:           newEvent.Name,
+		Description:    newEvent.Description,
+		Items:          items,
+		Tags:           tags,
+		User:           owner,
+		ID:             eID,
+		UserID:         ownerID,
+		UpdatedByID:    updatedByID,
+		Visibility:     model.Visibility(newEvent.Visibility),
+		Draft:          newEvent.Draft,
+		PublishAt:      newEvent.PublishAt,
+		Sealed:         newEvent.Sealed,
+		UnlockAt:       newEvent.UnlockAt,
+		OpenedAt:       newEvent.OpenedAt,
+		CreatedAt:      newEvent.CreatedAt,
+		UpdatedAt:      newEvent.UpdatedAt,
+		SealedForOwner: newEvent.SealedForOwner,
+		RecipientIDs:   recipientIDs,
 	}
 }
 
@@ -227,5 +942,31 @@ func (rc *EventRepository) createSchema(db *pg.DB) error {
 		return pkg.NewError(err, "failed to create event table", http.StatusInternalServerError)
 	}
 
+	if err := addMissingColumns(db, model); err != nil {
+		return pkg.NewError(err, "failed to add event columns", http.StatusInternalServerError)
+	}
+
+	indexQuery := "CREATE INDEX IF NOT EXISTS event_search_idx ON events USING GIN (" +
+		"to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(description, '')))"
+	if _, err := db.Exec(indexQuery); err != nil {
+		return pkg.NewError(err, "failed to create event search index", http.StatusInternalServerError)
+	}
+
+	feedIndexQuery := "CREATE INDEX IF NOT EXISTS event_user_created_idx ON events (user_id, created_at DESC, id DESC)"
+	if _, err := db.Exec(feedIndexQuery); err != nil {
+		return pkg.NewError(err, "failed to create event feed index", http.StatusInternalServerError)
+	}
+
+	// capsules are opened once, the scheduler looks for the ones not opened yet
+	unlockIndexQuery := "CREATE INDEX IF NOT EXISTS event_unlock_idx ON events (unlock_at) WHERE sealed AND opened_at IS NULL"
+	if _, err := db.Exec(unlockIndexQuery); err != nil {
+		return pkg.NewError(err, "failed to create event unlock index", http.StatusInternalServerError)
+	}
+
+	publishIndexQuery := "CREATE INDEX IF NOT EXISTS event_publish_idx ON events (publish_at) WHERE draft"
+	if _, err := db.Exec(publishIndexQuery); err != nil {
+		return pkg.NewError(err, "failed to create event publish index", http.StatusInternalServerError)
+	}
+
 	return nil
 }
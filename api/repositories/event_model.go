@@ -13,9 +13,19 @@ type event struct {
 	Description string      `json:"description"`
 	Date        time.Time   `json:"date"`
 	Items       []eventItem `json:"items"`
+	Tags        []tag       `json:"tags" pg:"many2many:event_tags"`
 	ID          int         `json:"id" pg:",pk"`
 	Visibility  int         `json:"visibility"`
 	UserID      int         `json:"user_id" pg:",notnull"`
+	UpdatedByID int         `json:"updated_by_id"`
+	Draft       bool        `json:"draft" pg:",use_zero,default:false"`
+	PublishAt   time.Time   `json:"publish_at"`
+	// time capsule fields, recipients are user ids
+	Sealed         bool      `json:"sealed" pg:",use_zero,default:false"`
+	SealedForOwner bool      `json:"sealed_for_owner" pg:",use_zero,default:false"`
+	UnlockAt       time.Time `json:"unlock_at"`
+	OpenedAt       time.Time `json:"opened_at"`
+	RecipientIDs   []int     `json:"recipient_ids" pg:"recipient_ids,array"`
 }
 
 type eventItem struct {
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type FollowRepository struct {
+	db *pg.DB
+}
+
+func NewFollowRepository(db *pg.DB) *FollowRepository {
+	rc := &FollowRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Create follows the user, following an already followed user is a conflict.
+// The events about it are written to the outbox in the same transaction.
+func (rc *FollowRepository) Create(ctx context.Context, newFollow *model.Follow, events ...*model.DomainEvent) (*model.Follow, error) {
+	sqlFollow := rc.internalToSQL(newFollow)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Model(sqlFollow).Insert(); err != nil {
+			return err
+		}
+
+		return insertOutboxEvents(tx, strconv.Itoa(sqlFollow.ID), events)
+	})
+	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.IntegrityViolation() {
+			return nil, pkg.NewError(err, "user is already followed", http.StatusConflict)
+		}
+
+		return nil, pkg.NewError(err, "failed to follow user", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlFollow), nil
+}
+
+func (rc *FollowRepository) Delete(ctx context.Context, userID, followedID string) error {
+	result, err := rc.db.Model((*follow)(nil)).Where("user_id = ? AND followed_id = ?", userID, followedID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to unfollow user", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "user is not followed: "+followedID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *FollowRepository) List(ctx context.Context, opts *model.FollowFindOpts) (*model.FollowList, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	follows := make([]follow, 0)
+
+	query := rc.db.Model(&follows).
+		Relation("User.id").
+		Relation("User.username").
+		Relation("Followed.id").
+		Relation("Followed.username").
+		OrderExpr("follow.created_at DESC, follow.id DESC")
+
+	query = applyStandardQueries(query, opts.PaginationOpts)
+
+	if opts.UserID.IsSended {
+		query = applyFilterWithOperand(query, "follow.user_id", opts.UserID)
+	}
+
+	if opts.FollowedID.IsSended {
+		query = applyFilterWithOperand(query, "follow.followed_id", opts.FollowedID)
+	}
+
+	if opts.VisibleTo.IsSended {
+		query = query.
+			Where("follow.user_id NOT IN ("+blockedUsersSQL+")", opts.VisibleTo.Value, opts.VisibleTo.Value).
+			Where("follow.followed_id NOT IN ("+blockedUsersSQL+")", opts.VisibleTo.Value, opts.VisibleTo.Value)
+	}
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list follows", http.StatusInternalServerError)
+	}
+
+	internalFollows := make([]model.Follow, 0, len(follows))
+	for _, v := range follows {
+		internalFollows = append(internalFollows, *rc.sqlToInternal(&v))
+	}
+
+	return &model.FollowList{
+		Follows: internalFollows,
+		Total:   count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+func (rc *FollowRepository) internalToSQL(newFollow *model.Follow) *follow {
+	fID, _ := strconv.Atoi(newFollow.ID)
+	userID, _ := strconv.Atoi(newFollow.UserID)
+	followedID, _ := strconv.Atoi(newFollow.FollowedID)
+
+	return &follow{
+		CreatedAt:  newFollow.CreatedAt,
+		ID:         fID,
+		UserID:     userID,
+		FollowedID: followedID,
+	}
+}
+
+func (rc *FollowRepository) sqlToInternal(newFollow *follow) *model.Follow {
+	var follower *model.User
+	if newFollow.User != nil {
+		follower = &model.User{
+			ID:       strconv.Itoa(newFollow.User.ID),
+			Username: newFollow.User.Username,
+		}
+	}
+
+	var followed *model.User
+	if newFollow.Followed != nil {
+		followed = &model.User{
+			ID:       strconv.Itoa(newFollow.Followed.ID),
+			Username: newFollow.Followed.Username,
+		}
+	}
+
+	return &model.Follow{
+		CreatedAt:  newFollow.CreatedAt,
+		User:       follower,
+		Followed:   followed,
+		ID:         strconv.Itoa(newFollow.ID),
+		UserID:     strconv.Itoa(newFollow.UserID),
+		FollowedID: strconv.Itoa(newFollow.FollowedID),
+	}
+}
+
+func (rc *FollowRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*follow)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create follow table", http.StatusInternalServerError)
+	}
+
+	// follower listings, the unique constraint covers the following side
+	indexQuery := "CREATE INDEX IF NOT EXISTS follow_followed_idx ON follows (followed_id, created_at DESC)"
+	if _, err := db.Exec(indexQuery); err != nil {
+		return pkg.NewError(err, "failed to create follow index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
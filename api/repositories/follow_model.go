@@ -0,0 +1,12 @@
+package repositories
+
+import "time"
+
+type follow struct {
+	CreatedAt  time.Time `json:"created_at"`
+	User       *user     `json:"user" pg:"rel:has-one"`
+	Followed   *user     `json:"followed" pg:"rel:has-one"`
+	ID         int       `json:"id" pg:",pk"`
+	UserID     int       `json:"user_id" pg:",notnull,unique:follow_user_followed,on_delete:CASCADE"`
+	FollowedID int       `json:"followed_id" pg:",notnull,unique:follow_user_followed,on_delete:CASCADE"`
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type BlockRepository interface {
+	Block(ctx context.Context, block *model.Block) (*model.Block, error)
+	Unblock(ctx context.Context, userID, blockedID string) error
+	IsBlocked(ctx context.Context, userID, otherID string) (bool, error)
+	ListBlocked(ctx context.Context, userID string, opts model.PaginationOpts) (*model.BlockList, error)
+	Mute(ctx context.Context, mute *model.Mute) (*model.Mute, error)
+	Unmute(ctx context.Context, userID, mutedID string) error
+	ListMuted(ctx context.Context, userID string, opts model.PaginationOpts) (*model.MuteList, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type CoOwnerRepository interface {
+	Create(ctx context.Context, coOwner *model.CoOwner, events ...*model.DomainEvent) (*model.CoOwner, error)
+	UpdateStatus(ctx context.Context, coOwnerID string, status model.RequestStatus, events ...*model.DomainEvent) error
+	Delete(ctx context.Context, coOwnerID string) error
+	List(ctx context.Context, opts *model.CoOwnerFindOpts) (*model.CoOwnerList, error)
+	GetByID(ctx context.Context, coOwnerID string) (*model.CoOwner, error)
+	GetByTarget(ctx context.Context, eventID, eraID, userID string) (*model.CoOwner, error)
+}
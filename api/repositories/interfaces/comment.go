@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *model.Comment, events ...*model.DomainEvent) (*model.Comment, error)
+	Update(ctx context.Context, commentID string, comment *model.Comment) (*model.Comment, error)
+	Delete(ctx context.Context, commentID string) error
+	List(ctx context.Context, opts *model.CommentFindOpts) (*model.CommentList, error)
+	GetByID(ctx context.Context, commentID string) (*model.Comment, error)
+}
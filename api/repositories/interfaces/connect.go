@@ -2,14 +2,20 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 )
 
 type ConnectInterfaces interface {
-	Create(ctx context.Context, connect *model.Connect) (*model.Connect, error)
-	Update(ctx context.Context, connectID string, connect *model.Connect) (*model.Connect, error)
+	Create(ctx context.Context, connect *model.Connect, events ...*model.DomainEvent) (*model.Connect, error)
+	Update(ctx context.Context, connectID string, connect *model.Connect, events ...*model.DomainEvent) (*model.Connect, error)
 	ConnectsRequests(ctx context.Context, opts *model.ConnectFindOpts) (*model.ConnectList, error)
 	GetByID(ctx context.Context, connectID string) (*model.Connect, error)
-	Delete(ctx context.Context, connectID string) error
+	Delete(ctx context.Context, connectID string, events ...*model.DomainEvent) error
+	Exists(ctx context.Context, userID, otherID string, statuses ...model.RequestStatus) (bool, error)
+	ConnectionIDs(ctx context.Context, userID string) ([]string, error)
+	ExpirePending(ctx context.Context, createdBefore time.Time) ([]model.Connect, error)
+	CountByPeriod(ctx context.Context, userID string, period model.TimelinePeriod) ([]model.PeriodCount, error)
+	Suggestions(ctx context.Context, userID string, opts model.PaginationOpts) (*model.SuggestionList, error)
 }
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type ContactRepository interface {
+	Import(ctx context.Context, contacts []model.Contact) (int, error)
+	Delete(ctx context.Context, userID, contactID string) error
+	List(ctx context.Context, opts *model.ContactFindOpts) (*model.ContactList, error)
+}
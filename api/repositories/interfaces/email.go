@@ -1,5 +1,20 @@
 package interfaces
 
-type EmailInterfaces interface {
-	SendPasswordResetEmail(to, username, resetToken string) error
+import (
+	"context"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+// EmailTransport hands rendered emails over to be delivered.
+type EmailTransport interface {
+	Send(ctx context.Context, message *model.EmailMessage) error
+}
+
+type EmailQueueRepository interface {
+	Enqueue(ctx context.Context, message *model.EmailMessage) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.QueuedEmail, error)
+	Delete(ctx context.Context, emailID string) error
+	Reschedule(ctx context.Context, email *model.QueuedEmail) error
 }
@@ -12,4 +12,6 @@ type EraRepository interface {
 	Delete(ctx context.Context, eraID string) error
 	List(ctx context.Context, opts *model.EraFindOpts) (*model.EraList, error)
 	GetByID(ctx context.Context, eraID string) (*model.Era, error)
+	Longest(ctx context.Context, opts *model.EraFindOpts) (*model.Era, error)
+	CountByPeriod(ctx context.Context, opts *model.EraFindOpts, period model.TimelinePeriod) ([]model.PeriodCount, error)
 }
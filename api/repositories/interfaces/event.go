@@ -2,14 +2,24 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 )
 
 type EventRepository interface {
 	Create(ctx context.Context, event *model.Event) (*model.Event, error)
-	Update(ctx context.Context, eventID string, event *model.Event) (*model.Event, error)
+	Update(ctx context.Context, eventID string, event, read *model.Event) (*model.Event, error)
+	Publish(ctx context.Context, eventID, userID string) (*model.Event, error)
+	PublishDue(ctx context.Context, now time.Time) ([]model.Event, error)
+	OpenDue(ctx context.Context, now time.Time) ([]model.Event, error)
 	Delete(ctx context.Context, eventID string) error
 	List(ctx context.Context, opts *model.EventFindOpts) (*model.EventList, error)
 	GetByID(ctx context.Context, eventID string) (*model.Event, error)
+	Feed(ctx context.Context, opts *model.FeedFindOpts) ([]model.Event, error)
+	OnThisDay(ctx context.Context, userID string, day time.Time) ([]model.Event, error)
+	CountByPeriod(ctx context.Context, opts *model.EventFindOpts, period model.TimelinePeriod) ([]model.PeriodCount, error)
+	CountByItemType(ctx context.Context, opts *model.EventFindOpts) ([]model.EventTypeCount, error)
+	CountByVisibility(ctx context.Context, opts *model.EventFindOpts) ([]model.VisibilityCount, error)
+	Gaps(ctx context.Context, opts *model.EventFindOpts, minDays, limit int) ([]model.Gap, error)
 }
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type FollowRepository interface {
+	Create(ctx context.Context, follow *model.Follow, events ...*model.DomainEvent) (*model.Follow, error)
+	Delete(ctx context.Context, userID, followedID string) error
+	List(ctx context.Context, opts *model.FollowFindOpts) (*model.FollowList, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type MentionRepository interface {
+	Create(ctx context.Context, mention *model.Mention, events ...*model.DomainEvent) (*model.Mention, error)
+	UpdateStatus(ctx context.Context, mentionID string, status model.RequestStatus, events ...*model.DomainEvent) error
+	Delete(ctx context.Context, mentionID string) error
+	List(ctx context.Context, opts *model.MentionFindOpts) (*model.MentionList, error)
+	GetByID(ctx context.Context, mentionID string) (*model.Mention, error)
+	GetByEventAndUser(ctx context.Context, eventID, userID string) (*model.Mention, error)
+}
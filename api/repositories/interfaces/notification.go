@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 )
@@ -12,4 +13,16 @@ type NotificationRepository interface {
 	List(ctx context.Context, opts *model.NotificationFindOpts) (*model.NotificationList, error)
 	GetByID(ctx context.Context, notificationID string) (*model.Notification, error)
 	Delete(ctx context.Context, notificationID string) error
+	MarkAllRead(ctx context.Context, userID string) (int, error)
+	DeleteMany(ctx context.Context, userID string, notificationIDs []string) (int, error)
+	CountUnread(ctx context.Context, userID string) (int, error)
+	DeleteReadBefore(ctx context.Context, createdBefore time.Time) (int, error)
+	EmailPendingUserIDs(ctx context.Context) ([]string, error)
+	MarkEmailed(ctx context.Context, notificationIDs []string) error
+}
+
+type NotificationPreferenceRepository interface {
+	Get(ctx context.Context, userID string) (*model.NotificationPreferences, error)
+	Upsert(ctx context.Context, userID string, prefs *model.NotificationPreferences) error
+	UpdateDigestSentAt(ctx context.Context, userID string, sentAt time.Time) error
 }
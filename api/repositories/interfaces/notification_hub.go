@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+// NotificationHub fans created notifications out to the open streams of their users
+type NotificationHub interface {
+	Publish(ctx context.Context, notification *model.Notification) error
+	// Subscribe returns the notifications of the user published from now on and a func to stop receiving them.
+	// The channel is closed when the subscriber falls behind, the client has to reconnect and replay.
+	Subscribe(userID string) (<-chan model.Notification, func())
+}
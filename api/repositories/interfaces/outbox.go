@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type OutboxRepository interface {
+	Add(ctx context.Context, events ...*model.DomainEvent) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.DomainEvent, error)
+	Delete(ctx context.Context, eventID string) error
+	Reschedule(ctx context.Context, event *model.DomainEvent) error
+	MoveToDeadLetters(ctx context.Context, event *model.DomainEvent) error
+	ListDeadLetters(ctx context.Context, opts model.PaginationOpts) (*model.DeadLetterList, error)
+	RetryDeadLetter(ctx context.Context, eventID string) error
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type PushSubscriptionRepository interface {
+	Upsert(ctx context.Context, subscription *model.PushSubscription) (*model.PushSubscription, error)
+	Delete(ctx context.Context, userID, subscriptionID string) error
+	Get(ctx context.Context, userID, subscriptionID string) (*model.PushSubscription, error)
+	ListByUser(ctx context.Context, userID string) ([]model.PushSubscription, error)
+	DeleteExpired(ctx context.Context, expiredBefore time.Time) (int, error)
+}
+
+// PushSender delivers web push messages to push services of browsers.
+// Send returns an error with http.StatusGone status if the push service dropped the subscription.
+type PushSender interface {
+	Send(ctx context.Context, subscription *model.PushSubscription, payload []byte) error
+	PublicKey() string
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type ReactionRepository interface {
+	Set(ctx context.Context, reaction *model.Reaction, ownReaction bool) (*model.Reaction, error)
+	Delete(ctx context.Context, eventID, userID string) error
+	List(ctx context.Context, opts *model.ReactionFindOpts) (*model.ReactionList, error)
+	PendingBatches(ctx context.Context) ([]model.ReactionBatch, error)
+	MarkNotified(ctx context.Context, batch *model.ReactionBatch) error
+}
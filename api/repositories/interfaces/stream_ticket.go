@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+// StreamTicketRepository keeps the single use tickets the notification stream is opened with.
+// Redeem returns an error with http.StatusUnauthorized status if the ticket is unknown, used or expired.
+type StreamTicketRepository interface {
+	Create(ctx context.Context, ticket string, owner model.TokenOwner, expiresAt time.Time) error
+	Redeem(ctx context.Context, ticket string, now time.Time) (model.TokenOwner, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *model.Tag) (*model.Tag, error)
+	Update(ctx context.Context, tagID string, tag *model.Tag) (*model.Tag, error)
+	Delete(ctx context.Context, tagID string) error
+	List(ctx context.Context, opts *model.TagFindOpts) (*model.TagList, error)
+	GetByID(ctx context.Context, tagID string) (*model.Tag, error)
+	FindOrCreate(ctx context.Context, userID string, names []string, color string) ([]model.Tag, error)
+	Counts(ctx context.Context, opts *model.TagFindOpts) ([]model.TagCount, error)
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 )
@@ -16,4 +17,6 @@ type UserInterfaces interface {
 	Exists(ctx context.Context, usernameOrEmail string) (bool, error)
 	Delete(ctx context.Context, userID string) error
 	UpdatePassword(ctx context.Context, userID string, hashedPassword string) error
+	UpdatePreferences(ctx context.Context, userID string, prefs *model.UserPreferences) error
+	UpdateDigestSentAt(ctx context.Context, userID string, sentAt time.Time) error
 }
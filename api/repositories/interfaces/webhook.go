@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	Update(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	Delete(ctx context.Context, userID, webhookID string) error
+	GetByID(ctx context.Context, userID, webhookID string) (*model.Webhook, error)
+	ListByUser(ctx context.Context, userID string) ([]model.Webhook, error)
+	ListSubscribed(ctx context.Context, userIDs []string, eventType model.DomainEventType) ([]model.Webhook, error)
+	CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID string, opts model.PaginationOpts) (*model.WebhookDeliveryList, error)
+	GetDelivery(ctx context.Context, webhookID, deliveryID string) (*model.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID string) (*model.WebhookDelivery, error)
+}
+
+// WebhookSender posts webhook deliveries, it returns an error only if the endpoint did not answer.
+type WebhookSender interface {
+	Post(ctx context.Context, endpoint string, header http.Header, body []byte) (*model.WebhookResponse, error)
+}
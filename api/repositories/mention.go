@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type MentionRepository struct {
+	db *pg.DB
+}
+
+func NewMentionRepository(db *pg.DB) *MentionRepository {
+	rc := &MentionRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Create stores the mention and writes the events about it to the outbox in the same transaction.
+func (rc *MentionRepository) Create(ctx context.Context, newMention *model.Mention, events ...*model.DomainEvent) (*model.Mention, error) {
+	sqlMention := rc.internalToSQL(newMention)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Model(sqlMention).Insert(); err != nil {
+			return err
+		}
+
+		return insertOutboxEvents(tx, strconv.Itoa(sqlMention.ID), events)
+	})
+	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.IntegrityViolation() {
+			return nil, pkg.NewError(err, "user is already tagged in the event", http.StatusConflict)
+		}
+
+		return nil, pkg.NewError(err, "failed to create mention", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlMention), nil
+}
+
+// UpdateStatus changes the status of the mention and writes the events about it to the outbox in the same transaction.
+func (rc *MentionRepository) UpdateStatus(ctx context.Context, mentionID string, status model.RequestStatus, events ...*model.DomainEvent) error {
+	var rowsAffected int
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		result, err := tx.Model((*mention)(nil)).
+			Set("status = ?", int(status)).
+			Set("updated_at = now()").
+			Where("id = ?", mentionID).
+			Update()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected = result.RowsAffected()
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		return insertOutboxEvents(tx, mentionID, events)
+	})
+	if err != nil {
+		return pkg.NewError(err, "failed to update mention "+mentionID, http.StatusInternalServerError)
+	}
+
+	if rowsAffected == 0 {
+		return pkg.NewError(nil, "no mention updated: "+mentionID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *MentionRepository) Delete(ctx context.Context, mentionID string) error {
+	result, err := rc.db.Model(&mention{}).Where("id = ?", mentionID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete mention", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no mention deleted: "+mentionID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *MentionRepository) List(ctx context.Context, opts *model.MentionFindOpts) (*model.MentionList, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	mentions := make([]mention, 0)
+
+	query := rc.db.Model(&mentions).
+		Relation("User.id").
+		Relation("User.username").
+		Relation("Event.id").
+		Relation("Event.name").
+		Relation("Event.date").
+		Relation("Event.user_id").
+		Relation("Event.sealed").
+		Relation("Event.draft").
+		Relation("Event.unlock_at").
+		Where("event.deleted_at IS NULL")
+
+	if opts.OrderByOpts.IsSended {
+		query = applyOrderBy(query, opts.OrderByOpts)
+	} else {
+		query = query.OrderExpr("mention.created_at DESC, mention.id DESC")
+	}
+
+	query = applyStandardQueries(query, opts.PaginationOpts)
+
+	query = rc.fillFilter(query, opts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list mentions", http.StatusInternalServerError)
+	}
+
+	internalMentions := make([]model.Mention, 0, len(mentions))
+	for _, v := range mentions {
+		internalMentions = append(internalMentions, *rc.sqlToInternal(&v))
+	}
+
+	return &model.MentionList{
+		Mentions: internalMentions,
+		Total:    count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+func (rc *MentionRepository) GetByID(ctx context.Context, mentionID string) (*model.Mention, error) {
+	if mentionID == "" || mentionID == "0" {
+		return nil, pkg.NewError(nil, "invalid mention ID: "+mentionID, http.StatusBadRequest)
+	}
+
+	resp := new(mention)
+
+	if err := rc.db.Model(resp).Where("mention.id = ?", mentionID).Select(); err != nil {
+		return nil, pkg.NewError(err, "failed to find mention by ID "+mentionID, http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(resp), nil
+}
+
+// GetByEventAndUser returns the mention of the user in the event.
+func (rc *MentionRepository) GetByEventAndUser(ctx context.Context, eventID, userID string) (*model.Mention, error) {
+	resp := new(mention)
+
+	err := rc.db.Model(resp).
+		Where("mention.event_id = ? AND mention.user_id = ?", eventID, userID).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, pkg.NewError(err, "user is not tagged in the event", http.StatusNotFound)
+		}
+
+		return nil, pkg.NewError(err, "failed to find mention", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(resp), nil
+}
+
+func (rc *MentionRepository) fillFilter(tx *orm.Query, opts *model.MentionFindOpts) *orm.Query {
+	if opts.EventID.IsSended {
+		tx = applyFilterWithOperand(tx, "mention.event_id", opts.EventID)
+	}
+
+	if opts.UserID.IsSended {
+		tx = applyFilterWithOperand(tx, "mention.user_id", opts.UserID)
+	}
+
+	if opts.Status.IsSended {
+		tx = applyFilterWithOperand(tx, "mention.status", opts.Status)
+	}
+
+	return tx
+}
+
+func (rc *MentionRepository) internalToSQL(newMention *model.Mention) *mention {
+	mID, _ := strconv.Atoi(newMention.ID)
+	eventID, _ := strconv.Atoi(newMention.EventID)
+	userID, _ := strconv.Atoi(newMention.UserID)
+
+	return &mention{
+		CreatedAt: newMention.CreatedAt,
+		UpdatedAt: newMention.UpdatedAt,
+		ID:        mID,
+		EventID:   eventID,
+		UserID:    userID,
+		Status:    int(newMention.Status),
+	}
+}
+
+func (rc *MentionRepository) sqlToInternal(newMention *mention) *model.Mention {
+	var tagged *model.User
+	if newMention.User != nil {
+		tagged = &model.User{
+			ID:       strconv.Itoa(newMention.User.ID),
+			Username: newMention.User.Username,
+		}
+	}
+
+	var taggedIn *model.Event
+	if newMention.Event != nil {
+		taggedIn = &model.Event{
+			ID:       strconv.Itoa(newMention.Event.ID),
+			Name:     newMention.Event.Name,
+			Date:     newMention.Event.Date,
+			UserID:   strconv.Itoa(newMention.Event.UserID),
+			Sealed:   newMention.Event.Sealed,
+			Draft:    newMention.Event.Draft,
+			UnlockAt: newMention.Event.UnlockAt,
+		}
+
+		// the name of a time capsule is hidden until it opens
+		if taggedIn.Locked(time.Now()) {
+			taggedIn.Name = ""
+			taggedIn.Redacted = true
+		}
+	}
+
+	return &model.Mention{
+		CreatedAt: newMention.CreatedAt,
+		UpdatedAt: newMention.UpdatedAt,
+		User:      tagged,
+		Event:     taggedIn,
+		ID:        strconv.Itoa(newMention.ID),
+		EventID:   strconv.Itoa(newMention.EventID),
+		UserID:    strconv.Itoa(newMention.UserID),
+		Status:    model.RequestStatus(newMention.Status),
+	}
+}
+
+func (rc *MentionRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*mention)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create mention table", http.StatusInternalServerError)
+	}
+
+	indexQuery := "CREATE INDEX IF NOT EXISTS mention_user_status_idx ON mentions (user_id, status)"
+	if _, err := db.Exec(indexQuery); err != nil {
+		return pkg.NewError(err, "failed to create mention index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import "time"
+
+type mention struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      *user     `json:"user" pg:"rel:has-one"`
+	Event     *event    `json:"event" pg:"rel:has-one"`
+	ID        int       `json:"id" pg:",pk"`
+	EventID   int       `json:"event_id" pg:",notnull,unique:mention_event_user,on_delete:CASCADE"`
+	UserID    int       `json:"user_id" pg:",notnull,unique:mention_event_user,on_delete:CASCADE"`
+	Status    int       `json:"status" pg:",notnull"`
+}
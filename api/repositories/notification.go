@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -29,16 +30,21 @@ func NewNotificationRepository(db *pg.DB) *NotificationRepository {
 	return rc
 }
 
+// Create stores the notification. It returns nil if the user already has a notification of the type from the same source.
 func (rc *NotificationRepository) Create(ctx context.Context, newNotification *model.Notification) (*model.Notification, error) {
 	sqlNotification := rc.internalToSQL(newNotification)
 
-	q := rc.db.Model(sqlNotification)
+	q := rc.db.Model(sqlNotification).OnConflict("DO NOTHING")
 
-	_, err := q.Insert()
+	result, err := q.Insert()
 	if err != nil {
 		return nil, pkg.NewError(err, "failed to create notification", http.StatusInternalServerError)
 	}
 
+	if result.RowsAffected() == 0 {
+		return nil, nil
+	}
+
 	return rc.sqlToInternal(sqlNotification), nil
 }
 
@@ -133,6 +139,117 @@ func (rc *NotificationRepository) Delete(ctx context.Context, notificationID str
 	return nil
 }
 
+// MarkAllRead marks every unread notification of the user as read and returns how many were marked.
+func (rc *NotificationRepository) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	if userID == "" || userID == "0" {
+		return 0, pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	result, err := rc.db.Model((*notification)(nil)).
+		Set("read = ?", int(model.NotificationStatusRead)).
+		Where("user_id = ?", userID).
+		Where("read = ?", int(model.NotificationStatusUnread)).
+		Update()
+	if err != nil {
+		return 0, pkg.NewError(err, "failed to mark notifications read", http.StatusInternalServerError)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// DeleteMany deletes the given notifications of the user and returns how many were deleted.
+// Ids of notifications of other users are skipped.
+func (rc *NotificationRepository) DeleteMany(ctx context.Context, userID string, notificationIDs []string) (int, error) {
+	if userID == "" || userID == "0" {
+		return 0, pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	ids := make([]int, 0, len(notificationIDs))
+	for _, v := range notificationIDs {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, pkg.NewError(err, "invalid notification id "+v, http.StatusBadRequest)
+		}
+
+		ids = append(ids, id)
+	}
+
+	result, err := rc.db.Model((*notification)(nil)).
+		Where("user_id = ?", userID).
+		Where("id IN (?)", pg.In(ids)).
+		Delete()
+	if err != nil {
+		return 0, pkg.NewError(err, "failed to delete notifications", http.StatusInternalServerError)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// CountUnread counts the unread notifications of the user.
+func (rc *NotificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	if userID == "" || userID == "0" {
+		return 0, pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	count, err := rc.db.Model((*notification)(nil)).
+		Where("user_id = ?", userID).
+		Where("read = ?", int(model.NotificationStatusUnread)).
+		Count()
+	if err != nil {
+		return 0, pkg.NewError(err, "failed to count unread notifications", http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// DeleteReadBefore deletes the read notifications of all users created before the given time and returns how many were deleted.
+func (rc *NotificationRepository) DeleteReadBefore(ctx context.Context, createdBefore time.Time) (int, error) {
+	result, err := rc.db.Model((*notification)(nil)).
+		Where("read = ?", int(model.NotificationStatusRead)).
+		// created_at is stored as RFC3339 text, offsets of the rows may differ
+		Where("created_at::timestamptz < ?", createdBefore).
+		Delete()
+	if err != nil {
+		return 0, pkg.NewError(err, "failed to delete read notifications", http.StatusInternalServerError)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// EmailPendingUserIDs returns the users who have notifications waiting to be emailed.
+func (rc *NotificationRepository) EmailPendingUserIDs(ctx context.Context) ([]string, error) {
+	userIDs := make([]string, 0)
+
+	err := rc.db.Model((*notification)(nil)).
+		ColumnExpr("DISTINCT user_id").
+		Where("email_pending = true").
+		Select(&userIDs)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list users with pending notification emails", http.StatusInternalServerError)
+	}
+
+	return userIDs, nil
+}
+
+// MarkEmailed clears the pending email of the given notifications.
+func (rc *NotificationRepository) MarkEmailed(ctx context.Context, notificationIDs []string) error {
+	ids := make([]int, 0, len(notificationIDs))
+	for _, v := range notificationIDs {
+		id, _ := strconv.Atoi(v)
+		ids = append(ids, id)
+	}
+
+	_, err := rc.db.Model((*notification)(nil)).
+		Set("email_pending = false").
+		Where("id IN (?)", pg.In(ids)).
+		Update()
+	if err != nil {
+		return pkg.NewError(err, "failed to mark notifications emailed", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 func (rc *NotificationRepository) fillFields(tx *orm.Query, opts *model.NotificationFindOpts) *orm.Query {
 	fields := opts.Fields
 
@@ -146,6 +263,7 @@ func (rc *NotificationRepository) fillFields(tx *orm.Query, opts *model.Notifica
 			"notification.user_id",
 			"notification.type",
 			"notification.message",
+			"notification.payload",
 			"notification.read",
 			"notification.created_at",
 		)
@@ -160,6 +278,10 @@ func (rc *NotificationRepository) fillFields(tx *orm.Query, opts *model.Notifica
 }
 
 func (rc *NotificationRepository) fillNotificationFilter(tx *orm.Query, opts *model.NotificationFindOpts) *orm.Query {
+	if opts.ID.IsSended {
+		tx = applyFilterWithOperand(tx, "id", opts.ID)
+	}
+
 	if opts.UserID.IsSended {
 		tx = applyFilterWithOperand(tx, "user_id", opts.UserID)
 	}
@@ -168,6 +290,10 @@ func (rc *NotificationRepository) fillNotificationFilter(tx *orm.Query, opts *mo
 		tx = applyFilterWithOperand(tx, "read", opts.Read)
 	}
 
+	if opts.EmailPending.IsSended {
+		tx = applyFilterWithOperand(tx, "email_pending", opts.EmailPending)
+	}
+
 	return tx
 }
 
@@ -175,38 +301,78 @@ func (rc *NotificationRepository) internalToSQL(newNotification *model.Notificat
 	nID, _ := strconv.Atoi(newNotification.ID)
 	userID, _ := strconv.Atoi(newNotification.UserID)
 	return &notification{
-		Type:      newNotification.Type,
-		Message:   newNotification.Message,
-		Read:      int(newNotification.Read),
-		UserID:    userID,
-		ID:        nID,
-		CreatedAt: newNotification.CreatedAt.Format(time.RFC3339),
+		Type:    string(newNotification.Type),
+		Message: newNotification.Message,
+		Payload: notificationPayload{
+			ActorID:    newNotification.Payload.ActorID,
+			ActorName:  newNotification.Payload.ActorName,
+			TargetType: string(newNotification.Payload.TargetType),
+			TargetID:   newNotification.Payload.TargetID,
+			TargetName: newNotification.Payload.TargetName,
+			Count:      newNotification.Payload.Count,
+		},
+		Read:         int(newNotification.Read),
+		UserID:       userID,
+		ID:           nID,
+		CreatedAt:    newNotification.CreatedAt.Format(time.RFC3339),
+		EmailPending: newNotification.EmailPending,
+		SourceID:     newNotification.SourceID,
 	}
 }
 
 func (rc *NotificationRepository) sqlToInternal(notification *notification) *model.Notification {
 	createdAt, _ := time.Parse(time.RFC3339, notification.CreatedAt)
 	return &model.Notification{
-		ID:        strconv.Itoa(notification.ID),
-		UserID:    strconv.Itoa(notification.UserID),
-		Type:      notification.Type,
-		Message:   notification.Message,
-		Read:      model.NotificationStatus(notification.Read),
-		CreatedAt: createdAt,
+		ID:      strconv.Itoa(notification.ID),
+		UserID:  strconv.Itoa(notification.UserID),
+		Type:    model.NotificationType(notification.Type),
+		Message: notification.Message,
+		Payload: model.NotificationPayload{
+			ActorID:    notification.Payload.ActorID,
+			ActorName:  notification.Payload.ActorName,
+			TargetType: model.NotificationTarget(notification.Payload.TargetType),
+			TargetID:   notification.Payload.TargetID,
+			TargetName: notification.Payload.TargetName,
+			Count:      notification.Payload.Count,
+		},
+		Read:         model.NotificationStatus(notification.Read),
+		CreatedAt:    createdAt,
+		EmailPending: notification.EmailPending,
+		SourceID:     notification.SourceID,
 	}
 }
 
 func (rc *NotificationRepository) createSchema(db *pg.DB) error {
-	model := (*notification)(nil)
+	table := (*notification)(nil)
 
 	opts := &orm.CreateTableOptions{
 		IfNotExists:   true,
 		FKConstraints: true,
 	}
 
-	if err := db.Model(model).CreateTable(opts); err != nil {
+	if err := db.Model(table).CreateTable(opts); err != nil {
 		return pkg.NewError(err, "failed to create notification table", http.StatusInternalServerError)
 	}
 
+	if err := addMissingColumns(db, table); err != nil {
+		return pkg.NewError(err, "failed to add notification columns", http.StatusInternalServerError)
+	}
+
+	indexQueries := []string{
+		// unread counts and mark all read
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS notification_unread_idx ON notifications (user_id) WHERE read = %d", model.NotificationStatusUnread),
+		// the email sweep looks for pending notification emails only
+		"CREATE INDEX IF NOT EXISTS notification_email_pending_idx ON notifications (user_id) WHERE email_pending",
+		// listings and stream replays of a user
+		"CREATE INDEX IF NOT EXISTS notification_user_idx ON notifications (user_id, id)",
+		// outbox handlers retrying an event skip the notifications they already created
+		"CREATE UNIQUE INDEX IF NOT EXISTS notification_source_idx ON notifications (source_id, user_id, type) WHERE source_id IS NOT NULL",
+	}
+	for _, query := range indexQueries {
+		if _, err := db.Exec(query); err != nil {
+			return pkg.NewError(err, "failed to create notification index", http.StatusInternalServerError)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+// subscriberBuffer is how many notifications a stream may lag behind before it is dropped
+const subscriberBuffer = 16
+
+// MemoryNotificationHub delivers notifications to the streams open on this process only.
+// It is shared by every use case of the process, so it has to be created once.
+type MemoryNotificationHub struct {
+	subscribers map[string]map[chan model.Notification]struct{}
+	mu          sync.Mutex
+}
+
+func NewMemoryNotificationHub() *MemoryNotificationHub {
+	return &MemoryNotificationHub{
+		subscribers: make(map[string]map[chan model.Notification]struct{}),
+	}
+}
+
+func (rc *MemoryNotificationHub) Publish(ctx context.Context, notification *model.Notification) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for ch := range rc.subscribers[notification.UserID] {
+		select {
+		case ch <- *notification:
+		default:
+			// a slow stream is closed instead of blocking the publisher, it replays what it missed on reconnect
+			rc.remove(notification.UserID, ch)
+		}
+	}
+
+	return nil
+}
+
+func (rc *MemoryNotificationHub) Subscribe(userID string) (<-chan model.Notification, func()) {
+	ch := make(chan model.Notification, subscriberBuffer)
+
+	rc.mu.Lock()
+	if rc.subscribers[userID] == nil {
+		rc.subscribers[userID] = make(map[chan model.Notification]struct{})
+	}
+	rc.subscribers[userID][ch] = struct{}{}
+	rc.mu.Unlock()
+
+	unsubscribe := func() {
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+
+		rc.remove(userID, ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// remove closes the channel of the subscriber if it is still subscribed, mu has to be held
+func (rc *MemoryNotificationHub) remove(userID string, ch chan model.Notification) {
+	if _, ok := rc.subscribers[userID][ch]; !ok {
+		return
+	}
+
+	delete(rc.subscribers[userID], ch)
+	if len(rc.subscribers[userID]) == 0 {
+		delete(rc.subscribers, userID)
+	}
+
+	close(ch)
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// notificationChannel is the postgres channel the notifications are sent over
+const notificationChannel = "notifications"
+
+// PGNotificationHub sends notifications over postgres LISTEN/NOTIFY, so every instance of the api
+// gets them and delivers them to its own streams. It has to be created once per process.
+type PGNotificationHub struct {
+	db    *pg.DB
+	local *MemoryNotificationHub
+}
+
+// NewPGNotificationHub starts listening on the notification channel until ctx is done.
+func NewPGNotificationHub(ctx context.Context, db *pg.DB) *PGNotificationHub {
+	rc := &PGNotificationHub{
+		db:    db,
+		local: NewMemoryNotificationHub(),
+	}
+
+	go rc.listen(ctx)
+
+	return rc
+}
+
+func (rc *PGNotificationHub) Publish(ctx context.Context, notification *model.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return pkg.NewError(err, "failed to marshal notification", http.StatusInternalServerError)
+	}
+
+	if _, err := rc.db.ExecContext(ctx, "SELECT pg_notify(?, ?)", notificationChannel, string(payload)); err != nil {
+		return pkg.NewError(err, "failed to publish notification", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (rc *PGNotificationHub) Subscribe(userID string) (<-chan model.Notification, func()) {
+	return rc.local.Subscribe(userID)
+}
+
+func (rc *PGNotificationHub) listen(ctx context.Context) {
+	ln := rc.db.Listen(ctx, notificationChannel)
+	defer ln.Close()
+
+	// the channel of the listener reconnects on its own
+	ch := ln.Channel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			var notification model.Notification
+			if err := json.Unmarshal([]byte(msg.Payload), &notification); err != nil {
+				logger.Log.Errorf("failed to unmarshal notification: %v", err)
+				continue
+			}
+
+			rc.local.Publish(ctx, &notification)
+		}
+	}
+}
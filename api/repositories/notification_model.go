@@ -1,10 +1,37 @@
 package repositories
 
+import "time"
+
 type notification struct {
-	ID        int    `json:"id" pg:",pk"`
-	UserID    int    `json:"user_id" pg:",notnull"`
-	Type      string `json:"type" pg:",notnull"`
-	Message   string `json:"message" pg:",notnull"`
-	Read      int    `json:"read" pg:",notnull"`
-	CreatedAt string `json:"created_at" pg:",notnull"`
+	ID        int                 `json:"id" pg:",pk"`
+	UserID    int                 `json:"user_id" pg:",notnull"`
+	Type      string              `json:"type" pg:",notnull"`
+	Message   string              `json:"message" pg:",notnull"`
+	Payload   notificationPayload `json:"payload" pg:"type:jsonb"`
+	Read      int                 `json:"read" pg:",notnull"`
+	CreatedAt string              `json:"created_at" pg:",notnull"`
+	// EmailPending is kept false on rows from before emails with default
+	EmailPending bool `json:"email_pending" pg:",notnull,use_zero,default:false"`
+	// SourceID is null on notifications not created by an outbox event
+	SourceID string `json:"source_id"`
+}
+
+type notificationPayload struct {
+	ActorID    string `json:"actor_id,omitempty"`
+	ActorName  string `json:"actor_name,omitempty"`
+	TargetType string `json:"target_type,omitempty"`
+	TargetID   string `json:"target_id,omitempty"`
+	TargetName string `json:"target_name,omitempty"`
+	Count      int    `json:"count,omitempty"`
+}
+
+type notificationPreference struct {
+	DigestSentAt    time.Time         `json:"digest_sent_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	User            *user             `json:"user" pg:"rel:has-one"`
+	Channels        map[string]string `json:"channels" pg:"type:jsonb"`
+	QuietHoursStart string            `json:"quiet_hours_start"`
+	QuietHoursEnd   string            `json:"quiet_hours_end"`
+	UserID          int               `json:"user_id" pg:",pk,type:bigint,on_delete:CASCADE"`
+	DailyDigest     bool              `json:"daily_digest" pg:",notnull,use_zero"`
 }
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type NotificationPreferenceRepository struct {
+	db *pg.DB
+}
+
+func NewNotificationPreferenceRepository(db *pg.DB) *NotificationPreferenceRepository {
+	rc := &NotificationPreferenceRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Get returns the notification preferences of the user, the defaults if they never saved any.
+func (rc *NotificationPreferenceRepository) Get(ctx context.Context, userID string) (*model.NotificationPreferences, error) {
+	if userID == "" || userID == "0" {
+		return nil, pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	prefs := new(notificationPreference)
+
+	err := rc.db.Model(prefs).Where("user_id = ?", userID).Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return &model.NotificationPreferences{
+				Channels: map[model.NotificationType]model.NotificationChannel{},
+			}, nil
+		}
+
+		return nil, pkg.NewError(err, "failed to get notification preferences", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(prefs), nil
+}
+
+// Upsert saves the channels, quiet hours and digest option of the user, the last digest time is kept.
+func (rc *NotificationPreferenceRepository) Upsert(ctx context.Context, userID string, prefs *model.NotificationPreferences) error {
+	if userID == "" || userID == "0" {
+		return pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	sqlPrefs := rc.internalToSQL(userID, prefs)
+	sqlPrefs.UpdatedAt = time.Now()
+
+	_, err := rc.db.Model(sqlPrefs).
+		OnConflict("(user_id) DO UPDATE").
+		Set("channels = EXCLUDED.channels").
+		Set("quiet_hours_start = EXCLUDED.quiet_hours_start").
+		Set("quiet_hours_end = EXCLUDED.quiet_hours_end").
+		Set("daily_digest = EXCLUDED.daily_digest").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+	if err != nil {
+		return pkg.NewError(err, "failed to save notification preferences", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// UpdateDigestSentAt records when the last daily digest of notifications was sent to the user.
+func (rc *NotificationPreferenceRepository) UpdateDigestSentAt(ctx context.Context, userID string, sentAt time.Time) error {
+	if userID == "" || userID == "0" {
+		return pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	_, err := rc.db.Model((*notificationPreference)(nil)).
+		Set("digest_sent_at = ?", sentAt).
+		Where("user_id = ?", userID).
+		Update()
+	if err != nil {
+		return pkg.NewError(err, "failed to update notification digest time", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (rc *NotificationPreferenceRepository) internalToSQL(userID string, prefs *model.NotificationPreferences) *notificationPreference {
+	uID, _ := strconv.Atoi(userID)
+	channels := make(map[string]string, len(prefs.Channels))
+	for k, v := range prefs.Channels {
+		channels[string(k)] = string(v)
+	}
+	return &notificationPreference{
+		DigestSentAt:    prefs.DigestSentAt,
+		Channels:        channels,
+		QuietHoursStart: prefs.QuietHoursStart,
+		QuietHoursEnd:   prefs.QuietHoursEnd,
+		UserID:          uID,
+		DailyDigest:     prefs.DailyDigest,
+	}
+}
+
+func (rc *NotificationPreferenceRepository) sqlToInternal(prefs *notificationPreference) *model.NotificationPreferences {
+	channels := make(map[model.NotificationType]model.NotificationChannel, len(prefs.Channels))
+	for k, v := range prefs.Channels {
+		channels[model.NotificationType(k)] = model.NotificationChannel(v)
+	}
+	return &model.NotificationPreferences{
+		Channels:        channels,
+		QuietHoursStart: prefs.QuietHoursStart,
+		QuietHoursEnd:   prefs.QuietHoursEnd,
+		DailyDigest:     prefs.DailyDigest,
+		DigestSentAt:    prefs.DigestSentAt,
+	}
+}
+
+func (rc *NotificationPreferenceRepository) createSchema(db *pg.DB) error {
+	model := (*notificationPreference)(nil)
+
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model(model).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create notification preference table", http.StatusInternalServerError)
+	}
+
+	return nil
+}
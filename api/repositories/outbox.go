@@ -0,0 +1,269 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type OutboxRepository struct {
+	db *pg.DB
+}
+
+func NewOutboxRepository(db *pg.DB) *OutboxRepository {
+	rc := &OutboxRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// insertOutboxEvents writes the events with the state change they are about, db is the transaction of the change.
+// Events without an aggregate id get aggregateID, the id of the entity created in the transaction.
+func insertOutboxEvents(db orm.DB, aggregateID string, events []*model.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	sqlEvents := make([]outboxEvent, 0, len(events))
+	for _, v := range events {
+		if v.AggregateID == "" {
+			v.AggregateID = aggregateID
+		}
+
+		sqlEvent := outboxEventToSQL(v)
+		sqlEvent.NextAttemptAt = sqlEvent.CreatedAt
+		sqlEvents = append(sqlEvents, *sqlEvent)
+	}
+
+	_, err := db.Model(&sqlEvents).Insert()
+
+	return err
+}
+
+// insertEntityEvent writes an event whose payload is the entity as it was stored, db is the transaction of the change
+func insertEntityEvent(db orm.DB, eventType model.DomainEventType, aggregateID string, entity any) error {
+	event, err := model.NewDomainEvent(eventType, aggregateID, entity)
+	if err != nil {
+		return err
+	}
+
+	return insertOutboxEvents(db, aggregateID, []*model.DomainEvent{event})
+}
+
+// ClaimDue returns the events due by now, oldest first, and leases them so other instances skip them.
+// An event whose dispatcher stopped before reporting back is due again when the lease ends.
+func (rc *OutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.DomainEvent, error) {
+	events := make([]outboxEvent, 0)
+
+	due := rc.db.Model((*outboxEvent)(nil)).
+		Column("id").
+		Where("next_attempt_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	_, err := rc.db.Model(&events).
+		Set("next_attempt_at = ?", now.Add(lease)).
+		Where("id IN (?)", due).
+		Returning("*").
+		Update()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to claim outbox events", http.StatusInternalServerError)
+	}
+
+	internalEvents := make([]model.DomainEvent, 0, len(events))
+	for _, v := range events {
+		internalEvents = append(internalEvents, *outboxEventToInternal(&v))
+	}
+
+	return internalEvents, nil
+}
+
+// Add writes events that are not about a state change of the api, like queued deliveries.
+func (rc *OutboxRepository) Add(ctx context.Context, events ...*model.DomainEvent) error {
+	if err := insertOutboxEvents(rc.db, "", events); err != nil {
+		return pkg.NewError(err, "failed to add outbox events", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// Delete removes a delivered event from the outbox.
+func (rc *OutboxRepository) Delete(ctx context.Context, eventID string) error {
+	_, err := rc.db.Model((*outboxEvent)(nil)).Where("id = ?", eventID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete outbox event", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// Reschedule records a failed attempt of the event and when it is tried again.
+func (rc *OutboxRepository) Reschedule(ctx context.Context, event *model.DomainEvent) error {
+	sqlEvent := outboxEventToSQL(event)
+
+	_, err := rc.db.Model(sqlEvent).
+		Column("next_attempt_at", "delivered", "last_error", "attempts").
+		WherePK().
+		Update()
+	if err != nil {
+		return pkg.NewError(err, "failed to reschedule outbox event", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// MoveToDeadLetters gives up on the event, it is kept in the dead letter table until it is retried.
+func (rc *OutboxRepository) MoveToDeadLetters(ctx context.Context, event *model.DomainEvent) error {
+	sqlEvent := outboxEventToSQL(event)
+
+	deadLetter := outboxDeadLetter{
+		outboxEvent: *sqlEvent,
+		DeadAt:      time.Now(),
+	}
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Model(&deadLetter).Insert(); err != nil {
+			return err
+		}
+
+		_, err := tx.Model((*outboxEvent)(nil)).Where("id = ?", sqlEvent.ID).Delete()
+
+		return err
+	})
+	if err != nil {
+		return pkg.NewError(err, "failed to move outbox event to dead letters", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (rc *OutboxRepository) ListDeadLetters(ctx context.Context, opts model.PaginationOpts) (*model.DeadLetterList, error) {
+	deadLetters := make([]outboxDeadLetter, 0)
+
+	query := rc.db.Model(&deadLetters).OrderExpr("dead_at DESC")
+
+	query = applyStandardQueries(query, opts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list dead letters", http.StatusInternalServerError)
+	}
+
+	internalDeadLetters := make([]model.DeadLetter, 0, len(deadLetters))
+	for _, v := range deadLetters {
+		internalDeadLetters = append(internalDeadLetters, model.DeadLetter{
+			DomainEvent: *outboxEventToInternal(&v.outboxEvent),
+			DeadAt:      v.DeadAt,
+		})
+	}
+
+	return &model.DeadLetterList{
+		DeadLetters: internalDeadLetters,
+		Total:       count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+// RetryDeadLetter moves the dead letter back to the outbox with its attempts reset, it is due right away.
+// Handlers that delivered it before are still skipped.
+func (rc *OutboxRepository) RetryDeadLetter(ctx context.Context, eventID string) error {
+	deadLetter := new(outboxDeadLetter)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(deadLetter).
+			Where("id = ?", eventID).
+			Returning("*").
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		if deadLetter.ID == 0 {
+			return pg.ErrNoRows
+		}
+
+		sqlEvent := deadLetter.outboxEvent
+		sqlEvent.Attempts = 0
+		sqlEvent.NextAttemptAt = time.Now()
+
+		_, err = tx.Model(&sqlEvent).Insert()
+
+		return err
+	})
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return pkg.NewError(err, "no dead letter "+eventID, http.StatusNotFound)
+		}
+
+		return pkg.NewError(err, "failed to retry dead letter", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func outboxEventToSQL(event *model.DomainEvent) *outboxEvent {
+	eID, _ := strconv.Atoi(event.ID)
+
+	return &outboxEvent{
+		CreatedAt:     event.CreatedAt,
+		NextAttemptAt: event.NextAttemptAt,
+		ID:            eID,
+		Type:          string(event.Type),
+		AggregateID:   event.AggregateID,
+		Payload:       event.Payload,
+		Delivered:     event.Delivered,
+		LastError:     event.LastError,
+		Attempts:      event.Attempts,
+	}
+}
+
+func outboxEventToInternal(event *outboxEvent) *model.DomainEvent {
+	return &model.DomainEvent{
+		CreatedAt:     event.CreatedAt,
+		NextAttemptAt: event.NextAttemptAt,
+		ID:            strconv.Itoa(event.ID),
+		Type:          model.DomainEventType(event.Type),
+		AggregateID:   event.AggregateID,
+		Payload:       event.Payload,
+		Delivered:     event.Delivered,
+		LastError:     event.LastError,
+		Attempts:      event.Attempts,
+	}
+}
+
+func (rc *OutboxRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*outboxEvent)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create outbox table", http.StatusInternalServerError)
+	}
+
+	if err := db.Model((*outboxDeadLetter)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create outbox dead letter table", http.StatusInternalServerError)
+	}
+
+	if _, err := db.Exec("CREATE INDEX IF NOT EXISTS outbox_event_due_idx ON outbox_events (next_attempt_at)"); err != nil {
+		return pkg.NewError(err, "failed to create outbox index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
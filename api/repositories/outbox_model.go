@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type outboxEvent struct {
+	CreatedAt     time.Time       `json:"created_at"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" pg:",notnull"`
+	ID            int             `json:"id" pg:",pk"`
+	Type          string          `json:"type" pg:",notnull"`
+	AggregateID   string          `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload" pg:"type:jsonb"`
+	Delivered     []string        `json:"delivered" pg:",array"`
+	LastError     string          `json:"last_error"`
+	Attempts      int             `json:"attempts" pg:",notnull,use_zero"`
+}
+
+// outboxDeadLetter keeps the id of its outbox event so a retry moves it back as the same event
+type outboxDeadLetter struct {
+	outboxEvent
+	DeadAt time.Time `json:"dead_at" pg:",notnull"`
+}
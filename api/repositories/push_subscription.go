@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type PushSubscriptionRepository struct {
+	db *pg.DB
+}
+
+func NewPushSubscriptionRepository(db *pg.DB) *PushSubscriptionRepository {
+	rc := &PushSubscriptionRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Upsert stores the subscription, subscribing the same endpoint again moves it to the user with its new keys.
+func (rc *PushSubscriptionRepository) Upsert(ctx context.Context, subscription *model.PushSubscription) (*model.PushSubscription, error) {
+	sqlSubscription := rc.internalToSQL(subscription)
+
+	_, err := rc.db.Model(sqlSubscription).
+		OnConflict("(endpoint) DO UPDATE").
+		Set("user_id = EXCLUDED.user_id").
+		Set("p256dh = EXCLUDED.p256dh").
+		Set("auth = EXCLUDED.auth").
+		Set("expires_at = EXCLUDED.expires_at").
+		Set("user_agent = EXCLUDED.user_agent").
+		Returning("id").
+		Insert()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to save push subscription", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlSubscription), nil
+}
+
+func (rc *PushSubscriptionRepository) Delete(ctx context.Context, userID, subscriptionID string) error {
+	result, err := rc.db.Model((*pushSubscription)(nil)).Where("id = ? AND user_id = ?", subscriptionID, userID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete push subscription", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no push subscription deleted", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Get returns the subscription of the user.
+func (rc *PushSubscriptionRepository) Get(ctx context.Context, userID, subscriptionID string) (*model.PushSubscription, error) {
+	sqlSubscription := new(pushSubscription)
+
+	err := rc.db.Model(sqlSubscription).
+		Where("id = ? AND user_id = ?", subscriptionID, userID).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, pkg.NewError(err, "push subscription not found", http.StatusNotFound)
+		}
+
+		return nil, pkg.NewError(err, "failed to find push subscription", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlSubscription), nil
+}
+
+// ListByUser returns the subscriptions of every device of the user.
+func (rc *PushSubscriptionRepository) ListByUser(ctx context.Context, userID string) ([]model.PushSubscription, error) {
+	subscriptions := make([]pushSubscription, 0)
+
+	err := rc.db.Model(&subscriptions).
+		Where("user_id = ?", userID).
+		Order("id").
+		Select()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list push subscriptions", http.StatusInternalServerError)
+	}
+
+	internalSubscriptions := make([]model.PushSubscription, 0, len(subscriptions))
+	for _, v := range subscriptions {
+		internalSubscriptions = append(internalSubscriptions, *rc.sqlToInternal(&v))
+	}
+
+	return internalSubscriptions, nil
+}
+
+// DeleteExpired deletes the subscriptions browsers dropped before the given time and returns how many were deleted.
+func (rc *PushSubscriptionRepository) DeleteExpired(ctx context.Context, expiredBefore time.Time) (int, error) {
+	result, err := rc.db.Model((*pushSubscription)(nil)).
+		Where("expires_at IS NOT NULL").
+		Where("expires_at <= ?", expiredBefore).
+		Delete()
+	if err != nil {
+		return 0, pkg.NewError(err, "failed to delete expired push subscriptions", http.StatusInternalServerError)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (rc *PushSubscriptionRepository) internalToSQL(newSubscription *model.PushSubscription) *pushSubscription {
+	sID, _ := strconv.Atoi(newSubscription.ID)
+	userID, _ := strconv.Atoi(newSubscription.UserID)
+
+	return &pushSubscription{
+		CreatedAt: newSubscription.CreatedAt,
+		ExpiresAt: newSubscription.ExpiresAt,
+		ID:        sID,
+		UserID:    userID,
+		Endpoint:  newSubscription.Endpoint,
+		P256dh:    newSubscription.P256dh,
+		Auth:      newSubscription.Auth,
+		UserAgent: newSubscription.UserAgent,
+	}
+}
+
+func (rc *PushSubscriptionRepository) sqlToInternal(newSubscription *pushSubscription) *model.PushSubscription {
+	return &model.PushSubscription{
+		CreatedAt: newSubscription.CreatedAt,
+		ExpiresAt: newSubscription.ExpiresAt,
+		ID:        strconv.Itoa(newSubscription.ID),
+		UserID:    strconv.Itoa(newSubscription.UserID),
+		Endpoint:  newSubscription.Endpoint,
+		P256dh:    newSubscription.P256dh,
+		Auth:      newSubscription.Auth,
+		UserAgent: newSubscription.UserAgent,
+	}
+}
+
+func (rc *PushSubscriptionRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*pushSubscription)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create push subscription table", http.StatusInternalServerError)
+	}
+
+	if _, err := db.Exec("CREATE INDEX IF NOT EXISTS push_subscription_user_idx ON push_subscriptions (user_id)"); err != nil {
+		return pkg.NewError(err, "failed to create push subscription index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
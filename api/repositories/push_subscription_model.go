@@ -0,0 +1,15 @@
+package repositories
+
+import "time"
+
+type pushSubscription struct {
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      *user     `json:"user" pg:"rel:has-one"`
+	ID        int       `json:"id" pg:",pk"`
+	UserID    int       `json:"user_id" pg:",notnull,on_delete:CASCADE"`
+	Endpoint  string    `json:"endpoint" pg:",notnull,unique:push_subscription_endpoint"`
+	P256dh    string    `json:"p256dh" pg:",notnull"`
+	Auth      string    `json:"auth" pg:",notnull"`
+	UserAgent string    `json:"user_agent"`
+}
@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type ReactionRepository struct {
+	db *pg.DB
+}
+
+func NewReactionRepository(db *pg.DB) *ReactionRepository {
+	rc := &ReactionRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Set adds the reaction of the user to the event, or replaces the emoji if the user reacted already.
+// Reactions of the event owner are marked as notified since the owner is never notified about them.
+func (rc *ReactionRepository) Set(ctx context.Context, newReaction *model.Reaction, ownReaction bool) (*model.Reaction, error) {
+	sqlReaction := rc.internalToSQL(newReaction)
+	sqlReaction.Notified = ownReaction
+
+	_, err := rc.db.Model(sqlReaction).
+		OnConflict("(event_id, user_id) DO UPDATE").
+		Set("emoji = EXCLUDED.emoji, updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Insert()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to set reaction", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlReaction), nil
+}
+
+func (rc *ReactionRepository) Delete(ctx context.Context, eventID, userID string) error {
+	result, err := rc.db.Model(&reaction{}).Where("event_id = ? AND user_id = ?", eventID, userID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete reaction", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no reaction deleted on event: "+eventID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *ReactionRepository) List(ctx context.Context, opts *model.ReactionFindOpts) (*model.ReactionList, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	reactions := make([]reaction, 0)
+
+	query := rc.db.Model(&reactions).
+		Relation("User.id").
+		Relation("User.username").
+		OrderExpr("reaction.created_at DESC, reaction.id DESC")
+
+	query = applyStandardQueries(query, opts.PaginationOpts)
+
+	if opts.EventID.IsSended {
+		query = applyFilterWithOperand(query, "reaction.event_id", opts.EventID)
+	}
+
+	if opts.Emoji.IsSended {
+		query = applyFilterWithOperand(query, "reaction.emoji", opts.Emoji)
+	}
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list reactions", http.StatusInternalServerError)
+	}
+
+	internalReactions := make([]model.Reaction, 0, len(reactions))
+	for _, v := range reactions {
+		internalReactions = append(internalReactions, *rc.sqlToInternal(&v))
+	}
+
+	return &model.ReactionList{
+		Reactions: internalReactions,
+		Total:     count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+// PendingBatches returns the reactions of other users the event owners are not notified about, one batch per event.
+func (rc *ReactionRepository) PendingBatches(ctx context.Context) ([]model.ReactionBatch, error) {
+	batches := make([]reactionBatch, 0)
+
+	err := rc.db.Model((*reaction)(nil)).
+		ColumnExpr("reaction.event_id").
+		// the name of a time capsule is hidden until it opens
+		ColumnExpr("CASE WHEN "+lockedCapsuleSQL+" THEN '' ELSE event.name END AS event_name").
+		ColumnExpr("event.user_id AS owner_id").
+		ColumnExpr("max(reaction.id) AS last_id").
+		ColumnExpr("count(*) AS count").
+		Join("JOIN events AS event ON event.id = reaction.event_id AND event.deleted_at IS NULL").
+		Where("reaction.notified = false").
+		Group("reaction.event_id", "event.id").
+		Select(&batches)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to find pending reactions", http.StatusInternalServerError)
+	}
+
+	internalBatches := make([]model.ReactionBatch, 0, len(batches))
+	for _, v := range batches {
+		internalBatches = append(internalBatches, model.ReactionBatch{
+			EventID:   strconv.Itoa(v.EventID),
+			EventName: v.EventName,
+			OwnerID:   strconv.Itoa(v.OwnerID),
+			LastID:    strconv.Itoa(v.LastID),
+			Count:     v.Count,
+		})
+	}
+
+	return internalBatches, nil
+}
+
+// MarkNotified marks the reactions of the batch as notified, reactions newer than the batch are kept pending.
+func (rc *ReactionRepository) MarkNotified(ctx context.Context, batch *model.ReactionBatch) error {
+	_, err := rc.db.Model((*reaction)(nil)).
+		Set("notified = true").
+		Where("event_id = ? AND id <= ? AND notified = false", batch.EventID, batch.LastID).
+		Update()
+	if err != nil {
+		return pkg.NewError(err, "failed to mark reactions notified", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (rc *ReactionRepository) internalToSQL(newReaction *model.Reaction) *reaction {
+	rID, _ := strconv.Atoi(newReaction.ID)
+	eventID, _ := strconv.Atoi(newReaction.EventID)
+	userID, _ := strconv.Atoi(newReaction.UserID)
+
+	return &reaction{
+		CreatedAt: newReaction.CreatedAt,
+		UpdatedAt: newReaction.UpdatedAt,
+		Emoji:     newReaction.Emoji,
+		ID:        rID,
+		EventID:   eventID,
+		UserID:    userID,
+	}
+}
+
+func (rc *ReactionRepository) sqlToInternal(newReaction *reaction) *model.Reaction {
+	var reactor *model.User
+	if newReaction.User != nil {
+		reactor = &model.User{
+			ID:       strconv.Itoa(newReaction.User.ID),
+			Username: newReaction.User.Username,
+		}
+	}
+
+	return &model.Reaction{
+		CreatedAt: newReaction.CreatedAt,
+		UpdatedAt: newReaction.UpdatedAt,
+		User:      reactor,
+		Emoji:     newReaction.Emoji,
+		ID:        strconv.Itoa(newReaction.ID),
+		EventID:   strconv.Itoa(newReaction.EventID),
+		UserID:    strconv.Itoa(newReaction.UserID),
+	}
+}
+
+func (rc *ReactionRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*reaction)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create reaction table", http.StatusInternalServerError)
+	}
+
+	indexQuery := "CREATE INDEX IF NOT EXISTS reaction_pending_idx ON reactions (event_id, id) WHERE notified = false"
+	if _, err := db.Exec(indexQuery); err != nil {
+		return pkg.NewError(err, "failed to create reaction index", http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import "time"
+
+type reaction struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      *user     `json:"user" pg:"rel:has-one"`
+	Event     *event    `json:"event" pg:"rel:has-one"`
+	Emoji     string    `json:"emoji" pg:",notnull"`
+	ID        int       `json:"id" pg:",pk"`
+	EventID   int       `json:"event_id" pg:",notnull,unique:reaction_event_user,on_delete:CASCADE"`
+	UserID    int       `json:"user_id" pg:",notnull,unique:reaction_event_user,on_delete:CASCADE"`
+	// Notified is set once the owner of the event got a notification about the reaction
+	Notified bool `json:"notified" pg:",notnull,use_zero"`
+}
+
+// reactionCount is the scan target of reaction aggregates per event
+type reactionCount struct {
+	EventID int    `json:"event_id"`
+	Emoji   string `json:"emoji"`
+	Count   int    `json:"count"`
+}
+
+// reactionBatch is the scan target of reactions waiting for a notification
+type reactionBatch struct {
+	EventID   int    `json:"event_id"`
+	EventName string `json:"event_name"`
+	OwnerID   int    `json:"owner_id"`
+	LastID    int    `json:"last_id"`
+	Count     int    `json:"count"`
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// StreamTicketRepository keeps the notification stream tickets in the database,
+// so a ticket given by one instance opens the stream on any other.
+type StreamTicketRepository struct {
+	db *pg.DB
+}
+
+func NewStreamTicketRepository(db *pg.DB) *StreamTicketRepository {
+	rc := &StreamTicketRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+// Create stores the ticket of the owner until expiresAt, the expired tickets of every user are deleted on the way.
+func (rc *StreamTicketRepository) Create(ctx context.Context, ticket string, owner model.TokenOwner, expiresAt time.Time) error {
+	if _, err := rc.db.Model((*streamTicket)(nil)).Where("expires_at <= ?", time.Now()).Delete(); err != nil {
+		return pkg.NewError(err, "failed to delete expired stream tickets", http.StatusInternalServerError)
+	}
+
+	userID, _ := strconv.Atoi(owner.ID)
+
+	sqlTicket := &streamTicket{
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+		Hash:      hashStreamTicket(ticket),
+		UserID:    userID,
+		RoleID:    int(owner.RoleID),
+	}
+
+	if _, err := rc.db.Model(sqlTicket).Insert(); err != nil {
+		return pkg.NewError(err, "failed to save stream ticket", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// Redeem deletes the ticket and returns its owner, a ticket opens one stream only.
+// It returns an error with http.StatusUnauthorized status if the ticket is unknown, used or expired.
+func (rc *StreamTicketRepository) Redeem(ctx context.Context, ticket string, now time.Time) (model.TokenOwner, error) {
+	sqlTicket := new(streamTicket)
+
+	result, err := rc.db.Model(sqlTicket).
+		Where("hash = ?", hashStreamTicket(ticket)).
+		Returning("*").
+		Delete()
+	if err != nil && err != pg.ErrNoRows {
+		return model.TokenOwner{}, pkg.NewError(err, "failed to redeem stream ticket", http.StatusInternalServerError)
+	}
+
+	if err == pg.ErrNoRows || result.RowsAffected() == 0 || !now.Before(sqlTicket.ExpiresAt) {
+		return model.TokenOwner{}, pkg.NewError(nil, "invalid or expired stream ticket", http.StatusUnauthorized)
+	}
+
+	return model.TokenOwner{
+		ID:     strconv.Itoa(sqlTicket.UserID),
+		RoleID: model.UserRole(sqlTicket.RoleID),
+	}, nil
+}
+
+func hashStreamTicket(ticket string) string {
+	sum := sha256.Sum256([]byte(ticket))
+	return hex.EncodeToString(sum[:])
+}
+
+func (rc *StreamTicketRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*streamTicket)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create stream ticket table", http.StatusInternalServerError)
+	}
+
+	return nil
+}
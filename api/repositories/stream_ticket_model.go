@@ -0,0 +1,13 @@
+package repositories
+
+import "time"
+
+type streamTicket struct {
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" pg:",notnull"`
+	User      *user     `json:"user" pg:"rel:has-one"`
+	// Hash is the sha256 of the ticket, the ticket itself is not stored
+	Hash   string `json:"hash" pg:",pk"`
+	UserID int    `json:"user_id" pg:",notnull,on_delete:CASCADE"`
+	RoleID int    `json:"role_id" pg:",notnull"`
+}
@@ -0,0 +1,278 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/fleimkeipa/lifery/util"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type TagRepository struct {
+	db *pg.DB
+}
+
+func NewTagRepository(db *pg.DB) *TagRepository {
+	rc := &TagRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+func (rc *TagRepository) Create(ctx context.Context, newTag *model.Tag) (*model.Tag, error) {
+	sqlTag := rc.internalToSQL(newTag)
+
+	q := rc.db.Model(sqlTag)
+
+	_, err := q.Insert()
+	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.IntegrityViolation() {
+			return nil, pkg.NewError(err, "tag already exists: "+newTag.Name, http.StatusConflict)
+		}
+
+		return nil, pkg.NewError(err, "failed to create tag "+newTag.Name, http.StatusInternalServerError)
+	}
+
+	return tagToInternal(sqlTag), nil
+}
+
+func (rc *TagRepository) Update(ctx context.Context, tagID string, newTag *model.Tag) (*model.Tag, error) {
+	if tagID == "" || tagID == "0" {
+		return nil, pkg.NewError(nil, "invalid tag id "+tagID, http.StatusBadRequest)
+	}
+
+	newTag.ID = tagID
+
+	sqlTag := rc.internalToSQL(newTag)
+
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	q := rc.db.Model(sqlTag).Where("id = ? AND user_id = ?", tagID, ownerID)
+
+	result, err := q.Update()
+	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.IntegrityViolation() {
+			return nil, pkg.NewError(err, "tag already exists: "+newTag.Name, http.StatusConflict)
+		}
+
+		return nil, pkg.NewError(err, "failed to update tag "+tagID, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, pkg.NewError(nil, "no tag updated: "+tagID, http.StatusBadRequest)
+	}
+
+	return tagToInternal(sqlTag), nil
+}
+
+func (rc *TagRepository) Delete(ctx context.Context, tagID string) error {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	result, err := rc.db.Model(&tag{}).Where("id = ? AND user_id = ?", tagID, ownerID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete tag "+tagID, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no tag deleted: "+tagID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *TagRepository) List(ctx context.Context, opts *model.TagFindOpts) (*model.TagList, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	tags := make([]tag, 0)
+
+	query := rc.db.Model(&tags)
+
+	query = applyOrderBy(query, opts.OrderByOpts)
+
+	query = applyStandardQueries(query, opts.PaginationOpts)
+
+	query = rc.fillFilter(query, opts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list tags", http.StatusInternalServerError)
+	}
+
+	internalTags := make([]model.Tag, 0)
+	for _, v := range tags {
+		internalTags = append(internalTags, *tagToInternal(&v))
+	}
+
+	return &model.TagList{
+		Tags:  internalTags,
+		Total: count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+func (rc *TagRepository) GetByID(ctx context.Context, tagID string) (*model.Tag, error) {
+	if tagID == "" || tagID == "0" {
+		return nil, pkg.NewError(nil, "invalid tag ID: "+tagID, http.StatusBadRequest)
+	}
+
+	resp := new(tag)
+
+	if err := rc.db.Model(resp).Where("id = ?", tagID).Select(); err != nil {
+		return nil, pkg.NewError(err, "failed to find tag by ID "+tagID, http.StatusInternalServerError)
+	}
+
+	return tagToInternal(resp), nil
+}
+
+// FindOrCreate returns the tags of the user with the given names, creating the missing ones.
+func (rc *TagRepository) FindOrCreate(ctx context.Context, userID string, names []string, color string) ([]model.Tag, error) {
+	if len(names) == 0 {
+		return []model.Tag{}, nil
+	}
+
+	ownerID, _ := strconv.Atoi(userID)
+	now := util.Now()
+
+	newTags := make([]tag, 0, len(names))
+	for _, name := range names {
+		newTags = append(newTags, tag{
+			Name:      name,
+			Color:     color,
+			UserID:    ownerID,
+			CreatedAt: now,
+		})
+	}
+
+	if _, err := rc.db.Model(&newTags).OnConflict("DO NOTHING").Insert(); err != nil {
+		return nil, pkg.NewError(err, "failed to create tags", http.StatusInternalServerError)
+	}
+
+	tags := make([]tag, 0)
+
+	err := rc.db.Model(&tags).
+		Where("user_id = ?", ownerID).
+		WhereIn("name IN (?)", names).
+		Select()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to find tags", http.StatusInternalServerError)
+	}
+
+	internalTags := make([]model.Tag, 0, len(tags))
+	for _, v := range tags {
+		internalTags = append(internalTags, *tagToInternal(&v))
+	}
+
+	return internalTags, nil
+}
+
+// Counts returns the tags of the user with the number of events using them, most used first.
+func (rc *TagRepository) Counts(ctx context.Context, opts *model.TagFindOpts) ([]model.TagCount, error) {
+	if opts == nil {
+		return nil, pkg.NewError(nil, "opts is nil", http.StatusBadRequest)
+	}
+
+	counts := make([]tagCount, 0)
+
+	query := rc.db.Model(&counts).
+		ColumnExpr("tag.*").
+		ColumnExpr("count(event.id) AS count").
+		Join("LEFT JOIN event_tags AS event_tag ON event_tag.tag_id = tag.id").
+		Join("LEFT JOIN events AS event ON event.id = event_tag.event_id AND event.deleted_at IS NULL").
+		Group("tag.id").
+		OrderExpr("count DESC, tag.name ASC")
+
+	query = applyStandardQueries(query, opts.PaginationOpts)
+
+	if opts.UserID.IsSended {
+		query = applyFilterWithOperand(query, "tag.user_id", opts.UserID)
+	}
+
+	if opts.Name.IsSended {
+		query = applyFilterWithOperand(query, "tag.name", opts.Name)
+	}
+
+	if err := query.Select(); err != nil {
+		return nil, pkg.NewError(err, "failed to count tags", http.StatusInternalServerError)
+	}
+
+	internalCounts := make([]model.TagCount, 0, len(counts))
+	for _, v := range counts {
+		internalCounts = append(internalCounts, model.TagCount{
+			Tag:   *tagToInternal(&v.tag),
+			Count: v.Count,
+		})
+	}
+
+	return internalCounts, nil
+}
+
+func (rc *TagRepository) fillFilter(tx *orm.Query, opts *model.TagFindOpts) *orm.Query {
+	if opts.Name.IsSended {
+		tx = applyFilterWithOperand(tx, "name", opts.Name)
+	}
+
+	if opts.UserID.IsSended {
+		tx = applyFilterWithOperand(tx, "user_id", opts.UserID)
+	}
+
+	return tx
+}
+
+func (rc *TagRepository) internalToSQL(newTag *model.Tag) *tag {
+	tID, _ := strconv.Atoi(newTag.ID)
+	userID, _ := strconv.Atoi(newTag.UserID)
+
+	return &tag{
+		Name:      newTag.Name,
+		Color:     newTag.Color,
+		UserID:    userID,
+		ID:        tID,
+		CreatedAt: newTag.CreatedAt,
+		UpdatedAt: newTag.UpdatedAt,
+	}
+}
+
+// tagToInternal is shared with the event repository which loads tags as a relation
+func tagToInternal(newTag *tag) *model.Tag {
+	return &model.Tag{
+		Name:      newTag.Name,
+		Color:     newTag.Color,
+		UserID:    strconv.Itoa(newTag.UserID),
+		ID:        strconv.Itoa(newTag.ID),
+		CreatedAt: newTag.CreatedAt,
+		UpdatedAt: newTag.UpdatedAt,
+	}
+}
+
+func (rc *TagRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*tag)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create tag table", http.StatusInternalServerError)
+	}
+
+	if err := db.Model((*eventTag)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create event tag table", http.StatusInternalServerError)
+	}
+
+	return nil
+}
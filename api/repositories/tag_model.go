@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+func init() {
+	// many2many join tables have to be registered before they are used in relations
+	orm.RegisterTable((*eventTag)(nil))
+}
+
+type tag struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      *user     `json:"user" pg:"rel:has-one,fk:user_id"`
+	Name      string    `json:"name" pg:",notnull,unique:tag_user_name"`
+	Color     string    `json:"color"`
+	UserID    int       `json:"user_id" pg:",notnull,unique:tag_user_name,on_delete:CASCADE"`
+	ID        int       `json:"id" pg:",pk"`
+}
+
+type eventTag struct {
+	tableName struct{} `pg:"event_tags"`
+	Event     *event   `json:"event" pg:"rel:has-one"`
+	Tag       *tag     `json:"tag" pg:"rel:has-one"`
+	EventID   int      `json:"event_id" pg:",pk,type:bigint,on_delete:CASCADE"`
+	TagID     int      `json:"tag_id" pg:",pk,type:bigint,on_delete:CASCADE"`
+}
+
+// tagCount is the scan target of tag usage aggregates
+type tagCount struct {
+	tag   `pg:",inherit"`
+	Count int `json:"count"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 	"github.com/fleimkeipa/lifery/pkg"
@@ -208,6 +209,48 @@ func (rc *UserRepository) UpdatePassword(ctx context.Context, userID string, has
 	return nil
 }
 
+// UpdatePreferences sets the timezone, digest email frequency and language of the user.
+func (rc *UserRepository) UpdatePreferences(ctx context.Context, userID string, prefs *model.UserPreferences) error {
+	if userID == "" || userID == "0" {
+		return pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	result, err := rc.db.
+		Model(&user{}).
+		Set("timezone = ?", prefs.Timezone).
+		Set("digest_frequency = ?", string(prefs.DigestFrequency)).
+		Set("language = ?", string(prefs.Language)).
+		Where("id = ?", userID).
+		Update()
+	if err != nil {
+		return pkg.NewError(err, "failed to update user preferences", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no user updated: "+userID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// UpdateDigestSentAt records when the last digest email was sent to the user.
+func (rc *UserRepository) UpdateDigestSentAt(ctx context.Context, userID string, sentAt time.Time) error {
+	if userID == "" || userID == "0" {
+		return pkg.NewError(nil, "invalid user ID: "+userID, http.StatusBadRequest)
+	}
+
+	_, err := rc.db.
+		Model(&user{}).
+		Set("digest_sent_at = ?", sentAt).
+		Where("id = ?", userID).
+		Update()
+	if err != nil {
+		return pkg.NewError(err, "failed to update user digest time", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 func (rc *UserRepository) fillFilter(tx *orm.Query, opts *model.UserFindOpts) *orm.Query {
 	if opts.Username.IsSended {
 		tx = applyFilterWithOperand(tx, "username", opts.Username)
@@ -221,6 +264,14 @@ func (rc *UserRepository) fillFilter(tx *orm.Query, opts *model.UserFindOpts) *o
 		tx = applyFilterWithOperand(tx, "role_id", opts.RoleID)
 	}
 
+	if opts.DigestFrequency.IsSended {
+		tx = applyFilterWithOperand(tx, "digest_frequency", opts.DigestFrequency)
+	}
+
+	if opts.VisibleTo.IsSended {
+		tx = tx.Where("id NOT IN ("+blockedUsersSQL+")", opts.VisibleTo.Value, opts.VisibleTo.Value)
+	}
+
 	return tx
 }
 
@@ -259,14 +310,18 @@ func (rc *UserRepository) internalToSQL(newUser *model.User) *user {
 		})
 	}
 	return &user{
-		CreatedAt: newUser.CreatedAt,
-		Connects:  connects,
-		Username:  newUser.Username,
-		Email:     newUser.Email,
-		Password:  newUser.Password,
-		ID:        uID,
-		RoleID:    UserRole(newUser.RoleID),
-		AuthType:  string(newUser.AuthType),
+		CreatedAt:       newUser.CreatedAt,
+		DigestSentAt:    newUser.DigestSentAt,
+		Connects:        connects,
+		Username:        newUser.Username,
+		Email:           newUser.Email,
+		Password:        newUser.Password,
+		Timezone:        newUser.Timezone,
+		DigestFrequency: string(newUser.DigestFrequency),
+		Language:        string(newUser.Language),
+		ID:              uID,
+		RoleID:          UserRole(newUser.RoleID),
+		AuthType:        string(newUser.AuthType),
 	}
 }
 
@@ -284,14 +339,18 @@ func (rc *UserRepository) sqlToInternal(newUser *user) *model.User {
 		})
 	}
 	return &model.User{
-		CreatedAt: newUser.CreatedAt,
-		Connects:  connects,
-		Username:  newUser.Username,
-		Email:     newUser.Email,
-		Password:  newUser.Password,
-		ID:        uID,
-		RoleID:    model.UserRole(newUser.RoleID),
-		AuthType:  model.AuthType(newUser.AuthType),
+		CreatedAt:       newUser.CreatedAt,
+		DigestSentAt:    newUser.DigestSentAt,
+		Connects:        connects,
+		Username:        newUser.Username,
+		Email:           newUser.Email,
+		Password:        newUser.Password,
+		Timezone:        newUser.Timezone,
+		DigestFrequency: model.DigestFrequency(newUser.DigestFrequency),
+		Language:        model.Language(newUser.Language),
+		ID:              uID,
+		RoleID:          model.UserRole(newUser.RoleID),
+		AuthType:        model.AuthType(newUser.AuthType),
 	}
 }
 
@@ -307,5 +366,9 @@ func (rc *UserRepository) createSchema(db *pg.DB) error {
 		return pkg.NewError(err, "failed to create user table", http.StatusInternalServerError)
 	}
 
+	if err := addMissingColumns(db, model); err != nil {
+		return pkg.NewError(err, "failed to add user columns", http.StatusInternalServerError)
+	}
+
 	return nil
 }
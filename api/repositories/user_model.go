@@ -3,12 +3,16 @@ package repositories
 import "time"
 
 type user struct {
-	CreatedAt time.Time  `json:"created_at"`
-	Username  string     `json:"username" pg:",unique"`
-	Email     string     `json:"email" pg:",unique"`
-	Password  string     `json:"password"`
-	Connects  []*connect `json:"connects" pg:"rel:has_many,on_delete:CASCADE"`
-	ID        int        `json:"id" pg:",pk"`
-	RoleID    UserRole   `json:"role_id"`
-	AuthType  string     `json:"auth_type"`
+	CreatedAt       time.Time  `json:"created_at"`
+	DigestSentAt    time.Time  `json:"digest_sent_at"`
+	Username        string     `json:"username" pg:",unique"`
+	Email           string     `json:"email" pg:",unique"`
+	Password        string     `json:"password"`
+	Timezone        string     `json:"timezone"`
+	DigestFrequency string     `json:"digest_frequency"`
+	Language        string     `json:"language"`
+	Connects        []*connect `json:"connects" pg:"rel:has-many,on_delete:CASCADE"`
+	ID              int        `json:"id" pg:",pk"`
+	RoleID          UserRole   `json:"role_id"`
+	AuthType        string     `json:"auth_type"`
 }
@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// webPushRecordSize is the aes128gcm record size, payloads are sent in a single record
+	webPushRecordSize = 4096
+	// webPushTTL is how long push services keep a message for an offline browser
+	webPushTTL = 24 * time.Hour
+	// vapidTokenTTL is the lifetime of the VAPID token, push services accept up to 24 hours
+	vapidTokenTTL = 12 * time.Hour
+)
+
+// WebPushRepository sends web push messages encrypted with aes128gcm (RFC 8291) and signed with VAPID (RFC 8292).
+type WebPushRepository struct {
+	client     *http.Client
+	privateKey *ecdsa.PrivateKey
+	publicKey  string
+	subject    string
+}
+
+// NewWebPushRepository reads the VAPID keys as base64url like the web-push tooling generates them.
+// Messages cannot be sent without the keys, they are not needed to run the api.
+// Endpoints are user input, push services are public so private addresses are never dialed.
+func NewWebPushRepository() *WebPushRepository {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: publicAddressOnly}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	rc := &WebPushRepository{
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: transport,
+			// a redirect would move the message to an address that was not checked as the endpoint
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		publicKey: os.Getenv("VAPID_PUBLIC_KEY"),
+		subject:   os.Getenv("VAPID_SUBJECT"),
+	}
+
+	privateKey := os.Getenv("VAPID_PRIVATE_KEY")
+	if privateKey == "" || rc.publicKey == "" {
+		logger.Log.Warn("VAPID keys are not set, web push is disabled")
+		return rc
+	}
+
+	raw, err := decodeBase64URL(privateKey)
+	if err != nil {
+		logger.Log.Fatalf("failed to decode VAPID private key: %v", err)
+	}
+
+	rc.privateKey, err = ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		logger.Log.Fatalf("failed to parse VAPID private key: %v", err)
+	}
+
+	return rc
+}
+
+// PublicKey returns the VAPID public key, empty if web push is disabled
+func (rc *WebPushRepository) PublicKey() string {
+	if rc.privateKey == nil {
+		return ""
+	}
+
+	return rc.publicKey
+}
+
+func (rc *WebPushRepository) Send(ctx context.Context, subscription *model.PushSubscription, payload []byte) error {
+	if rc.privateKey == nil {
+		return pkg.NewError(nil, "web push is not configured", http.StatusServiceUnavailable)
+	}
+
+	body, err := encryptPushPayload(subscription, payload)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := rc.vapidAuthorization(subscription.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return pkg.NewError(err, "failed to create push request", http.StatusInternalServerError)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", fmt.Sprintf("%d", int(webPushTTL.Seconds())))
+
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return pkg.NewError(err, "failed to send push message", http.StatusInternalServerError)
+	}
+	defer resp.Body.Close()
+
+	// push services answer 404 or 410 when the browser dropped the subscription
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return pkg.NewError(nil, "push subscription is gone", http.StatusGone)
+	}
+
+	// the answer of the push service is not passed on, the endpoint is user input
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return pkg.NewError(nil, "push service refused the message", http.StatusBadGateway)
+	}
+
+	return nil
+}
+
+// vapidAuthorization returns the Authorization header value for the push service of the endpoint
+func (rc *WebPushRepository) vapidAuthorization(endpoint string) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", pkg.NewError(err, "invalid push endpoint", http.StatusBadRequest)
+	}
+
+	claims := jwt.MapClaims{
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": time.Now().Add(vapidTokenTTL).Unix(),
+	}
+	if rc.subject != "" {
+		claims["sub"] = rc.subject
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(rc.privateKey)
+	if err != nil {
+		return "", pkg.NewError(err, "failed to sign VAPID token", http.StatusInternalServerError)
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", token, rc.publicKey), nil
+}
+
+// encryptPushPayload encrypts the payload for the browser of the subscription as a single aes128gcm record
+func encryptPushPayload(subscription *model.PushSubscription, payload []byte) ([]byte, error) {
+	browserKeyBytes, err := decodeBase64URL(subscription.P256dh)
+	if err != nil {
+		return nil, pkg.NewError(err, "invalid push subscription key", http.StatusBadRequest)
+	}
+
+	authSecret, err := decodeBase64URL(subscription.Auth)
+	if err != nil {
+		return nil, pkg.NewError(err, "invalid push subscription auth secret", http.StatusBadRequest)
+	}
+
+	// every message is encrypted with a new key pair and salt
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to generate push key", http.StatusInternalServerError)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, pkg.NewError(err, "failed to generate push salt", http.StatusInternalServerError)
+	}
+
+	return sealPushRecord(browserKeyBytes, authSecret, serverKey, salt, payload)
+}
+
+// sealPushRecord encrypts the payload with the server key pair and salt, the header carries both for the browser
+func sealPushRecord(browserKeyBytes, authSecret []byte, serverKey *ecdh.PrivateKey, salt, payload []byte) ([]byte, error) {
+	browserKey, err := ecdh.P256().NewPublicKey(browserKeyBytes)
+	if err != nil {
+		return nil, pkg.NewError(err, "invalid push subscription key", http.StatusBadRequest)
+	}
+
+	sharedSecret, err := serverKey.ECDH(browserKey)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to derive push secret", http.StatusInternalServerError)
+	}
+
+	serverPublicKey := serverKey.PublicKey().Bytes()
+
+	keyInfo := "WebPush: info\x00" + string(browserKeyBytes) + string(serverPublicKey)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to derive push key", http.StatusInternalServerError)
+	}
+
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to derive push key", http.StatusInternalServerError)
+	}
+
+	contentKey, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to derive push key", http.StatusInternalServerError)
+	}
+
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to derive push nonce", http.StatusInternalServerError)
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to create push cipher", http.StatusInternalServerError)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to create push cipher", http.StatusInternalServerError)
+	}
+
+	// the record holds the payload, the last record delimiter and the tag
+	if len(payload)+1+gcm.Overhead() > webPushRecordSize {
+		return nil, pkg.NewError(nil, "push payload is too large", http.StatusBadRequest)
+	}
+
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	header := make([]byte, 0, 16+4+1+len(serverPublicKey))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(serverPublicKey)))
+	header = append(header, serverPublicKey...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// decodeBase64URL decodes base64url with or without padding, browsers and key tools differ
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+)
+
+// the example of RFC 8291 Appendix A
+const (
+	rfc8291Plaintext        = "When I grow up, I want to be a watermelon"
+	rfc8291ServerPrivateKey = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfc8291BrowserPublicKey = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfc8291AuthSecret       = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfc8291Salt             = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfc8291Message          = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func Test_sealPushRecord(t *testing.T) {
+	decode := func(s string) []byte {
+		t.Helper()
+
+		raw, err := decodeBase64URL(s)
+		if err != nil {
+			t.Fatalf("decodeBase64URL(%q) error = %v", s, err)
+		}
+
+		return raw
+	}
+
+	serverKey, err := ecdh.P256().NewPrivateKey(decode(rfc8291ServerPrivateKey))
+	if err != nil {
+		t.Fatalf("NewPrivateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		browserKey []byte
+		payload    []byte
+		want       []byte
+		wantErr    bool
+	}{
+		{
+			name:       "rfc 8291 example",
+			browserKey: decode(rfc8291BrowserPublicKey),
+			payload:    []byte(rfc8291Plaintext),
+			want:       decode(rfc8291Message),
+		},
+		{
+			name:       "invalid browser key",
+			browserKey: []byte{0x04, 0x01},
+			payload:    []byte(rfc8291Plaintext),
+			wantErr:    true,
+		},
+		{
+			name:       "payload larger than a record",
+			browserKey: decode(rfc8291BrowserPublicKey),
+			payload:    bytes.Repeat([]byte("a"), webPushRecordSize),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sealPushRecord(tt.browserKey, decode(rfc8291AuthSecret), serverKey, decode(rfc8291Salt), tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sealPushRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("sealPushRecord() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebPushRepository_Send(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantStatus int
+	}{
+		{
+			name:       "accepted",
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "subscription dropped",
+			statusCode: http.StatusGone,
+			wantStatus: http.StatusGone,
+		},
+		{
+			name:       "subscription unknown",
+			statusCode: http.StatusNotFound,
+			wantStatus: http.StatusGone,
+		},
+		{
+			name:       "push service failure",
+			statusCode: http.StatusInternalServerError,
+			wantStatus: http.StatusBadGateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+
+			// the stand-in push service records the message and answers with the status of the case
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte("secret details of the push service"))
+			}))
+			defer server.Close()
+
+			rc := &WebPushRepository{
+				client:     server.Client(),
+				privateKey: privateKey,
+				publicKey:  rfc8291BrowserPublicKey,
+			}
+
+			subscription := &model.PushSubscription{
+				Endpoint: server.URL + "/push/abc",
+				P256dh:   rfc8291BrowserPublicKey,
+				Auth:     rfc8291AuthSecret,
+			}
+
+			err := rc.Send(context.Background(), subscription, []byte(rfc8291Plaintext))
+
+			var pe *pkg.Error
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Fatalf("Send() error = %v", err)
+			case tt.wantStatus != 0 && (!errors.As(err, &pe) || pe.StatusCode() != tt.wantStatus):
+				t.Fatalf("Send() error = %v, want status %d", err, tt.wantStatus)
+			case err != nil && strings.Contains(err.Error(), "secret details"):
+				t.Fatalf("Send() error = %v, leaks the answer of the push service", err)
+			}
+
+			if got == nil {
+				t.Fatal("Send() did not reach the push service")
+			}
+
+			if got.Header.Get("Content-Encoding") != "aes128gcm" {
+				t.Errorf("Content-Encoding = %q, want aes128gcm", got.Header.Get("Content-Encoding"))
+			}
+
+			if got.Header.Get("TTL") == "" {
+				t.Error("TTL header is missing")
+			}
+
+			if !strings.HasPrefix(got.Header.Get("Authorization"), "vapid t=") {
+				t.Errorf("Authorization = %q, want a vapid token", got.Header.Get("Authorization"))
+			}
+
+			// salt, record size, key length and the 65 byte server key, then the payload, its delimiter and the tag
+			if want := 16 + 4 + 1 + 65 + len(rfc8291Plaintext) + 1 + 16; len(body) != want {
+				t.Errorf("body length = %d, want %d", len(body), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,427 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type WebhookRepository struct {
+	db *pg.DB
+}
+
+func NewWebhookRepository(db *pg.DB) *WebhookRepository {
+	rc := &WebhookRepository{
+		db: db,
+	}
+
+	if err := rc.createSchema(db); err != nil {
+		logger.Log.Fatalf("failed to create schema: %v", err)
+	}
+
+	return rc
+}
+
+func (rc *WebhookRepository) Create(ctx context.Context, hook *model.Webhook) (*model.Webhook, error) {
+	sqlWebhook := rc.internalToSQL(hook)
+
+	if _, err := rc.db.Model(sqlWebhook).Insert(); err != nil {
+		return nil, pkg.NewError(err, "failed to create webhook", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlWebhook), nil
+}
+
+// Update stores the url, events and state of the webhook of the user.
+func (rc *WebhookRepository) Update(ctx context.Context, hook *model.Webhook) (*model.Webhook, error) {
+	sqlWebhook := rc.internalToSQL(hook)
+
+	result, err := rc.db.Model(sqlWebhook).
+		Column("url", "events", "enabled", "failure_count", "disabled_at").
+		Where("id = ? AND user_id = ?", sqlWebhook.ID, sqlWebhook.UserID).
+		Returning("*").
+		Update()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to update webhook", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, pkg.NewError(nil, "no webhook updated: "+hook.ID, http.StatusBadRequest)
+	}
+
+	return rc.sqlToInternal(sqlWebhook), nil
+}
+
+// Delete removes the webhook of the user with its delivery log.
+func (rc *WebhookRepository) Delete(ctx context.Context, userID, webhookID string) error {
+	result, err := rc.db.Model((*webhook)(nil)).Where("id = ? AND user_id = ?", webhookID, userID).Delete()
+	if err != nil {
+		return pkg.NewError(err, "failed to delete webhook", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return pkg.NewError(nil, "no webhook deleted", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (rc *WebhookRepository) GetByID(ctx context.Context, userID, webhookID string) (*model.Webhook, error) {
+	sqlWebhook := new(webhook)
+
+	err := rc.db.Model(sqlWebhook).Where("id = ? AND user_id = ?", webhookID, userID).Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, pkg.NewError(err, "webhook not found", http.StatusNotFound)
+		}
+
+		return nil, pkg.NewError(err, "failed to get webhook", http.StatusInternalServerError)
+	}
+
+	return rc.sqlToInternal(sqlWebhook), nil
+}
+
+func (rc *WebhookRepository) ListByUser(ctx context.Context, userID string) ([]model.Webhook, error) {
+	webhooks := make([]webhook, 0)
+
+	err := rc.db.Model(&webhooks).
+		Where("user_id = ?", userID).
+		Order("id").
+		Select()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list webhooks", http.StatusInternalServerError)
+	}
+
+	internalWebhooks := make([]model.Webhook, 0, len(webhooks))
+	for _, v := range webhooks {
+		internalWebhooks = append(internalWebhooks, *rc.sqlToInternal(&v))
+	}
+
+	return internalWebhooks, nil
+}
+
+// ListSubscribed returns the enabled webhooks of the users that get events of the type.
+func (rc *WebhookRepository) ListSubscribed(ctx context.Context, userIDs []string, eventType model.DomainEventType) ([]model.Webhook, error) {
+	webhooks := make([]webhook, 0)
+
+	err := rc.db.Model(&webhooks).
+		Where("user_id IN (?)", pg.In(userIDs)).
+		Where("enabled").
+		Where("? = ANY(events)", string(eventType)).
+		Order("id").
+		Select()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list subscribed webhooks", http.StatusInternalServerError)
+	}
+
+	internalWebhooks := make([]model.Webhook, 0, len(webhooks))
+	for _, v := range webhooks {
+		internalWebhooks = append(internalWebhooks, *rc.sqlToInternal(&v))
+	}
+
+	return internalWebhooks, nil
+}
+
+// CreateDeliveries queues the deliveries, an event is delivered to a webhook once even when it is queued again.
+func (rc *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	sqlDeliveries := make([]webhookDelivery, 0, len(deliveries))
+	for _, v := range deliveries {
+		sqlDeliveries = append(sqlDeliveries, *rc.deliveryToSQL(&v))
+	}
+
+	_, err := rc.db.Model(&sqlDeliveries).
+		OnConflict("(webhook_id, event_id) DO NOTHING").
+		Insert()
+	if err != nil {
+		return pkg.NewError(err, "failed to create webhook deliveries", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries returns the pending deliveries of enabled webhooks due by now with their webhooks, oldest first,
+// and leases them so other instances skip them. Deliveries of disabled webhooks wait until the webhook is enabled again.
+func (rc *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error) {
+	claimed := make([]webhookDelivery, 0)
+
+	due := rc.db.Model((*webhookDelivery)(nil)).
+		Column("id").
+		Where("status = ?", model.WebhookDeliveryStatusPending).
+		Where("next_attempt_at <= ?", now).
+		Where("webhook_id IN (SELECT id FROM webhooks WHERE enabled)").
+		Order("id").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	_, err := rc.db.Model(&claimed).
+		Set("next_attempt_at = ?", now.Add(lease)).
+		Where("id IN (?)", due).
+		Returning("id").
+		Update()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to claim webhook deliveries", http.StatusInternalServerError)
+	}
+
+	if len(claimed) == 0 {
+		return []model.WebhookDelivery{}, nil
+	}
+
+	ids := make([]int, 0, len(claimed))
+	for _, v := range claimed {
+		ids = append(ids, v.ID)
+	}
+
+	deliveries := make([]webhookDelivery, 0, len(claimed))
+
+	err = rc.db.Model(&deliveries).
+		Relation("Webhook").
+		Where("webhook_delivery.id IN (?)", pg.In(ids)).
+		Order("webhook_delivery.id").
+		Select()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to get webhook deliveries", http.StatusInternalServerError)
+	}
+
+	internalDeliveries := make([]model.WebhookDelivery, 0, len(deliveries))
+	for _, v := range deliveries {
+		internalDeliveries = append(internalDeliveries, *rc.deliveryToInternal(&v))
+	}
+
+	return internalDeliveries, nil
+}
+
+// RecordAttempt stores the outcome of an attempt of the delivery and counts it for its webhook, see model.Webhook.RecordAttempt.
+func (rc *WebhookRepository) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery) error {
+	sqlDelivery := rc.deliveryToSQL(delivery)
+
+	err := rc.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(sqlDelivery).
+			Column("next_attempt_at", "delivered_at", "response_body", "last_error", "status", "response_status", "attempts").
+			WherePK().
+			Update()
+		if err != nil {
+			return err
+		}
+
+		// the webhook is locked so attempts delivered at the same time are counted one after the other
+		sqlHook := new(webhook)
+		err = tx.Model(sqlHook).Where("id = ?", sqlDelivery.WebhookID).For("UPDATE").Select()
+		if err == pg.ErrNoRows {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		hook := rc.sqlToInternal(sqlHook)
+		hook.RecordAttempt(delivery.Status == model.WebhookDeliveryStatusSucceeded, time.Now())
+
+		_, err = tx.Model(rc.internalToSQL(hook)).
+			Column("failure_count", "enabled", "disabled_at").
+			WherePK().
+			Update()
+
+		return err
+	})
+	if err != nil {
+		return pkg.NewError(err, "failed to record webhook delivery attempt", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the delivery log of the webhook, newest first.
+func (rc *WebhookRepository) ListDeliveries(ctx context.Context, webhookID string, opts model.PaginationOpts) (*model.WebhookDeliveryList, error) {
+	deliveries := make([]webhookDelivery, 0)
+
+	query := rc.db.Model(&deliveries).
+		Where("webhook_id = ?", webhookID).
+		OrderExpr("id DESC")
+
+	query = applyStandardQueries(query, opts)
+
+	count, err := query.SelectAndCount()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to list webhook deliveries", http.StatusInternalServerError)
+	}
+
+	internalDeliveries := make([]model.WebhookDelivery, 0, len(deliveries))
+	for _, v := range deliveries {
+		internalDeliveries = append(internalDeliveries, *rc.deliveryToInternal(&v))
+	}
+
+	return &model.WebhookDeliveryList{
+		Deliveries: internalDeliveries,
+		Total:      count,
+		PaginationOpts: model.PaginationOpts{
+			Skip:  opts.Skip,
+			Limit: opts.Limit,
+		},
+	}, nil
+}
+
+func (rc *WebhookRepository) GetDelivery(ctx context.Context, webhookID, deliveryID string) (*model.WebhookDelivery, error) {
+	sqlDelivery := new(webhookDelivery)
+
+	err := rc.db.Model(sqlDelivery).Where("id = ? AND webhook_id = ?", deliveryID, webhookID).Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, pkg.NewError(err, "webhook delivery not found", http.StatusNotFound)
+		}
+
+		return nil, pkg.NewError(err, "failed to get webhook delivery", http.StatusInternalServerError)
+	}
+
+	return rc.deliveryToInternal(sqlDelivery), nil
+}
+
+// Redeliver queues a finished delivery again with its attempts reset, it is due right away.
+func (rc *WebhookRepository) Redeliver(ctx context.Context, webhookID, deliveryID string) (*model.WebhookDelivery, error) {
+	sqlDelivery := new(webhookDelivery)
+
+	result, err := rc.db.Model(sqlDelivery).
+		Set("status = ?", model.WebhookDeliveryStatusPending).
+		Set("attempts = 0").
+		Set("next_attempt_at = ?", time.Now()).
+		Where("id = ? AND webhook_id = ?", deliveryID, webhookID).
+		Where("status != ?", model.WebhookDeliveryStatusPending).
+		Returning("*").
+		Update()
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to redeliver webhook delivery", http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, pkg.NewError(nil, "webhook delivery is pending already", http.StatusBadRequest)
+	}
+
+	return rc.deliveryToInternal(sqlDelivery), nil
+}
+
+func (rc *WebhookRepository) internalToSQL(hook *model.Webhook) *webhook {
+	id, _ := strconv.Atoi(hook.ID)
+	userID, _ := strconv.Atoi(hook.UserID)
+
+	events := make([]string, 0, len(hook.Events))
+	for _, v := range hook.Events {
+		events = append(events, string(v))
+	}
+
+	return &webhook{
+		CreatedAt:    hook.CreatedAt,
+		DisabledAt:   hook.DisabledAt,
+		ID:           id,
+		UserID:       userID,
+		URL:          hook.URL,
+		Secret:       hook.Secret,
+		Events:       events,
+		Enabled:      hook.Enabled,
+		FailureCount: hook.FailureCount,
+	}
+}
+
+func (rc *WebhookRepository) sqlToInternal(hook *webhook) *model.Webhook {
+	events := make([]model.DomainEventType, 0, len(hook.Events))
+	for _, v := range hook.Events {
+		events = append(events, model.DomainEventType(v))
+	}
+
+	return &model.Webhook{
+		CreatedAt:    hook.CreatedAt,
+		DisabledAt:   hook.DisabledAt,
+		ID:           strconv.Itoa(hook.ID),
+		UserID:       strconv.Itoa(hook.UserID),
+		URL:          hook.URL,
+		Secret:       hook.Secret,
+		Events:       events,
+		Enabled:      hook.Enabled,
+		FailureCount: hook.FailureCount,
+	}
+}
+
+func (rc *WebhookRepository) deliveryToSQL(delivery *model.WebhookDelivery) *webhookDelivery {
+	id, _ := strconv.Atoi(delivery.ID)
+	webhookID, _ := strconv.Atoi(delivery.WebhookID)
+
+	return &webhookDelivery{
+		CreatedAt:      delivery.CreatedAt,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		ID:             id,
+		WebhookID:      webhookID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		Status:         int(delivery.Status),
+		ResponseStatus: delivery.ResponseStatus,
+		Attempts:       delivery.Attempts,
+	}
+}
+
+func (rc *WebhookRepository) deliveryToInternal(delivery *webhookDelivery) *model.WebhookDelivery {
+	var hook *model.Webhook
+	if delivery.Webhook != nil {
+		hook = rc.sqlToInternal(delivery.Webhook)
+	}
+
+	return &model.WebhookDelivery{
+		CreatedAt:      delivery.CreatedAt,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		Webhook:        hook,
+		ID:             strconv.Itoa(delivery.ID),
+		WebhookID:      strconv.Itoa(delivery.WebhookID),
+		EventID:        delivery.EventID,
+		EventType:      model.DomainEventType(delivery.EventType),
+		Payload:        delivery.Payload,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		Status:         model.WebhookDeliveryStatus(delivery.Status),
+		ResponseStatus: delivery.ResponseStatus,
+		Attempts:       delivery.Attempts,
+	}
+}
+
+func (rc *WebhookRepository) createSchema(db *pg.DB) error {
+	opts := &orm.CreateTableOptions{
+		IfNotExists:   true,
+		FKConstraints: true,
+	}
+
+	if err := db.Model((*webhook)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create webhook table", http.StatusInternalServerError)
+	}
+
+	if err := db.Model((*webhookDelivery)(nil)).CreateTable(opts); err != nil {
+		return pkg.NewError(err, "failed to create webhook delivery table", http.StatusInternalServerError)
+	}
+
+	indexQueries := []string{
+		"CREATE INDEX IF NOT EXISTS webhook_user_idx ON webhooks (user_id)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS webhook_delivery_event_idx ON webhook_deliveries (webhook_id, event_id)",
+		// retries look for pending deliveries only
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS webhook_delivery_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = %d", model.WebhookDeliveryStatusPending),
+	}
+	for _, query := range indexQueries {
+		if _, err := db.Exec(query); err != nil {
+			return pkg.NewError(err, "failed to create webhook index", http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type webhook struct {
+	CreatedAt    time.Time `json:"created_at"`
+	DisabledAt   time.Time `json:"disabled_at"`
+	User         *user     `json:"user" pg:"rel:has-one"`
+	ID           int       `json:"id" pg:",pk"`
+	UserID       int       `json:"user_id" pg:",notnull,on_delete:CASCADE"`
+	URL          string    `json:"url" pg:",notnull"`
+	Secret       string    `json:"secret" pg:",notnull"`
+	Events       []string  `json:"events" pg:",array,notnull"`
+	Enabled      bool      `json:"enabled" pg:",notnull,use_zero"`
+	FailureCount int       `json:"failure_count" pg:",notnull,use_zero"`
+}
+
+type webhookDelivery struct {
+	CreatedAt      time.Time       `json:"created_at"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" pg:",notnull"`
+	DeliveredAt    time.Time       `json:"delivered_at"`
+	Webhook        *webhook        `json:"webhook" pg:"rel:has-one"`
+	ID             int             `json:"id" pg:",pk"`
+	WebhookID      int             `json:"webhook_id" pg:",notnull,on_delete:CASCADE"`
+	EventID        string          `json:"event_id" pg:",notnull"`
+	EventType      string          `json:"event_type" pg:",notnull"`
+	Payload        json.RawMessage `json:"payload" pg:"type:jsonb"`
+	ResponseBody   string          `json:"response_body"`
+	LastError      string          `json:"last_error"`
+	Status         int             `json:"status" pg:",notnull"`
+	ResponseStatus int             `json:"response_status" pg:",notnull,use_zero"`
+	Attempts       int             `json:"attempts" pg:",notnull,use_zero"`
+}
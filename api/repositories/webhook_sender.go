@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+)
+
+// webhookResponseLimit is how much of the answer of a webhook endpoint is kept in the delivery log
+const webhookResponseLimit = 2048
+
+var errAddressNotPublic = errors.New("address is not public")
+
+// WebhookSenderRepository posts webhook deliveries to the endpoints of users.
+// Endpoints are user input, so they cannot reach the private network of the api unless WEBHOOK_ALLOW_PRIVATE_NETWORKS is true.
+type WebhookSenderRepository struct {
+	client *http.Client
+}
+
+func NewWebhookSenderRepository() *WebhookSenderRepository {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") != "true" {
+		dialer.Control = publicAddressOnly
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &WebhookSenderRepository{
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: transport,
+			// a redirect would move the delivery to an address that was not checked as the endpoint
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Post sends the body to the endpoint, any answer of the endpoint is returned for the caller to judge.
+// The error is set only when no answer was received.
+func (rc *WebhookSenderRepository) Post(ctx context.Context, endpoint string, header http.Header, body []byte) (*model.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to create webhook request", http.StatusBadRequest)
+	}
+
+	req.Header = header
+
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to send webhook", http.StatusBadGateway)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+
+	return &model.WebhookResponse{
+		Body:       string(respBody),
+		StatusCode: resp.StatusCode,
+	}, nil
+}
+
+// publicAddressOnly refuses connections to loopback, private and link local addresses, it checks the resolved address
+func publicAddressOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return errAddressNotPublic
+	}
+
+	return nil
+}
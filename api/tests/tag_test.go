@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/uc"
+)
+
+// tagRepositoryStub filters its tags by the user_id filter like the sql one, other methods are not used
+type tagRepositoryStub struct {
+	interfaces.TagRepository
+	tags []model.Tag
+}
+
+func (rc *tagRepositoryStub) List(ctx context.Context, opts *model.TagFindOpts) (*model.TagList, error) {
+	tags := rc.filter(opts)
+	return &model.TagList{Tags: tags, Total: len(tags)}, nil
+}
+
+func (rc *tagRepositoryStub) Counts(ctx context.Context, opts *model.TagFindOpts) ([]model.TagCount, error) {
+	counts := make([]model.TagCount, 0)
+	for _, v := range rc.filter(opts) {
+		counts = append(counts, model.TagCount{Tag: v})
+	}
+
+	return counts, nil
+}
+
+func (rc *tagRepositoryStub) filter(opts *model.TagFindOpts) []model.Tag {
+	values := strings.Split(opts.UserID.Value, ",")
+
+	tags := make([]model.Tag, 0)
+	for _, v := range rc.tags {
+		matched := slices.Contains(values, v.UserID)
+		if opts.UserID.Operand == model.OperandNot || opts.UserID.Operand == model.OperandNotIn {
+			matched = !matched
+		}
+
+		if matched {
+			tags = append(tags, v)
+		}
+	}
+
+	return tags
+}
+
+func TestTagUC_userIDOperand(t *testing.T) {
+	repo := &tagRepositoryStub{
+		tags: []model.Tag{
+			{ID: "1", Name: "travel", UserID: "1"},
+			{ID: "2", Name: "family", UserID: "2"},
+			{ID: "3", Name: "work", UserID: "3"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		owner      model.TokenOwner
+		filter     model.Filter
+		wantIDs    []string
+		wantStatus int
+	}{
+		{
+			name:    "no filter",
+			owner:   model.TokenOwner{ID: "1"},
+			wantIDs: []string{"1"},
+		},
+		{
+			name:    "eq on the own id",
+			owner:   model.TokenOwner{ID: "1"},
+			filter:  model.Filter{Value: "1", Operand: model.OperandEqual, IsSended: true},
+			wantIDs: []string{"1"},
+		},
+		{
+			name:    "ne on the own id",
+			owner:   model.TokenOwner{ID: "1"},
+			filter:  model.Filter{Value: "1", Operand: model.OperandNot, IsSended: true},
+			wantIDs: []string{"1"},
+		},
+		{
+			name:    "nin on the own id",
+			owner:   model.TokenOwner{ID: "1"},
+			filter:  model.Filter{Value: "1", Operand: model.OperandNotIn, IsSended: true},
+			wantIDs: []string{"1"},
+		},
+		{
+			name:       "another users id",
+			owner:      model.TokenOwner{ID: "1"},
+			filter:     model.Filter{Value: "2", Operand: model.OperandEqual, IsSended: true},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:    "admin eq on another users id",
+			owner:   model.TokenOwner{ID: "1", RoleID: model.AdminRole},
+			filter:  model.Filter{Value: "2", Operand: model.OperandEqual, IsSended: true},
+			wantIDs: []string{"2"},
+		},
+		{
+			name:       "admin ne on another users id",
+			owner:      model.TokenOwner{ID: "1", RoleID: model.AdminRole},
+			filter:     model.Filter{Value: "2", Operand: model.OperandNot, IsSended: true},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), "user", tt.owner)
+			tagUC := uc.NewTagUC(repo)
+
+			list, err := tagUC.List(ctx, &model.TagFindOpts{UserID: tt.filter})
+			if !checkStatus(t, "TagUC.List()", err, tt.wantStatus) {
+				return
+			}
+
+			gotIDs := make([]string, 0)
+			for _, v := range list.Tags {
+				gotIDs = append(gotIDs, v.ID)
+			}
+
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("TagUC.List() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+
+			counts, err := tagUC.Counts(ctx, &model.TagFindOpts{UserID: tt.filter})
+			if !checkStatus(t, "TagUC.Counts()", err, tt.wantStatus) {
+				return
+			}
+
+			gotIDs = make([]string, 0)
+			for _, v := range counts {
+				gotIDs = append(gotIDs, v.Tag.ID)
+			}
+
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("TagUC.Counts() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+// checkStatus reports whether the call succeeded, a missing or different error status fails the test
+func checkStatus(t *testing.T, call string, err error, wantStatus int) bool {
+	t.Helper()
+
+	if wantStatus == 0 {
+		if err != nil {
+			t.Fatalf("%s error = %v", call, err)
+		}
+
+		return true
+	}
+
+	var pe *pkg.Error
+	if !errors.As(err, &pe) || pe.StatusCode() != wantStatus {
+		t.Fatalf("%s error = %v, want status %d", call, err, wantStatus)
+	}
+
+	return false
+}
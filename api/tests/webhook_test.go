@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/uc"
+)
+
+// webhookRepositoryStub hands out the due deliveries and keeps the recorded attempts, other methods are not used
+type webhookRepositoryStub struct {
+	interfaces.WebhookRepository
+	due      []model.WebhookDelivery
+	recorded []model.WebhookDelivery
+}
+
+func (rc *webhookRepositoryStub) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error) {
+	return rc.due, nil
+}
+
+func (rc *webhookRepositoryStub) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery) error {
+	rc.recorded = append(rc.recorded, *delivery)
+	return nil
+}
+
+// webhookSenderStub keeps the posted requests and answers with statusCode
+type webhookSenderStub struct {
+	headers    []http.Header
+	bodies     [][]byte
+	statusCode int
+}
+
+func (rc *webhookSenderStub) Post(ctx context.Context, endpoint string, header http.Header, body []byte) (*model.WebhookResponse, error) {
+	rc.headers = append(rc.headers, header)
+	rc.bodies = append(rc.bodies, body)
+
+	return &model.WebhookResponse{StatusCode: rc.statusCode}, nil
+}
+
+func TestWebhookUC_Dispatch_signature(t *testing.T) {
+	signatureFormat := regexp.MustCompile(`^sha256=[0-9a-f]{64}$`)
+
+	tests := []struct {
+		name    string
+		secret  string
+		payload json.RawMessage
+	}{
+		{
+			name:    "event payload",
+			secret:  "whsec_0123456789abcdef",
+			payload: json.RawMessage(`{"id":"1","type":"event.created","data":{"id":"7"}}`),
+		},
+		{
+			name:    "empty payload",
+			secret:  "whsec_fedcba9876543210",
+			payload: json.RawMessage(`{}`),
+		},
+		{
+			name:    "unicode payload",
+			secret:  "whsec_secret",
+			payload: json.RawMessage(`{"data":{"name":"doğum günü"}}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &webhookRepositoryStub{
+				due: []model.WebhookDelivery{{
+					ID:        "1",
+					EventType: model.DomainEventEventCreated,
+					Payload:   tt.payload,
+					Status:    model.WebhookDeliveryStatusPending,
+					Webhook:   &model.Webhook{ID: "1", URL: "https://example.com/hook", Secret: tt.secret, Enabled: true},
+				}},
+			}
+			sender := &webhookSenderStub{statusCode: http.StatusOK}
+
+			if err := uc.NewWebhookUC(repo, sender).Dispatch(context.Background()); err != nil {
+				t.Fatalf("WebhookUC.Dispatch() error = %v", err)
+			}
+
+			if len(sender.headers) != 1 {
+				t.Fatalf("WebhookUC.Dispatch() posted %d requests, want 1", len(sender.headers))
+			}
+
+			header := sender.headers[0]
+			timestamp := header.Get("X-Lifery-Timestamp")
+			if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+				t.Fatalf("X-Lifery-Timestamp = %q, want unix seconds", timestamp)
+			}
+
+			signature := header.Get("X-Lifery-Signature")
+			if !signatureFormat.MatchString(signature) {
+				t.Fatalf("X-Lifery-Signature = %q, want sha256=<hex>", signature)
+			}
+
+			mac := hmac.New(sha256.New, []byte(tt.secret))
+			mac.Write([]byte(timestamp + "."))
+			mac.Write(sender.bodies[0])
+			if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != want {
+				t.Errorf("X-Lifery-Signature = %q, want %q", signature, want)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		base     time.Duration
+		max      time.Duration
+		want     time.Duration
+	}{
+		{
+			name:     "first attempt waits base",
+			attempts: 1,
+			base:     time.Minute,
+			max:      12 * time.Hour,
+			want:     time.Minute,
+		},
+		{
+			name:     "no attempts yet waits base",
+			attempts: 0,
+			base:     time.Minute,
+			max:      12 * time.Hour,
+			want:     time.Minute,
+		},
+		{
+			name:     "doubles after each attempt",
+			attempts: 4,
+			base:     time.Minute,
+			max:      12 * time.Hour,
+			want:     8 * time.Minute,
+		},
+		{
+			name:     "capped at max",
+			attempts: 11,
+			base:     time.Minute,
+			max:      12 * time.Hour,
+			want:     12 * time.Hour,
+		},
+		{
+			name:     "reaching max exactly",
+			attempts: 3,
+			base:     30 * time.Second,
+			max:      2 * time.Minute,
+			want:     2 * time.Minute,
+		},
+		{
+			name:     "many attempts do not overflow",
+			attempts: 1000,
+			base:     time.Minute,
+			max:      12 * time.Hour,
+			want:     12 * time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pkg.ExponentialBackoff(tt.attempts, tt.base, tt.max); got != tt.want {
+				t.Errorf("ExponentialBackoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_RecordAttempt(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	disabledBefore := now.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		webhook   model.Webhook
+		succeeded bool
+		want      model.Webhook
+	}{
+		{
+			name:      "success resets the failures",
+			webhook:   model.Webhook{Enabled: true, FailureCount: model.WebhookDisableAfter - 1},
+			succeeded: true,
+			want:      model.Webhook{Enabled: true},
+		},
+		{
+			name:    "failure is counted",
+			webhook: model.Webhook{Enabled: true, FailureCount: 3},
+			want:    model.Webhook{Enabled: true, FailureCount: 4},
+		},
+		{
+			name:    "stays enabled below the threshold",
+			webhook: model.Webhook{Enabled: true, FailureCount: model.WebhookDisableAfter - 2},
+			want:    model.Webhook{Enabled: true, FailureCount: model.WebhookDisableAfter - 1},
+		},
+		{
+			name:    "disabled at the threshold",
+			webhook: model.Webhook{Enabled: true, FailureCount: model.WebhookDisableAfter - 1},
+			want:    model.Webhook{DisabledAt: now, FailureCount: model.WebhookDisableAfter},
+		},
+		{
+			name:    "disabled webhook keeps when it was disabled",
+			webhook: model.Webhook{DisabledAt: disabledBefore, FailureCount: model.WebhookDisableAfter},
+			want:    model.Webhook{DisabledAt: disabledBefore, FailureCount: model.WebhookDisableAfter + 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.webhook
+			got.RecordAttempt(tt.succeeded, now)
+
+			if got.Enabled != tt.want.Enabled || got.FailureCount != tt.want.FailureCount || !got.DisabledAt.Equal(tt.want.DisabledAt) {
+				t.Errorf("Webhook.RecordAttempt() = enabled %v, failures %d, disabled at %v, want enabled %v, failures %d, disabled at %v",
+					got.Enabled, got.FailureCount, got.DisabledAt, tt.want.Enabled, tt.want.FailureCount, tt.want.DisabledAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package uc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+type BlockUC struct {
+	repo   interfaces.BlockRepository
+	userUC *UserUC
+}
+
+func NewBlockUC(repo interfaces.BlockRepository, userUC *UserUC) *BlockUC {
+	return &BlockUC{
+		repo:   repo,
+		userUC: userUC,
+	}
+}
+
+// Block blocks the user for the owner. Their connection is removed, they cannot send each other
+// connection requests and they are hidden from each other in searches, feeds and event listings.
+func (rc *BlockUC) Block(ctx context.Context, req *model.BlockCreateInput) (*model.Block, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	if req.UserID == ownerID {
+		return nil, pkg.NewError(nil, "cannot block self", http.StatusBadRequest)
+	}
+
+	// blocked user exist control
+	if _, err := rc.userUC.GetByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	block := model.Block{
+		UserID:    ownerID,
+		BlockedID: req.UserID,
+		CreatedAt: time.Now(),
+	}
+
+	return rc.repo.Block(ctx, &block)
+}
+
+// Unblock removes the block of the owner, the removed connection is not restored.
+func (rc *BlockUC) Unblock(ctx context.Context, blockedID string) error {
+	return rc.repo.Unblock(ctx, util.GetOwnerIDFromCtx(ctx), blockedID)
+}
+
+func (rc *BlockUC) ListBlocked(ctx context.Context, opts model.PaginationOpts) (*model.BlockList, error) {
+	return rc.repo.ListBlocked(ctx, util.GetOwnerIDFromCtx(ctx), opts)
+}
+
+// Mute hides the events of the user from the feed of the owner, nothing else changes between them.
+func (rc *BlockUC) Mute(ctx context.Context, req *model.MuteCreateInput) (*model.Mute, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	if req.UserID == ownerID {
+		return nil, pkg.NewError(nil, "cannot mute self", http.StatusBadRequest)
+	}
+
+	// muted user exist control
+	if _, err := rc.userUC.GetByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	mute := model.Mute{
+		UserID:    ownerID,
+		MutedID:   req.UserID,
+		CreatedAt: time.Now(),
+	}
+
+	return rc.repo.Mute(ctx, &mute)
+}
+
+func (rc *BlockUC) Unmute(ctx context.Context, mutedID string) error {
+	return rc.repo.Unmute(ctx, util.GetOwnerIDFromCtx(ctx), mutedID)
+}
+
+func (rc *BlockUC) ListMuted(ctx context.Context, opts model.PaginationOpts) (*model.MuteList, error) {
+	return rc.repo.ListMuted(ctx, util.GetOwnerIDFromCtx(ctx), opts)
+}
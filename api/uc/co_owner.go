@@ -0,0 +1,319 @@
+package uc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+type CoOwnerUC struct {
+	repo       interfaces.CoOwnerRepository
+	eventUC    *EventUC
+	eraUC      *EraUC
+	connectsUC *ConnectsUC
+}
+
+func NewCoOwnerUC(repo interfaces.CoOwnerRepository, eventUC *EventUC, eraUC *EraUC, connectsUC *ConnectsUC) *CoOwnerUC {
+	return &CoOwnerUC{
+		repo:       repo,
+		eventUC:    eventUC,
+		eraUC:      eraUC,
+		connectsUC: connectsUC,
+	}
+}
+
+// coOwned is the event or era co-owners are invited to
+type coOwned struct {
+	kind    string
+	eventID string
+	eraID   string
+	name    string
+	ownerID string
+}
+
+func (c coOwned) notificationPayload(actor model.TokenOwner) model.NotificationPayload {
+	targetID := c.eventID
+	if c.eraID != "" {
+		targetID = c.eraID
+	}
+
+	return model.NotificationPayload{
+		ActorID:    actor.ID,
+		ActorName:  actor.Username,
+		TargetType: model.NotificationTarget(c.kind),
+		TargetID:   targetID,
+		TargetName: c.name,
+	}
+}
+
+// InviteToEvent invites a connection of the owner to co-own the event, allowed to its owner and co-owners.
+func (rc *CoOwnerUC) InviteToEvent(ctx context.Context, eventID string, req *model.CoOwnerCreateInput) (*model.CoOwner, error) {
+	event, err := rc.eventUC.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Draft {
+		return nil, pkg.NewError(nil, "you cannot invite co-owners to drafts", http.StatusBadRequest)
+	}
+
+	if event.Locked(time.Now()) {
+		return nil, pkg.NewError(nil, "you cannot invite co-owners to time capsules before they open", http.StatusBadRequest)
+	}
+
+	return rc.invite(ctx, coOwned{kind: "event", eventID: event.ID, name: event.Name, ownerID: event.UserID}, req)
+}
+
+// InviteToEra invites a connection of the owner to co-own the era, allowed to its owner and co-owners.
+func (rc *CoOwnerUC) InviteToEra(ctx context.Context, eraID string, req *model.CoOwnerCreateInput) (*model.CoOwner, error) {
+	era, err := rc.eraUC.GetByID(ctx, eraID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.invite(ctx, coOwned{kind: "era", eraID: era.ID, name: era.Name, ownerID: era.UserID}, req)
+}
+
+func (rc *CoOwnerUC) invite(ctx context.Context, target coOwned, req *model.CoOwnerCreateInput) (*model.CoOwner, error) {
+	owner := util.GetOwnerFromCtx(ctx)
+
+	isOwner, err := rc.isOwner(ctx, target, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOwner {
+		return nil, pkg.NewError(nil, "only owners can invite co-owners", http.StatusForbidden)
+	}
+
+	if req.UserID == target.ownerID {
+		return nil, pkg.NewError(nil, "user already owns the "+target.kind, http.StatusBadRequest)
+	}
+
+	isConnected, err := rc.connectsUC.IsConnected(ctx, owner.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isConnected {
+		return nil, pkg.NewError(nil, "you can invite only your connections", http.StatusBadRequest)
+	}
+
+	now := time.Now()
+
+	coOwner := model.CoOwner{
+		EventID:     target.eventID,
+		EraID:       target.eraID,
+		UserID:      req.UserID,
+		InvitedByID: owner.ID,
+		Status:      model.RequestStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// the invited user is notified through the outbox
+	notification, err := notificationEvent(model.DomainEventCoOwnerInvited, model.NotificationCreateInput{
+		UserID:  req.UserID,
+		Type:    model.NotificationTypeCoOwnerRequest,
+		Payload: target.notificationPayload(owner),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.repo.Create(ctx, &coOwner, notification)
+}
+
+// Update approves or rejects an invitation of the owner, rejected invitations are removed.
+func (rc *CoOwnerUC) Update(ctx context.Context, id string, req *model.CoOwnerUpdateInput) error {
+	exist, err := rc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	owner := util.GetOwnerFromCtx(ctx)
+
+	if exist.UserID != owner.ID {
+		return pkg.NewError(nil, "you can update only your invitations", http.StatusForbidden)
+	}
+
+	if exist.Status != model.RequestStatusPending {
+		return pkg.NewError(nil, "invitation is already answered", http.StatusBadRequest)
+	}
+
+	switch req.Status {
+	case model.RequestStatusApproved:
+	case model.RequestStatusRejected:
+		return rc.repo.Delete(ctx, id)
+	default:
+		return pkg.NewError(nil, "status must be approved or rejected", http.StatusBadRequest)
+	}
+
+	target, err := rc.target(ctx, exist.EventID, exist.EraID)
+	if err != nil {
+		return err
+	}
+
+	// the inviter is notified through the outbox
+	notification, err := notificationEvent(model.DomainEventCoOwnerApproved, model.NotificationCreateInput{
+		UserID:  exist.InvitedByID,
+		Type:    model.NotificationTypeCoOwnerApproved,
+		Payload: target.notificationPayload(owner),
+	})
+	if err != nil {
+		return err
+	}
+
+	return rc.repo.UpdateStatus(ctx, id, req.Status, notification)
+}
+
+// RemoveFromEvent removes a co-owner from the event. The owner can remove anyone, co-owners only themselves.
+func (rc *CoOwnerUC) RemoveFromEvent(ctx context.Context, eventID, userID string) error {
+	return rc.remove(ctx, eventID, "", userID)
+}
+
+// RemoveFromEra removes a co-owner from the era. The owner can remove anyone, co-owners only themselves.
+func (rc *CoOwnerUC) RemoveFromEra(ctx context.Context, eraID, userID string) error {
+	return rc.remove(ctx, "", eraID, userID)
+}
+
+func (rc *CoOwnerUC) remove(ctx context.Context, eventID, eraID, userID string) error {
+	exist, err := rc.repo.GetByTarget(ctx, eventID, eraID, userID)
+	if err != nil {
+		return err
+	}
+
+	owner := util.GetOwnerFromCtx(ctx)
+
+	if exist.UserID != owner.ID && owner.RoleID != model.AdminRole {
+		target, err := rc.target(ctx, eventID, eraID)
+		if err != nil {
+			return err
+		}
+
+		if target.ownerID != owner.ID {
+			return pkg.NewError(nil, "only the owner can remove co-owners", http.StatusForbidden)
+		}
+	}
+
+	return rc.repo.Delete(ctx, exist.ID)
+}
+
+// ListEvent returns the co-owners of an event visible to the owner.
+// Pending invitations are listed only to the owners of the event.
+func (rc *CoOwnerUC) ListEvent(ctx context.Context, eventID string, opts *model.CoOwnerFindOpts) (*model.CoOwnerList, error) {
+	event, err := rc.eventUC.GetVisible(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.EventID = model.Filter{
+		Value:    eventID,
+		IsSended: true,
+	}
+
+	return rc.list(ctx, coOwned{kind: "event", eventID: event.ID, ownerID: event.UserID}, opts)
+}
+
+// ListEra returns the co-owners of an era. Pending invitations are listed only to the owners of the era.
+func (rc *CoOwnerUC) ListEra(ctx context.Context, eraID string, opts *model.CoOwnerFindOpts) (*model.CoOwnerList, error) {
+	era, err := rc.eraUC.GetByID(ctx, eraID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.EraID = model.Filter{
+		Value:    eraID,
+		IsSended: true,
+	}
+
+	return rc.list(ctx, coOwned{kind: "era", eraID: era.ID, ownerID: era.UserID}, opts)
+}
+
+// ListOwn returns the invitations of the owner.
+func (rc *CoOwnerUC) ListOwn(ctx context.Context, opts *model.CoOwnerFindOpts) (*model.CoOwnerList, error) {
+	opts.UserID = model.Filter{
+		Value:    util.GetOwnerIDFromCtx(ctx),
+		IsSended: true,
+	}
+
+	return rc.repo.List(ctx, opts)
+}
+
+func (rc *CoOwnerUC) list(ctx context.Context, target coOwned, opts *model.CoOwnerFindOpts) (*model.CoOwnerList, error) {
+	isOwner, err := rc.isOwner(ctx, target, util.GetOwnerIDFromCtx(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOwner {
+		opts.Status = model.Filter{
+			Value:    fmt.Sprintf("%d", model.RequestStatusApproved),
+			IsSended: true,
+		}
+	}
+
+	return rc.repo.List(ctx, opts)
+}
+
+// isOwner reports whether the user owns the target or co-owns it through an approved invitation.
+func (rc *CoOwnerUC) isOwner(ctx context.Context, target coOwned, userID string) (bool, error) {
+	if userID == "" {
+		return false, nil
+	}
+
+	if target.ownerID == userID {
+		return true, nil
+	}
+
+	opts := model.CoOwnerFindOpts{
+		UserID: model.Filter{
+			Value:    userID,
+			IsSended: true,
+		},
+		Status: model.Filter{
+			Value:    fmt.Sprintf("%d", model.RequestStatusApproved),
+			IsSended: true,
+		},
+		PaginationOpts: model.PaginationOpts{
+			Limit: 1,
+		},
+	}
+
+	if target.eventID != "" {
+		opts.EventID = model.Filter{Value: target.eventID, IsSended: true}
+	} else {
+		opts.EraID = model.Filter{Value: target.eraID, IsSended: true}
+	}
+
+	coOwners, err := rc.repo.List(ctx, &opts)
+	if err != nil {
+		return false, err
+	}
+
+	return coOwners.Total > 0, nil
+}
+
+func (rc *CoOwnerUC) target(ctx context.Context, eventID, eraID string) (coOwned, error) {
+	if eventID != "" {
+		event, err := rc.eventUC.GetByID(ctx, eventID)
+		if err != nil {
+			return coOwned{}, err
+		}
+
+		return coOwned{kind: "event", eventID: event.ID, name: event.Name, ownerID: event.UserID}, nil
+	}
+
+	era, err := rc.eraUC.GetByID(ctx, eraID)
+	if err != nil {
+		return coOwned{}, err
+	}
+
+	return coOwned{kind: "era", eraID: era.ID, name: era.Name, ownerID: era.UserID}, nil
+}
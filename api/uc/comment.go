@@ -0,0 +1,141 @@
+package uc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+type CommentUC struct {
+	repo    interfaces.CommentRepository
+	eventUC *EventUC
+}
+
+func NewCommentUC(repo interfaces.CommentRepository, eventUC *EventUC) *CommentUC {
+	return &CommentUC{
+		repo:    repo,
+		eventUC: eventUC,
+	}
+}
+
+// Create comments on the event or replies to one of its comments, the event has to be visible to the owner.
+func (rc *CommentUC) Create(ctx context.Context, eventID string, req *model.CommentCreateInput) (*model.Comment, error) {
+	owner := util.GetOwnerFromCtx(ctx)
+
+	event, err := rc.eventUC.GetVisible(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != "" {
+		parent, err := rc.repo.GetByID(ctx, req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.EventID != event.ID {
+			return nil, pkg.NewError(nil, "parent comment belongs to another event", http.StatusBadRequest)
+		}
+	}
+
+	now := time.Now()
+
+	comment := model.Comment{
+		Body:      req.Body,
+		EventID:   event.ID,
+		ParentID:  req.ParentID,
+		UserID:    owner.ID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// the event owner is notified through the outbox
+	var events []*model.DomainEvent
+	if event.UserID != owner.ID {
+		notification, err := notificationEvent(model.DomainEventCommentCreated, model.NotificationCreateInput{
+			UserID: event.UserID,
+			Type:   model.NotificationTypeEventComment,
+			Payload: model.NotificationPayload{
+				ActorID:    owner.ID,
+				ActorName:  owner.Username,
+				TargetType: model.NotificationTargetEvent,
+				TargetID:   event.ID,
+				TargetName: event.Name,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, notification)
+	}
+
+	return rc.repo.Create(ctx, &comment, events...)
+}
+
+// Update edits the body of the comment, only its author can edit it.
+func (rc *CommentUC) Update(ctx context.Context, id string, req *model.CommentUpdateInput) (*model.Comment, error) {
+	exist, err := rc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist.UserID != util.GetOwnerIDFromCtx(ctx) {
+		return nil, pkg.NewError(nil, "you can update only your comments", http.StatusForbidden)
+	}
+
+	exist.Body = req.Body
+	exist.UpdatedAt = time.Now()
+
+	return rc.repo.Update(ctx, id, exist)
+}
+
+// Delete removes the comment and its replies. Authors can delete their comments,
+// event owners can moderate every comment on their events.
+func (rc *CommentUC) Delete(ctx context.Context, id string) error {
+	exist, err := rc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	owner := util.GetOwnerFromCtx(ctx)
+
+	if exist.UserID != owner.ID && owner.RoleID != model.AdminRole {
+		event, err := rc.eventUC.GetByID(ctx, exist.EventID)
+		if err != nil {
+			return err
+		}
+
+		if event.UserID != owner.ID {
+			return pkg.NewError(nil, "you can delete only your comments or comments on your events", http.StatusForbidden)
+		}
+	}
+
+	return rc.repo.Delete(ctx, id)
+}
+
+// List returns the comments of the event or the replies of a comment, the event has to be visible to the owner.
+func (rc *CommentUC) List(ctx context.Context, eventID string, opts *model.CommentFindOpts) (*model.CommentList, error) {
+	if _, err := rc.eventUC.GetVisible(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	opts.EventID = model.Filter{
+		Value:    eventID,
+		IsSended: true,
+	}
+
+	if opts.ParentID.IsSended {
+		opts.ParentID = model.Filter{
+			Value:    opts.ParentID.Value,
+			IsSended: true,
+		}
+	}
+
+	return rc.repo.List(ctx, opts)
+}
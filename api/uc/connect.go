@@ -2,8 +2,9 @@ package uc
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 	"github.com/fleimkeipa/lifery/pkg"
@@ -14,13 +15,15 @@ import (
 type ConnectsUC struct {
 	userUC         *UserUC
 	connectRepo    interfaces.ConnectInterfaces
+	blockRepo      interfaces.BlockRepository
 	notificationUC *NotificationUC
 }
 
-func NewConnectsUC(userUC *UserUC, connectRepo interfaces.ConnectInterfaces, notificationUC *NotificationUC) *ConnectsUC {
+func NewConnectsUC(userUC *UserUC, connectRepo interfaces.ConnectInterfaces, blockRepo interfaces.BlockRepository, notificationUC *NotificationUC) *ConnectsUC {
 	return &ConnectsUC{
 		userUC:         userUC,
 		connectRepo:    connectRepo,
+		blockRepo:      blockRepo,
 		notificationUC: notificationUC,
 	}
 }
@@ -28,9 +31,11 @@ func NewConnectsUC(userUC *UserUC, connectRepo interfaces.ConnectInterfaces, not
 func (rc *ConnectsUC) Create(ctx context.Context, req model.ConnectCreateInput) (*model.Connect, error) {
 	ownerID := util.GetOwnerIDFromCtx(ctx)
 	connect := model.Connect{
-		Status:   model.RequestStatusPending,
-		UserID:   ownerID,
-		FriendID: req.FriendID,
+		Status:    model.RequestStatusPending,
+		UserID:    ownerID,
+		FriendID:  req.FriendID,
+		Message:   req.Message,
+		CreatedAt: time.Now(),
 	}
 
 	if ownerID == req.FriendID {
@@ -38,7 +43,7 @@ func (rc *ConnectsUC) Create(ctx context.Context, req model.ConnectCreateInput)
 	}
 
 	// sender exist control
-	sender, err := rc.userUC.GetByID(ctx, ownerID)
+	_, err := rc.userUC.GetByID(ctx, ownerID)
 	if err != nil {
 		return nil, err
 	}
@@ -49,57 +54,42 @@ func (rc *ConnectsUC) Create(ctx context.Context, req model.ConnectCreateInput)
 		return nil, err
 	}
 
-	connectsRequests, err := rc.ConnectsRequests(ctx, &model.ConnectFindOpts{
-		UserID: model.Filter{
-			Value:    ownerID,
-			IsSended: true,
-		},
-		Status: model.Filter{
-			Value:    fmt.Sprintf("%d,%d", model.RequestStatusPending, model.RequestStatusApproved),
-			IsSended: true,
-		},
-	})
+	isBlocked, err := rc.IsBlocked(ctx, ownerID, req.FriendID)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range connectsRequests.Connects {
-		if v.UserID == req.FriendID && v.FriendID == ownerID {
-			return nil, pkg.NewError(nil, "already connected", http.StatusBadRequest)
-		}
-
-		if v.UserID == ownerID && v.FriendID == req.FriendID {
-			return nil, pkg.NewError(nil, "already connected", http.StatusBadRequest)
-		}
+	if isBlocked {
+		return nil, pkg.NewError(nil, "you cannot connect to this user", http.StatusForbidden)
 	}
 
-	createdConnect, err := rc.connectRepo.Create(ctx, &connect)
+	exists, err := rc.connectRepo.Exists(ctx, ownerID, req.FriendID, model.RequestStatusPending, model.RequestStatusApproved)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create notification for the receiver
-	_, err = rc.notificationUC.Create(ctx, model.NotificationCreateInput{
-		UserID:  req.FriendID,
-		Type:    "connect_request",
-		Message: fmt.Sprintf("%s sent you a connection request", sender.Username),
-	})
+	if exists {
+		return nil, pkg.NewError(nil, "already connected", http.StatusConflict)
+	}
+
+	// the receiver is notified through the outbox, the id of the connect is set when it is stored
+	event, err := connectEvent(model.DomainEventConnectRequested, "", ownerID, req.FriendID, ownerID)
 	if err != nil {
-		// Log the error but don't fail the request
-		fmt.Printf("Failed to create notification: %v\n", err)
+		return nil, err
 	}
 
-	return createdConnect, nil
+	// the pair index rejects a concurrent request in either direction as a conflict too
+	return rc.connectRepo.Create(ctx, &connect, event)
 }
 
+// Update moves a pending connect request on. The receiver approves or rejects it, the sender cancels it.
+// Rejected and cancelled requests are deleted, the other side gets notified of every transition through the outbox.
 func (rc *ConnectsUC) Update(ctx context.Context, id string, req model.ConnectUpdateInput) error {
 	existConnect, err := rc.connectRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	existConnect.Status = req.Status
-
 	if req.Status == 0 {
 		return pkg.NewError(nil, "status is required", http.StatusBadRequest)
 	}
@@ -108,44 +98,58 @@ func (rc *ConnectsUC) Update(ctx context.Context, id string, req model.ConnectUp
 		return pkg.NewError(nil, "status is pending already", http.StatusBadRequest)
 	}
 
-	if req.Status != model.RequestStatusApproved && req.Status != model.RequestStatusRejected {
-		return pkg.NewError(nil, "invalid status", http.StatusBadRequest)
+	if existConnect.Status != model.RequestStatusPending {
+		return pkg.NewError(nil, "only pending connects can be updated", http.StatusBadRequest)
 	}
 
-	// users can only update their own connects
-	if !rc.isOwner(ctx, existConnect.FriendID) {
-		return pkg.NewError(nil, "you can update only your connects", http.StatusBadRequest)
-	}
+	owner := util.GetOwnerFromCtx(ctx)
 
-	if req.Status == model.RequestStatusRejected {
-		return rc.Delete(ctx, id)
+	switch req.Status {
+	case model.RequestStatusApproved, model.RequestStatusRejected:
+		// only the receiver answers the request
+		if !rc.isOwner(ctx, existConnect.FriendID) {
+			return pkg.NewError(nil, "you can update only your connects", http.StatusBadRequest)
+		}
+	case model.RequestStatusCancelled:
+		if !rc.isOwner(ctx, existConnect.UserID) {
+			return pkg.NewError(nil, "you can cancel only your connect requests", http.StatusBadRequest)
+		}
+	default:
+		return pkg.NewError(nil, "invalid status", http.StatusBadRequest)
 	}
 
-	updatedConnect, err := rc.connectRepo.Update(ctx, id, existConnect)
+	event, err := connectEvent(connectStatusEvents[req.Status], id, existConnect.UserID, existConnect.FriendID, owner.ID)
 	if err != nil {
 		return err
 	}
 
-	// Get the sender's username
-	sender, err := rc.userUC.GetByID(ctx, updatedConnect.UserID)
-	if err != nil {
-		// Log the error but don't fail the request
-		fmt.Printf("Failed to get sender: %v\n", err)
-		return nil
+	if req.Status == model.RequestStatusRejected || req.Status == model.RequestStatusCancelled {
+		return rc.connectRepo.Delete(ctx, id, event)
 	}
 
-	// Create notification for the sender
-	_, err = rc.notificationUC.Create(ctx, model.NotificationCreateInput{
-		UserID:  updatedConnect.UserID,
-		Type:    "connect_response",
-		Message: fmt.Sprintf("Your connection request to %s was accepted", sender.Username),
-	})
-	if err != nil {
-		// Log the error but don't fail the request
-		fmt.Printf("Failed to create notification: %v\n", err)
-	}
+	existConnect.Status = req.Status
+	existConnect.ApprovedAt = time.Now()
 
-	return nil
+	_, err = rc.connectRepo.Update(ctx, id, existConnect, event)
+
+	return err
+}
+
+// ExpireRequests deletes the connect requests pending longer than ttl, their senders are notified through the outbox.
+func (rc *ConnectsUC) ExpireRequests(ctx context.Context, ttl time.Duration) error {
+	_, err := rc.connectRepo.ExpirePending(ctx, time.Now().Add(-ttl))
+	return err
+}
+
+// RegisterOutboxHandlers makes the outbox deliver the notifications of connect events.
+func (rc *ConnectsUC) RegisterOutboxHandlers(outboxUC *OutboxUC) {
+	outboxUC.Register("connect_notification", rc.notify,
+		model.DomainEventConnectRequested,
+		model.DomainEventConnectApproved,
+		model.DomainEventConnectRejected,
+		model.DomainEventConnectCancelled,
+		model.DomainEventConnectExpired,
+	)
 }
 
 func (rc *ConnectsUC) ConnectsRequests(ctx context.Context, opts *model.ConnectFindOpts) (*model.ConnectList, error) {
@@ -173,36 +177,86 @@ func (rc *ConnectsUC) Delete(ctx context.Context, id string) error {
 	return rc.connectRepo.Delete(ctx, id)
 }
 
+// IsConnected reports whether the users have an approved connect in either direction.
 func (rc *ConnectsUC) IsConnected(ctx context.Context, userID, friendID string) (bool, error) {
-	connectsRequests, err := rc.ConnectsRequests(ctx, &model.ConnectFindOpts{
-		UserID: model.Filter{
-			Value:    userID,
-			IsSended: true,
-		},
-		Status: model.Filter{
-			Value:    fmt.Sprintf("%d", model.RequestStatusApproved),
-			IsSended: true,
-		},
+	return rc.connectRepo.Exists(ctx, userID, friendID, model.RequestStatusApproved)
+}
+
+// ConnectionIDs returns the ids of the users connected to the user.
+func (rc *ConnectsUC) ConnectionIDs(ctx context.Context, userID string) ([]string, error) {
+	return rc.connectRepo.ConnectionIDs(ctx, userID)
+}
+
+// Suggestions returns the users the owner may know, best matches first.
+func (rc *ConnectsUC) Suggestions(ctx context.Context, opts model.PaginationOpts) (*model.SuggestionList, error) {
+	return rc.connectRepo.Suggestions(ctx, util.GetOwnerIDFromCtx(ctx), opts)
+}
+
+// IsBlocked reports whether one of the users blocked the other.
+func (rc *ConnectsUC) IsBlocked(ctx context.Context, userID, otherID string) (bool, error) {
+	return rc.blockRepo.IsBlocked(ctx, userID, otherID)
+}
+
+// connectStatusEvents are the outbox events of the status changes of a connect request
+var connectStatusEvents = map[model.RequestStatus]model.DomainEventType{
+	model.RequestStatusApproved:  model.DomainEventConnectApproved,
+	model.RequestStatusRejected:  model.DomainEventConnectRejected,
+	model.RequestStatusCancelled: model.DomainEventConnectCancelled,
+}
+
+// connectNotificationTypes are the notifications of connect events, they go to the side that did not make the change
+var connectNotificationTypes = map[model.DomainEventType]model.NotificationType{
+	model.DomainEventConnectRequested: model.NotificationTypeConnectRequest,
+	model.DomainEventConnectApproved:  model.NotificationTypeConnectApproved,
+	model.DomainEventConnectRejected:  model.NotificationTypeConnectRejected,
+	model.DomainEventConnectCancelled: model.NotificationTypeConnectCancelled,
+	model.DomainEventConnectExpired:   model.NotificationTypeConnectExpired,
+}
+
+// connectEvent returns the outbox event of a change of the connect between sender and receiver made by the actor
+func connectEvent(eventType model.DomainEventType, connectID, senderID, receiverID, actorID string) (*model.DomainEvent, error) {
+	event, err := model.NewDomainEvent(eventType, connectID, model.ConnectEventPayload{
+		SenderID:   senderID,
+		ReceiverID: receiverID,
+		ActorID:    actorID,
 	})
 	if err != nil {
-		return false, err
+		return nil, pkg.NewError(err, "failed to create connect event", http.StatusInternalServerError)
 	}
 
-	if len(connectsRequests.Connects) == 0 {
-		return false, nil
+	return event, nil
+}
+
+// notify tells the other side about a change of the connect made by the actor, it is an outbox handler
+func (rc *ConnectsUC) notify(ctx context.Context, event *model.DomainEvent) error {
+	var payload model.ConnectEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return pkg.NewError(err, "invalid connect event payload", http.StatusInternalServerError)
 	}
 
-	for _, v := range connectsRequests.Connects {
-		if v.UserID == friendID && v.FriendID == userID {
-			return true, nil
-		}
+	userID := payload.ReceiverID
+	if payload.ActorID == payload.ReceiverID {
+		userID = payload.SenderID
+	}
 
-		if v.UserID == userID && v.FriendID == friendID {
-			return true, nil
-		}
+	actor, err := rc.userUC.GetByID(ctx, payload.ActorID)
+	if err != nil {
+		return err
 	}
 
-	return false, nil
+	_, err = rc.notificationUC.Create(ctx, model.NotificationCreateInput{
+		UserID: userID,
+		Type:   connectNotificationTypes[event.Type],
+		Payload: model.NotificationPayload{
+			ActorID:    actor.ID,
+			ActorName:  actor.Username,
+			TargetType: model.NotificationTargetConnect,
+			TargetID:   event.AggregateID,
+		},
+		SourceID: event.ID,
+	})
+
+	return err
 }
 
 func (rc *ConnectsUC) isOwner(ctx context.Context, id string) bool {
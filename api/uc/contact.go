@@ -0,0 +1,50 @@
+package uc
+
+import (
+	"context"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+type ContactUC struct {
+	repo interfaces.ContactRepository
+}
+
+func NewContactUC(repo interfaces.ContactRepository) *ContactUC {
+	return &ContactUC{
+		repo: repo,
+	}
+}
+
+// Import adds the contacts to the owners address book and returns the number of stored contacts.
+// The contacts are only used to suggest connections and never shown to other users.
+func (rc *ContactUC) Import(ctx context.Context, req *model.ContactImportInput) (int, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+	now := time.Now()
+
+	contacts := make([]model.Contact, 0, len(req.Contacts))
+	for _, v := range req.Contacts {
+		contacts = append(contacts, model.Contact{
+			CreatedAt: now,
+			UserID:    ownerID,
+			Name:      v.Name,
+			Email:     v.Email,
+		})
+	}
+
+	return rc.repo.Import(ctx, contacts)
+}
+
+func (rc *ContactUC) Delete(ctx context.Context, contactID string) error {
+	return rc.repo.Delete(ctx, util.GetOwnerIDFromCtx(ctx), contactID)
+}
+
+func (rc *ContactUC) List(ctx context.Context, pagination model.PaginationOpts) (*model.ContactList, error) {
+	return rc.repo.List(ctx, &model.ContactFindOpts{
+		UserID:         util.GetOwnerIDFromCtx(ctx),
+		PaginationOpts: pagination,
+	})
+}
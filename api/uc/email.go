@@ -1,15 +1,107 @@
 package uc
 
-import "github.com/fleimkeipa/lifery/repositories/interfaces"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+)
+
+const (
+	// emailBatchSize is the number of due emails claimed at once
+	emailBatchSize = 50
+	// emailLease is how long claimed emails are skipped by other instances, longer than a batch takes
+	emailLease = 10 * time.Minute
+	// emailMaxAttempts is after how many failed attempts an email is given up
+	emailMaxAttempts = 8
+	// emailBaseBackoff doubles after each failed attempt up to emailMaxBackoff
+	emailBaseBackoff = time.Minute
+	emailMaxBackoff  = 2 * time.Hour
+)
+
+// EmailUC renders emails in the language of their recipient and queues them, the queue is sent through the transport.
 type EmailUC struct {
-	emailRepo interfaces.EmailInterfaces
+	queue     interfaces.EmailQueueRepository
+	transport interfaces.EmailTransport
+	templates emailTemplates
+}
+
+// NewEmailUC parses the email templates, their links point to the ui at frontendURL.
+func NewEmailUC(queue interfaces.EmailQueueRepository, transport interfaces.EmailTransport, frontendURL string) *EmailUC {
+	return &EmailUC{
+		queue:     queue,
+		transport: transport,
+		templates: newEmailTemplates(frontendURL),
+	}
+}
+
+func (rc *EmailUC) SendPasswordResetEmail(ctx context.Context, user *model.User, resetToken string) error {
+	return rc.enqueue(ctx, model.EmailKindPasswordReset, user, model.PasswordResetEmail{
+		Username:   user.Username,
+		ResetToken: resetToken,
+	})
+}
+
+// SendDigestEmail queues the digest of the memories of the user.
+func (rc *EmailUC) SendDigestEmail(ctx context.Context, user *model.User, digest *model.Digest) error {
+	return rc.enqueue(ctx, model.EmailKindMemoryDigest, user, digest)
 }
 
-func NewEmailUC(emailRepo interfaces.EmailInterfaces) *EmailUC {
-	return &EmailUC{emailRepo: emailRepo}
+// SendNotificationEmail queues an email of one notification or the daily digest of notifications.
+func (rc *EmailUC) SendNotificationEmail(ctx context.Context, user *model.User, email *model.NotificationEmail) error {
+	return rc.enqueue(ctx, model.EmailKindNotification, user, email)
 }
 
-func (uc *EmailUC) SendPasswordResetEmail(to, username, resetToken string) error {
-	return uc.emailRepo.SendPasswordResetEmail(to, username, resetToken)
+// Deliver sends the due emails of the queue through the transport, it is run periodically.
+// Failed emails are retried with exponential backoff and given up after emailMaxAttempts.
+func (rc *EmailUC) Deliver(ctx context.Context) error {
+	emails, err := rc.queue.ClaimDue(ctx, time.Now(), emailLease, emailBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range emails {
+		if err := rc.deliver(ctx, &emails[i]); err != nil {
+			errs = append(errs, fmt.Errorf("queued email %s: %w", emails[i].ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// enqueue renders the email of the kind in the language of the user and queues it to their address
+func (rc *EmailUC) enqueue(ctx context.Context, kind model.EmailKind, user *model.User, data any) error {
+	message, err := rc.templates.render(kind, user.LanguageOrDefault(), user.Email, data)
+	if err != nil {
+		return err
+	}
+
+	return rc.queue.Enqueue(ctx, message)
+}
+
+// deliver sends the email and removes it from the queue, a failed attempt is recorded on it.
+// The returned error is about recording the attempt.
+func (rc *EmailUC) deliver(ctx context.Context, email *model.QueuedEmail) error {
+	err := rc.transport.Send(ctx, &email.EmailMessage)
+	if err == nil {
+		return rc.queue.Delete(ctx, email.ID)
+	}
+
+	now := time.Now()
+
+	email.Attempts++
+	email.LastError = errorText(err)
+
+	if email.Attempts >= emailMaxAttempts {
+		email.FailedAt = now
+	} else {
+		email.NextAttemptAt = now.Add(pkg.ExponentialBackoff(email.Attempts, emailBaseBackoff, emailMaxBackoff))
+	}
+
+	return rc.queue.Reschedule(ctx, email)
 }
@@ -0,0 +1,99 @@
+package uc
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+)
+
+// emailTemplateFS holds a text and an html template of every email kind in every language.
+// Text templates define the subject and the body, html templates fill the blocks of the shared layout.
+//
+//go:embed templates/email
+var emailTemplateFS embed.FS
+
+var (
+	emailKinds     = []model.EmailKind{model.EmailKindPasswordReset, model.EmailKindMemoryDigest, model.EmailKindNotification}
+	emailLanguages = []model.Language{model.LanguageTurkish, model.LanguageEnglish}
+)
+
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// emailTemplates are the parsed templates by kind and language, links in them point to the ui at frontendURL
+type emailTemplates map[model.EmailKind]map[model.Language]emailTemplate
+
+// newEmailTemplates parses every template, a broken template stops the api at startup
+func newEmailTemplates(frontendURL string) emailTemplates {
+	funcs := map[string]any{
+		// url returns the link of the ui path, query is pairs of keys and values
+		"url": func(path string, query ...string) string {
+			values := url.Values{}
+			for i := 0; i+1 < len(query); i += 2 {
+				values.Set(query[i], query[i+1])
+			}
+
+			link := strings.TrimSuffix(frontendURL, "/") + path
+			if len(values) > 0 {
+				link += "?" + values.Encode()
+			}
+
+			return link
+		},
+	}
+
+	templates := make(emailTemplates, len(emailKinds))
+	for _, kind := range emailKinds {
+		templates[kind] = make(map[model.Language]emailTemplate, len(emailLanguages))
+
+		for _, language := range emailLanguages {
+			path := fmt.Sprintf("templates/email/%s/%s", language, kind)
+
+			templates[kind][language] = emailTemplate{
+				text: texttemplate.Must(texttemplate.New(string(kind)).Funcs(funcs).ParseFS(emailTemplateFS, path+".txt")),
+				html: htmltemplate.Must(htmltemplate.New(string(kind)).Funcs(funcs).ParseFS(emailTemplateFS, "templates/email/layout.html", path+".html")),
+			}
+		}
+	}
+
+	return templates
+}
+
+// render returns the email of the kind to the address in the language, DefaultLanguage if there are no templates in it
+func (t emailTemplates) render(kind model.EmailKind, language model.Language, to string, data any) (*model.EmailMessage, error) {
+	tmpl, ok := t[kind][language]
+	if !ok {
+		tmpl = t[kind][model.DefaultLanguage]
+	}
+
+	var subject, text, html strings.Builder
+
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, pkg.NewError(err, "failed to render email subject", http.StatusInternalServerError)
+	}
+
+	if err := tmpl.text.ExecuteTemplate(&text, "body", data); err != nil {
+		return nil, pkg.NewError(err, "failed to render email text", http.StatusInternalServerError)
+	}
+
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, pkg.NewError(err, "failed to render email html", http.StatusInternalServerError)
+	}
+
+	return &model.EmailMessage{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		Kind:    kind,
+	}, nil
+}
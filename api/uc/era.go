@@ -3,6 +3,7 @@ package uc
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/fleimkeipa/lifery/model"
@@ -67,13 +68,14 @@ func (rc *EraUC) Update(ctx context.Context, eraID string, req *model.EraUpdateI
 	}
 
 	era := model.Era{
-		TimeStart: req.TimeStart,
-		TimeEnd:   req.TimeEnd,
-		Name:      req.Name,
-		Color:     req.Color,
-		UserID:    exist.UserID,
-		CreatedAt: exist.CreatedAt,
-		UpdatedAt: util.Now(),
+		TimeStart:   req.TimeStart,
+		TimeEnd:     req.TimeEnd,
+		Name:        req.Name,
+		Color:       req.Color,
+		UserID:      exist.UserID,
+		CreatedAt:   exist.CreatedAt,
+		UpdatedAt:   util.Now(),
+		UpdatedByID: util.GetOwnerIDFromCtx(ctx),
 	}
 
 	updatedEra, err := rc.repo.Update(ctx, eraID, &era)
@@ -126,6 +128,14 @@ func (rc *EraUC) GetByID(ctx context.Context, id string) (*model.Era, error) {
 }
 
 func (rc *EraUC) list(ctx context.Context, opts *model.EraFindOpts) (*model.EraList, error) {
+	// co-owned eras show up on the timeline of every owner
+	if opts.UserID.IsSended && !strings.Contains(opts.UserID.Value, ",") {
+		opts.SharedWith = model.Filter{
+			Value:    opts.UserID.Value,
+			IsSended: true,
+		}
+	}
+
 	list, err := rc.repo.List(ctx, opts)
 	if err != nil {
 		return nil, err
@@ -2,25 +2,38 @@ package uc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fleimkeipa/lifery/model"
 	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/pkg/logger"
 	"github.com/fleimkeipa/lifery/repositories/interfaces"
 	"github.com/fleimkeipa/lifery/util"
 )
 
 type EventUC struct {
-	repo       interfaces.EventRepository
-	connectsUC *ConnectsUC
+	repo           interfaces.EventRepository
+	connectsUC     *ConnectsUC
+	tagUC          *TagUC
+	userUC         *UserUC
+	notificationUC *NotificationUC
+	cacheRepo      interfaces.CacheRepository
 }
 
-func NewEventUC(repo interfaces.EventRepository, connectsUC *ConnectsUC) *EventUC {
+func NewEventUC(repo interfaces.EventRepository, connectsUC *ConnectsUC, tagUC *TagUC, userUC *UserUC, notificationUC *NotificationUC, cacheRepo interfaces.CacheRepository) *EventUC {
 	return &EventUC{
-		repo:       repo,
-		connectsUC: connectsUC,
+		repo:           repo,
+		connectsUC:     connectsUC,
+		tagUC:          tagUC,
+		userUC:         userUC,
+		notificationUC: notificationUC,
+		cacheRepo:      cacheRepo,
 	}
 }
 
@@ -44,16 +57,60 @@ func (rc *EventUC) Create(ctx context.Context, req *model.EventCreateInput) (*mo
 		}
 	}
 
+	// a scheduled event stays a draft until it is published
+	if !req.PublishAt.IsZero() {
+		if !req.PublishAt.After(time.Now()) {
+			return nil, pkg.NewError(nil, "publish time must be in the future", http.StatusBadRequest)
+		}
+
+		if req.Name == "" {
+			return nil, pkg.NewError(nil, "scheduled drafts need a name", http.StatusBadRequest)
+		}
+
+		req.Draft = true
+	}
+
 	event := model.Event{
-		Date:        req.Date,
-		TimeStart:   req.TimeStart,
-		TimeEnd:     req.TimeEnd,
-		Name:        req.Name,
-		Description: req.Description,
-		Items:       req.Items,
-		UserID:      ownerID,
-		Visibility:  req.Visibility,
-		CreatedAt:   util.Now(),
+		Date:           req.Date,
+		TimeStart:      req.TimeStart,
+		TimeEnd:        req.TimeEnd,
+		Name:           req.Name,
+		Description:    req.Description,
+		Items:          req.Items,
+		UserID:         ownerID,
+		Visibility:     req.Visibility,
+		Draft:          req.Draft,
+		PublishAt:      req.PublishAt,
+		CreatedAt:      util.Now(),
+		Sealed:         req.Sealed,
+		SealedForOwner: req.SealedForOwner,
+		UnlockAt:       req.UnlockAt,
+	}
+
+	if err := checkCapsule(&event, true); err != nil {
+		return nil, err
+	}
+
+	if len(req.RecipientIDs) > 0 {
+		recipientIDs, err := rc.recipients(ctx, &event, req.RecipientIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		event.RecipientIDs = recipientIDs
+	}
+
+	if len(event.RecipientIDs) > 0 && event.Visibility == model.EventVisibilityJustMe {
+		return nil, pkg.NewError(nil, "just me events cannot have recipients", http.StatusBadRequest)
+	}
+
+	if len(req.Tags) > 0 {
+		tags, err := rc.tagUC.Resolve(ctx, req.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Tags = tags
 	}
 
 	newEvent, err := rc.repo.Create(ctx, &event)
@@ -61,111 +118,541 @@ func (rc *EventUC) Create(ctx context.Context, req *model.EventCreateInput) (*mo
 		return nil, err
 	}
 
+	invalidateStats(ctx, rc.cacheRepo, ownerID)
+
 	return newEvent, nil
 }
 
+// Update changes the sent fields of the event, allowed to its owner and co-owners. The editor is recorded on the event.
+// Drafts of other users are not found, and only drafts can be scheduled for publishing.
 func (rc *EventUC) Update(ctx context.Context, eventID string, req *model.EventUpdateInput) (*model.Event, error) {
 	// event exist control
-	exist, err := rc.GetByID(ctx, eventID)
+	exist, err := rc.Get(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
 
-	if !req.Date.IsZero() {
-		_, err := time.Parse(time.RFC3339, req.Date.Format(time.RFC3339))
-		if err != nil {
-			return nil, pkg.NewError(err, "failed to parse timeStart", http.StatusBadRequest)
+	if exist.Locked(time.Now()) {
+		return nil, pkg.NewError(nil, "time capsules cannot be changed until they open", http.StatusBadRequest)
+	}
+
+	event := model.Event{
+		Date:           exist.Date,
+		TimeStart:      exist.TimeStart,
+		TimeEnd:        exist.TimeEnd,
+		Name:           exist.Name,
+		Description:    exist.Description,
+		Items:          exist.Items,
+		UserID:         exist.UserID,
+		UpdatedByID:    util.GetOwnerIDFromCtx(ctx),
+		Visibility:     exist.Visibility,
+		Draft:          exist.Draft,
+		PublishAt:      exist.PublishAt,
+		CreatedAt:      exist.CreatedAt,
+		UpdatedAt:      util.Now(),
+		Sealed:         exist.Sealed,
+		SealedForOwner: exist.SealedForOwner,
+		UnlockAt:       exist.UnlockAt,
+		OpenedAt:       exist.OpenedAt,
+		RecipientIDs:   exist.RecipientIDs,
+	}
+
+	if req.Date.Set {
+		event.Date = req.Date.Time
+	}
+
+	if req.TimeStart.Set {
+		event.TimeStart = req.TimeStart.Time
+	}
+
+	if req.TimeEnd.Set {
+		event.TimeEnd = req.TimeEnd.Time
+	}
+
+	if req.Name != nil {
+		event.Name = *req.Name
+	}
+
+	if req.Description != nil {
+		event.Description = *req.Description
+	}
+
+	if req.Items != nil {
+		event.Items = req.Items
+	}
+
+	if req.Visibility != nil {
+		event.Visibility = *req.Visibility
+	}
+
+	if req.PublishAt.Set {
+		if !exist.Draft {
+			return nil, pkg.NewError(nil, "only drafts can be scheduled", http.StatusBadRequest)
 		}
+
+		event.PublishAt = req.PublishAt.Time
 	}
-	if !req.TimeStart.IsZero() && !req.TimeEnd.IsZero() {
-		_, err := time.Parse(time.RFC3339, req.TimeStart.Format(time.RFC3339))
+
+	if !event.Draft && event.Name == "" {
+		return nil, pkg.NewError(nil, "name is required", http.StatusBadRequest)
+	}
+
+	if req.PublishAt.Set && !event.PublishAt.IsZero() && !event.PublishAt.After(time.Now()) {
+		return nil, pkg.NewError(nil, "publish time must be in the future", http.StatusBadRequest)
+	}
+
+	if !event.PublishAt.IsZero() && event.Name == "" {
+		return nil, pkg.NewError(nil, "scheduled drafts need a name", http.StatusBadRequest)
+	}
+
+	// published events are not sealed afterwards, their content was already seen
+	if !exist.Draft && ((req.Sealed != nil && *req.Sealed && !exist.Sealed) || req.UnlockAt.Set) {
+		return nil, pkg.NewError(nil, "only drafts can be sealed", http.StatusBadRequest)
+	}
+
+	// unsealing turns the capsule into an event
+	if req.Sealed != nil && !*req.Sealed {
+		event.Sealed = false
+		event.SealedForOwner = false
+		event.UnlockAt = time.Time{}
+		event.RecipientIDs = nil
+	} else if req.Sealed != nil {
+		event.Sealed = true
+	}
+
+	if req.SealedForOwner != nil {
+		event.SealedForOwner = *req.SealedForOwner
+	}
+
+	if req.UnlockAt.Set {
+		event.UnlockAt = req.UnlockAt.Time
+	}
+
+	// a capsule sealed again opens again
+	resealed := req.UnlockAt.Set || event.Sealed != exist.Sealed
+	if resealed {
+		event.OpenedAt = time.Time{}
+	}
+
+	if err := checkCapsule(&event, resealed); err != nil {
+		return nil, err
+	}
+
+	if req.RecipientIDs != nil {
+		recipientIDs, err := rc.recipients(ctx, &event, req.RecipientIDs)
 		if err != nil {
-			return nil, pkg.NewError(err, "failed to parse timeStart", http.StatusBadRequest)
+			return nil, err
 		}
-		_, err = time.Parse(time.RFC3339, req.TimeEnd.Format(time.RFC3339))
+
+		event.RecipientIDs = recipientIDs
+	}
+
+	if len(event.RecipientIDs) > 0 && event.Visibility == model.EventVisibilityJustMe {
+		return nil, pkg.NewError(nil, "just me events cannot have recipients", http.StatusBadRequest)
+	}
+
+	// tags are kept as is if they are not sent
+	if req.Tags != nil {
+		tags, err := rc.tagUC.Resolve(ctx, req.Tags)
 		if err != nil {
-			return nil, pkg.NewError(err, "failed to parse timeEnd", http.StatusBadRequest)
+			return nil, err
 		}
-	}
 
-	event := model.Event{
-		Date:        req.Date,
-		TimeStart:   req.TimeStart,
-		TimeEnd:     req.TimeEnd,
-		Name:        req.Name,
-		Description: req.Description,
-		Items:       req.Items,
-		UserID:      exist.UserID,
-		Visibility:  req.Visibility,
-		CreatedAt:   exist.CreatedAt,
-		UpdatedAt:   util.Now(),
+		event.Tags = tags
 	}
 
-	updatedEvent, err := rc.repo.Update(ctx, eventID, &event)
+	updatedEvent, err := rc.repo.Update(ctx, eventID, &event, exist)
 	if err != nil {
 		return nil, err
 	}
 
+	invalidateStats(ctx, rc.cacheRepo, exist.UserID)
+
 	return updatedEvent, nil
 }
 
+// Delete deletes the event, allowed to its owner and co-owners.
 func (rc *EventUC) Delete(ctx context.Context, id string) error {
-	return rc.repo.Delete(ctx, id)
+	exist, err := rc.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := rc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	invalidateStats(ctx, rc.cacheRepo, exist.UserID)
+
+	return nil
 }
 
 func (rc *EventUC) List(ctx context.Context, opts *model.EventFindOpts) (*model.EventList, error) {
 	ownerID := util.GetOwnerIDFromCtx(ctx)
 
-	if ownerID == "" {
-		if opts.UserID.Value == "" {
-			return nil, pkg.NewError(nil, "user id is empty", http.StatusBadRequest)
-		}
-
+	if !opts.UserID.IsSended {
 		opts.UserID = model.Filter{
-			Value:    opts.UserID.Value,
-			IsSended: true,
-		}
-		opts.Visibility = model.Filter{
-			Value:    fmt.Sprintf("%d", model.EventVisibilityPublic),
+			Value:    ownerID,
 			IsSended: true,
 		}
+	}
 
-		return rc.list(ctx, opts)
+	if opts.UserID.Value == "" {
+		return nil, pkg.NewError(nil, "user id is empty", http.StatusBadRequest)
 	}
 
-	if !opts.UserID.IsSended {
-		opts.UserID = model.Filter{
-			Value:    ownerID,
-			IsSended: true,
+	opts.UserID = model.Filter{
+		Value:    opts.UserID.Value,
+		IsSended: true,
+	}
+
+	visibility, err := rc.visibilityFilter(ctx, opts.UserID.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// owners may filter their own events by any visibility
+	if visibility.IsSended {
+		opts.Visibility = visibility
+	}
+
+	opts.SharedWith, opts.SharedVisibility = rc.sharedFilter(ctx, opts.UserID.Value)
+
+	opts.DraftsOf = rc.draftsOf(ctx, opts.UserID.Value)
+	opts.Recipient = rc.recipientFilter(ctx, opts.UserID.Value)
+
+	return rc.list(ctx, opts)
+}
+
+func (rc *EventUC) GetByID(ctx context.Context, id string) (*model.Event, error) {
+	return rc.repo.GetByID(ctx, id)
+}
+
+// Get returns the event, drafts only to their owner and time capsules with recipients only to them and the owner.
+func (rc *EventUC) Get(ctx context.Context, id string) (*model.Event, error) {
+	event, err := rc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	if (event.Draft && event.UserID != ownerID) || !event.ReachesUser(ownerID) {
+		return nil, pkg.NewError(nil, "event not found: "+id, http.StatusNotFound)
+	}
+
+	return event, nil
+}
+
+// Publish makes the draft of the owner visible now, its connections are notified through the outbox.
+func (rc *EventUC) Publish(ctx context.Context, id string) (*model.Event, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	exist, err := rc.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist.UserID != ownerID {
+		return nil, pkg.NewError(nil, "you can publish only your events", http.StatusForbidden)
+	}
+
+	if !exist.Draft {
+		return nil, pkg.NewError(nil, "event is published already", http.StatusBadRequest)
+	}
+
+	if exist.Name == "" {
+		return nil, pkg.NewError(nil, "name is required", http.StatusBadRequest)
+	}
+
+	event, err := rc.repo.Publish(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateStats(ctx, rc.cacheRepo, ownerID)
+
+	return event, nil
+}
+
+// PublishDue publishes the drafts whose publishing time has come, it is run periodically.
+func (rc *EventUC) PublishDue(ctx context.Context) error {
+	events, err := rc.repo.PublishDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, v := range events {
+		invalidateStats(ctx, rc.cacheRepo, v.UserID)
+	}
+
+	return nil
+}
+
+// OpenCapsules opens the time capsules whose unlock time has come, it is run periodically.
+// Their owners and recipients are notified through the outbox.
+func (rc *EventUC) OpenCapsules(ctx context.Context) error {
+	_, err := rc.repo.OpenDue(ctx, time.Now())
+	return err
+}
+
+// RegisterOutboxHandlers makes the outbox notify the connections of the owner about published drafts,
+// and the owners and recipients of time capsules about their opening.
+func (rc *EventUC) RegisterOutboxHandlers(outboxUC *OutboxUC) {
+	outboxUC.Register("event_published_notification", rc.notifyPublished, model.DomainEventEventPublished)
+	outboxUC.Register("capsule_opened_notification", rc.notifyOpened, model.DomainEventEventUnlocked)
+}
+
+// notifyOpened tells the owner and the recipients that the time capsule opened, recipients see who it is from.
+func (rc *EventUC) notifyOpened(ctx context.Context, domainEvent *model.DomainEvent) error {
+	var event model.Event
+	if err := json.Unmarshal(domainEvent.Payload, &event); err != nil {
+		return pkg.NewError(err, "invalid event payload", http.StatusInternalServerError)
+	}
+
+	owner, err := rc.userUC.GetByID(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	payload := model.NotificationPayload{
+		TargetType: model.NotificationTargetEvent,
+		TargetID:   event.ID,
+		TargetName: event.Name,
+	}
+
+	inputs := []model.NotificationCreateInput{{
+		UserID:  owner.ID,
+		Type:    model.NotificationTypeCapsuleOpened,
+		Payload: payload,
+	}}
+
+	payload.ActorID = owner.ID
+	payload.ActorName = owner.Username
+
+	for _, v := range event.RecipientIDs {
+		inputs = append(inputs, model.NotificationCreateInput{
+			UserID:  v,
+			Type:    model.NotificationTypeCapsuleOpened,
+			Payload: payload,
+		})
+	}
+
+	return rc.notifyAll(ctx, domainEvent.ID, inputs)
+}
+
+// notifyPublished tells the connections of the owner about the published event unless it is just for the owner.
+func (rc *EventUC) notifyPublished(ctx context.Context, domainEvent *model.DomainEvent) error {
+	var event model.Event
+	if err := json.Unmarshal(domainEvent.Payload, &event); err != nil {
+		return pkg.NewError(err, "invalid event payload", http.StatusInternalServerError)
+	}
+
+	// sealed and addressed time capsules are announced when they open
+	if event.Visibility == model.EventVisibilityJustMe || event.Redacted || len(event.RecipientIDs) > 0 {
+		return nil
+	}
+
+	owner, err := rc.userUC.GetByID(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	connectionIDs, err := rc.connectsUC.ConnectionIDs(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	inputs := make([]model.NotificationCreateInput, 0, len(connectionIDs))
+	for _, v := range connectionIDs {
+		inputs = append(inputs, model.NotificationCreateInput{
+			UserID: v,
+			Type:   model.NotificationTypeEventPublished,
+			Payload: model.NotificationPayload{
+				ActorID:    owner.ID,
+				ActorName:  owner.Username,
+				TargetType: model.NotificationTargetEvent,
+				TargetID:   event.ID,
+				TargetName: event.Name,
+			},
+		})
+	}
+
+	return rc.notifyAll(ctx, domainEvent.ID, inputs)
+}
+
+// notifyAll creates the notifications of an outbox event. A failing one is logged and does not stop the others,
+// the error is returned to retry the event and the notifications created before are skipped by their source.
+func (rc *EventUC) notifyAll(ctx context.Context, sourceID string, inputs []model.NotificationCreateInput) error {
+	var lastErr error
+	for _, v := range inputs {
+		v.SourceID = sourceID
+
+		if _, err := rc.notificationUC.Create(ctx, v); err != nil {
+			logger.Log.Errorf("failed to create %s notification for user %s: %v", v.Type, v.UserID, err)
+			lastErr = err
 		}
+	}
 
-		return rc.list(ctx, opts)
+	return lastErr
+}
+
+// GetVisible returns the event if the owner is allowed to see it by its visibility.
+// Drafts are visible only to their owner.
+func (rc *EventUC) GetVisible(ctx context.Context, id string) (*model.Event, error) {
+	event, err := rc.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	isConnected, err := rc.connectsUC.IsConnected(ctx, ownerID, opts.UserID.Value)
+	visibility, err := rc.visibilityFilter(ctx, event.UserID)
 	if err != nil {
 		return nil, err
 	}
 
+	if !visibility.IsSended {
+		return event, nil
+	}
+
+	for _, v := range strings.Split(visibility.Value, ",") {
+		if v == strconv.Itoa(int(event.Visibility)) {
+			return event, nil
+		}
+	}
+
+	return nil, pkg.NewError(nil, "you cannot see this event", http.StatusForbidden)
+}
+
+// visibilityFilter returns the visibilities of the users events the owner is allowed to see.
+// Public events are visible to everyone, private ones to connections and all of them to the user itself,
+// in which case the returned filter is not sended. Users who blocked each other see none of them.
+func (rc *EventUC) visibilityFilter(ctx context.Context, userID string) (model.Filter, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	if ownerID != "" && ownerID == userID {
+		return model.Filter{}, nil
+	}
+
+	public := model.Filter{
+		Value:    fmt.Sprintf("%d", model.EventVisibilityPublic),
+		IsSended: true,
+	}
+
+	if ownerID == "" {
+		return public, nil
+	}
+
+	isBlocked, err := rc.connectsUC.IsBlocked(ctx, ownerID, userID)
+	if err != nil {
+		return model.Filter{}, err
+	}
+
+	if isBlocked {
+		return model.Filter{}, pkg.NewError(nil, "you cannot see this users events", http.StatusForbidden)
+	}
+
+	isConnected, err := rc.connectsUC.IsConnected(ctx, ownerID, userID)
+	if err != nil {
+		return model.Filter{}, err
+	}
+
 	if !isConnected {
-		opts.Visibility = model.Filter{
-			Value:    fmt.Sprintf("%d", model.EventVisibilityPublic),
+		return public, nil
+	}
+
+	return model.Filter{
+		Value:    fmt.Sprintf("%d,%d", model.EventVisibilityPublic, model.EventVisibilityPrivate),
+		IsSended: true,
+	}, nil
+}
+
+// sharedFilter returns the filters adding the events the user approved being tagged in to the users timeline.
+// Just me events are never shared, and others see only the public ones since they may not be connected to their owners.
+func (rc *EventUC) sharedFilter(ctx context.Context, userID string) (model.Filter, model.Filter) {
+	sharedWith := model.Filter{
+		Value:    userID,
+		IsSended: true,
+	}
+
+	if ownerID := util.GetOwnerIDFromCtx(ctx); ownerID != "" && ownerID == userID {
+		return sharedWith, model.Filter{
+			Value:    fmt.Sprintf("%d,%d", model.EventVisibilityPublic, model.EventVisibilityPrivate),
 			IsSended: true,
 		}
+	}
+
+	return sharedWith, model.Filter{
+		Value:    fmt.Sprintf("%d", model.EventVisibilityPublic),
+		IsSended: true,
+	}
+}
 
-		return rc.list(ctx, opts)
+// draftsOf returns the user whose drafts the owner may list, which is the owner itself
+func (rc *EventUC) draftsOf(ctx context.Context, userID string) string {
+	if ownerID := util.GetOwnerIDFromCtx(ctx); ownerID != "" && ownerID == userID {
+		return ownerID
 	}
 
-	opts.Visibility = model.Filter{
-		Value:    fmt.Sprintf("%d,%d", model.EventVisibilityPublic, model.EventVisibilityPrivate),
+	return ""
+}
+
+// recipientFilter returns the filter leaving out the time capsules addressed to others when the owner reads the events of the user
+func (rc *EventUC) recipientFilter(ctx context.Context, userID string) model.Filter {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+	if ownerID != "" && ownerID == userID {
+		return model.Filter{}
+	}
+
+	return model.Filter{
+		Value:    ownerID,
 		IsSended: true,
 	}
+}
 
-	return rc.list(ctx, opts)
+// recipients checks that the time capsule is addressed only to connections of its owner and drops repeated ones
+func (rc *EventUC) recipients(ctx context.Context, event *model.Event, recipientIDs []string) ([]string, error) {
+	if !event.Sealed {
+		return nil, pkg.NewError(nil, "only time capsules can have recipients", http.StatusBadRequest)
+	}
+
+	connectionIDs, err := rc.connectsUC.ConnectionIDs(ctx, event.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make([]string, 0, len(recipientIDs))
+	for _, v := range recipientIDs {
+		if !slices.Contains(connectionIDs, v) {
+			return nil, pkg.NewError(nil, "time capsules can be addressed only to your connections", http.StatusBadRequest)
+		}
+
+		if !slices.Contains(unique, v) {
+			unique = append(unique, v)
+		}
+	}
+
+	return unique, nil
 }
 
-func (rc *EventUC) GetByID(ctx context.Context, id string) (*model.Event, error) {
-	return rc.repo.GetByID(ctx, id)
+// checkCapsule validates the time capsule fields of the event, a new unlock time has to be in the future
+func checkCapsule(event *model.Event, newUnlock bool) error {
+	if !event.Sealed {
+		if event.SealedForOwner || !event.UnlockAt.IsZero() || len(event.RecipientIDs) > 0 {
+			return pkg.NewError(nil, "only time capsules can have an unlock time or recipients", http.StatusBadRequest)
+		}
+
+		return nil
+	}
+
+	if event.UnlockAt.IsZero() {
+		return pkg.NewError(nil, "time capsules need an unlock time", http.StatusBadRequest)
+	}
+
+	if newUnlock && !event.UnlockAt.After(time.Now()) {
+		return pkg.NewError(nil, "unlock time must be in the future", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 func (rc *EventUC) list(ctx context.Context, opts *model.EventFindOpts) (*model.EventList, error) {
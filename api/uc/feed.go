@@ -0,0 +1,114 @@
+package uc
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 100
+)
+
+type FeedUC struct {
+	eventRepo interfaces.EventRepository
+}
+
+func NewFeedUC(eventRepo interfaces.EventRepository) *FeedUC {
+	return &FeedUC{
+		eventRepo: eventRepo,
+	}
+}
+
+// Get returns a page of the public and private events of the owners connections
+// and the public events of the users the owner follows, newest first.
+func (rc *FeedUC) Get(ctx context.Context, cursor string, limit int) (*model.Feed, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+	if ownerID == "" {
+		return nil, pkg.NewError(nil, "User not authenticated", http.StatusUnauthorized)
+	}
+
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	limit = min(limit, maxFeedLimit)
+
+	opts := model.FeedFindOpts{
+		UserID: ownerID,
+		// one more than asked to know if there is a next page
+		Limit: limit + 1,
+	}
+
+	if cursor != "" {
+		after, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.After = after
+	}
+
+	events, err := rc.eventRepo.Feed(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := model.Feed{
+		Events: events,
+	}
+
+	if len(events) > limit {
+		feed.Events = events[:limit]
+
+		last := feed.Events[limit-1]
+		feed.NextCursor = encodeFeedCursor(&model.FeedCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		})
+	}
+
+	return &feed, nil
+}
+
+// encodeFeedCursor makes an opaque cursor of the created time and id of an event
+func encodeFeedCursor(cursor *model.FeedCursor) string {
+	value := cursor.CreatedAt.Format(time.RFC3339Nano) + "," + cursor.ID
+
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func decodeFeedCursor(cursor string) (*model.FeedCursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, pkg.NewError(err, "invalid cursor", http.StatusBadRequest)
+	}
+
+	createdAt, id, ok := strings.Cut(string(value), ",")
+	if !ok || id == "" {
+		return nil, pkg.NewError(nil, "invalid cursor", http.StatusBadRequest)
+	}
+
+	// the id is compared with the integer ids of events
+	if _, err := strconv.Atoi(id); err != nil {
+		return nil, pkg.NewError(err, "invalid cursor", http.StatusBadRequest)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, pkg.NewError(err, "invalid cursor", http.StatusBadRequest)
+	}
+
+	return &model.FeedCursor{
+		CreatedAt: t,
+		ID:        id,
+	}, nil
+}
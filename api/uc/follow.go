@@ -0,0 +1,120 @@
+package uc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+type FollowUC struct {
+	repo       interfaces.FollowRepository
+	userUC     *UserUC
+	connectsUC *ConnectsUC
+}
+
+func NewFollowUC(repo interfaces.FollowRepository, userUC *UserUC, connectsUC *ConnectsUC) *FollowUC {
+	return &FollowUC{
+		repo:       repo,
+		userUC:     userUC,
+		connectsUC: connectsUC,
+	}
+}
+
+// Create follows the user without an approval, the followed user gets notified.
+func (rc *FollowUC) Create(ctx context.Context, req *model.FollowCreateInput) (*model.Follow, error) {
+	owner := util.GetOwnerFromCtx(ctx)
+
+	if req.UserID == owner.ID {
+		return nil, pkg.NewError(nil, "cannot follow self", http.StatusBadRequest)
+	}
+
+	// followed user exist control
+	if _, err := rc.userUC.GetByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	isBlocked, err := rc.connectsUC.IsBlocked(ctx, owner.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isBlocked {
+		return nil, pkg.NewError(nil, "you cannot follow this user", http.StatusForbidden)
+	}
+
+	follow := model.Follow{
+		UserID:     owner.ID,
+		FollowedID: req.UserID,
+		CreatedAt:  time.Now(),
+	}
+
+	// the followed user is notified through the outbox
+	notification, err := notificationEvent(model.DomainEventFollowCreated, model.NotificationCreateInput{
+		UserID: req.UserID,
+		Type:   model.NotificationTypeNewFollower,
+		Payload: model.NotificationPayload{
+			ActorID:    owner.ID,
+			ActorName:  owner.Username,
+			TargetType: model.NotificationTargetUser,
+			TargetID:   owner.ID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.repo.Create(ctx, &follow, notification)
+}
+
+// Delete unfollows the user.
+func (rc *FollowUC) Delete(ctx context.Context, followedID string) error {
+	return rc.repo.Delete(ctx, util.GetOwnerIDFromCtx(ctx), followedID)
+}
+
+// Followers returns the followers of the user, the ones blocked with the owner are left out.
+func (rc *FollowUC) Followers(ctx context.Context, userID string, opts *model.FollowFindOpts) (*model.FollowList, error) {
+	opts.FollowedID = model.Filter{
+		Value:    userID,
+		IsSended: true,
+	}
+
+	return rc.list(ctx, userID, opts)
+}
+
+// Following returns the users the user follows, the ones blocked with the owner are left out.
+func (rc *FollowUC) Following(ctx context.Context, userID string, opts *model.FollowFindOpts) (*model.FollowList, error) {
+	opts.UserID = model.Filter{
+		Value:    userID,
+		IsSended: true,
+	}
+
+	return rc.list(ctx, userID, opts)
+}
+
+func (rc *FollowUC) list(ctx context.Context, userID string, opts *model.FollowFindOpts) (*model.FollowList, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+	if ownerID == "" {
+		return rc.repo.List(ctx, opts)
+	}
+
+	isBlocked, err := rc.connectsUC.IsBlocked(ctx, ownerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isBlocked {
+		return nil, pkg.NewError(nil, "you cannot see this users follows", http.StatusForbidden)
+	}
+
+	opts.VisibleTo = model.Filter{
+		Value:    ownerID,
+		IsSended: true,
+	}
+
+	return rc.repo.List(ctx, opts)
+}
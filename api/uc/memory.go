@@ -0,0 +1,169 @@
+package uc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+const (
+	// digestSendHour is the local hour of the user after which the digest of a new period is sent
+	digestSendHour = 9
+	// maxDigestEvents caps the new events listed in a digest email
+	maxDigestEvents = 20
+	// digestBatchSize is the number of subscribed users loaded at once
+	digestBatchSize = 100
+)
+
+type MemoryUC struct {
+	eventRepo interfaces.EventRepository
+	emailUC   *EmailUC
+	userUC    *UserUC
+}
+
+func NewMemoryUC(eventRepo interfaces.EventRepository, emailUC *EmailUC, userUC *UserUC) *MemoryUC {
+	return &MemoryUC{
+		eventRepo: eventRepo,
+		emailUC:   emailUC,
+		userUC:    userUC,
+	}
+}
+
+// OnThisDay returns the owners events from the same calendar day in earlier years.
+// The day defaults to today in the timezone of the owner.
+func (rc *MemoryUC) OnThisDay(ctx context.Context, date string) ([]model.Event, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+	if ownerID == "" {
+		return nil, pkg.NewError(nil, "User not authenticated", http.StatusUnauthorized)
+	}
+
+	owner, err := rc.userUC.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := owner.Location()
+
+	day := time.Now().In(loc)
+	if date != "" {
+		day, err = time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil {
+			return nil, pkg.NewError(err, "failed to parse date", http.StatusBadRequest)
+		}
+	}
+
+	return rc.eventRepo.OnThisDay(ctx, ownerID, day)
+}
+
+// SendDigests sends the digest email to the subscribed users whose digest is due, it is run periodically.
+// A failing user does not stop the others, their errors are returned together.
+func (rc *MemoryUC) SendDigests(ctx context.Context) error {
+	now := time.Now()
+
+	opts := model.UserFindOpts{
+		OrderByOpts: model.OrderByOpts{Column: "id", OrderBy: "asc", IsSended: true},
+		DigestFrequency: model.Filter{
+			Value:    fmt.Sprintf("%s,%s", model.DigestFrequencyWeekly, model.DigestFrequencyMonthly),
+			Operand:  model.OperandIn,
+			IsSended: true,
+		},
+		PaginationOpts: model.PaginationOpts{Limit: digestBatchSize},
+	}
+
+	var errs []error
+	for {
+		list, err := rc.userUC.List(ctx, &opts)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range list.Users {
+			if err := rc.sendDigest(ctx, &v, now); err != nil {
+				errs = append(errs, fmt.Errorf("user %s: %w", v.ID, err))
+			}
+		}
+
+		if len(list.Users) < digestBatchSize {
+			break
+		}
+
+		opts.Skip += digestBatchSize
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendDigest sends the digest of the last period to the user if it is due and not sent yet.
+// Periods start on monday or on the first day of the month in the timezone of the user.
+func (rc *MemoryUC) sendDigest(ctx context.Context, user *model.User, now time.Time) error {
+	local := now.In(user.Location())
+
+	start, prevStart := digestPeriod(local, user.DigestFrequency)
+	if local.Before(start.Add(digestSendHour * time.Hour)) {
+		return nil
+	}
+
+	if !user.DigestSentAt.Before(start) {
+		return nil
+	}
+
+	eventOpts := model.EventFindOpts{
+		OrderByOpts:    model.OrderByOpts{Column: "created_at", OrderBy: "desc", IsSended: true},
+		UserID:         model.Filter{Value: user.ID, IsSended: true},
+		CreatedAt:      rangeFilter(prevStart, start.Add(-time.Microsecond)),
+		HideLocked:     true,
+		PaginationOpts: model.PaginationOpts{Limit: maxDigestEvents},
+	}
+
+	newEvents, err := rc.eventRepo.List(ctx, &eventOpts)
+	if err != nil {
+		return err
+	}
+
+	onThisDay, err := rc.eventRepo.OnThisDay(ctx, user.ID, local)
+	if err != nil {
+		return err
+	}
+
+	// nothing to remind of, the period is skipped without an email
+	if newEvents.Total > 0 || len(onThisDay) > 0 {
+		digest := model.Digest{
+			From:          prevStart,
+			To:            start,
+			Username:      user.Username,
+			Frequency:     user.DigestFrequency,
+			NewEvents:     newEvents.Events,
+			NewEventCount: newEvents.Total,
+			OnThisDay:     onThisDay,
+		}
+
+		if err := rc.emailUC.SendDigestEmail(ctx, user, &digest); err != nil {
+			return err
+		}
+	}
+
+	return rc.userUC.UpdateDigestSentAt(ctx, user.ID, now)
+}
+
+// digestPeriod returns the start of the current digest period of local and the start of the one before
+func digestPeriod(local time.Time, frequency model.DigestFrequency) (time.Time, time.Time) {
+	year, month, day := local.Date()
+
+	if frequency == model.DigestFrequencyMonthly {
+		start := time.Date(year, month, 1, 0, 0, 0, 0, local.Location())
+		return start, start.AddDate(0, -1, 0)
+	}
+
+	// weeks start on monday
+	sinceMonday := (int(local.Weekday()) + 6) % 7
+	start := time.Date(year, month, day-sinceMonday, 0, 0, 0, 0, local.Location())
+
+	return start, start.AddDate(0, 0, -7)
+}
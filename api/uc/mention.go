@@ -0,0 +1,205 @@
+package uc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+type MentionUC struct {
+	repo       interfaces.MentionRepository
+	eventUC    *EventUC
+	connectsUC *ConnectsUC
+}
+
+func NewMentionUC(repo interfaces.MentionRepository, eventUC *EventUC, connectsUC *ConnectsUC) *MentionUC {
+	return &MentionUC{
+		repo:       repo,
+		eventUC:    eventUC,
+		connectsUC: connectsUC,
+	}
+}
+
+// Create tags a connection of the owner in one of the owners events, the tag stays pending until the user approves it.
+func (rc *MentionUC) Create(ctx context.Context, eventID string, req *model.MentionCreateInput) (*model.Mention, error) {
+	owner := util.GetOwnerFromCtx(ctx)
+
+	event, err := rc.eventUC.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.UserID != owner.ID {
+		return nil, pkg.NewError(nil, "you can tag users only in your events", http.StatusForbidden)
+	}
+
+	if req.UserID == owner.ID {
+		return nil, pkg.NewError(nil, "you cannot tag yourself", http.StatusBadRequest)
+	}
+
+	if event.Visibility == model.EventVisibilityJustMe {
+		return nil, pkg.NewError(nil, "you cannot tag users in just me events", http.StatusBadRequest)
+	}
+
+	if event.Draft {
+		return nil, pkg.NewError(nil, "you cannot tag users in drafts", http.StatusBadRequest)
+	}
+
+	if event.Locked(time.Now()) {
+		return nil, pkg.NewError(nil, "you cannot tag users in time capsules before they open", http.StatusBadRequest)
+	}
+
+	isConnected, err := rc.connectsUC.IsConnected(ctx, owner.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isConnected {
+		return nil, pkg.NewError(nil, "you can tag only your connections", http.StatusBadRequest)
+	}
+
+	now := time.Now()
+
+	mention := model.Mention{
+		EventID:   event.ID,
+		UserID:    req.UserID,
+		Status:    model.RequestStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// the tagged user is notified through the outbox
+	notification, err := notificationEvent(model.DomainEventMentionCreated, model.NotificationCreateInput{
+		UserID: req.UserID,
+		Type:   model.NotificationTypeEventMention,
+		Payload: model.NotificationPayload{
+			ActorID:    owner.ID,
+			ActorName:  owner.Username,
+			TargetType: model.NotificationTargetEvent,
+			TargetID:   event.ID,
+			TargetName: event.Name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.repo.Create(ctx, &mention, notification)
+}
+
+// Update approves or rejects a mention of the owner. Approved events show up on the owners timeline,
+// rejecting an approved mention removes it again without letting the event owner tag the user once more.
+func (rc *MentionUC) Update(ctx context.Context, id string, req *model.MentionUpdateInput) error {
+	if req.Status != model.RequestStatusApproved && req.Status != model.RequestStatusRejected {
+		return pkg.NewError(nil, "status must be approved or rejected", http.StatusBadRequest)
+	}
+
+	exist, err := rc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	owner := util.GetOwnerFromCtx(ctx)
+
+	if exist.UserID != owner.ID {
+		return pkg.NewError(nil, "you can update only your mentions", http.StatusForbidden)
+	}
+
+	if exist.Status == req.Status {
+		return nil
+	}
+
+	if req.Status != model.RequestStatusApproved {
+		return rc.repo.UpdateStatus(ctx, id, req.Status)
+	}
+
+	event, err := rc.eventUC.GetByID(ctx, exist.EventID)
+	if err != nil {
+		return err
+	}
+
+	// the event owner is notified through the outbox
+	notification, err := notificationEvent(model.DomainEventMentionApproved, model.NotificationCreateInput{
+		UserID: event.UserID,
+		Type:   model.NotificationTypeMentionApproved,
+		Payload: model.NotificationPayload{
+			ActorID:    owner.ID,
+			ActorName:  owner.Username,
+			TargetType: model.NotificationTargetEvent,
+			TargetID:   event.ID,
+			TargetName: event.Name,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return rc.repo.UpdateStatus(ctx, id, req.Status, notification)
+}
+
+// Delete removes the tag of the user from the event, allowed to the event owner and the tagged user.
+// Rejected tags are kept for the event owner, so the user is not asked again.
+func (rc *MentionUC) Delete(ctx context.Context, eventID, userID string) error {
+	exist, err := rc.repo.GetByEventAndUser(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	owner := util.GetOwnerFromCtx(ctx)
+
+	if exist.UserID != owner.ID && owner.RoleID != model.AdminRole {
+		if exist.Status == model.RequestStatusRejected {
+			return pkg.NewError(nil, "the user rejected being tagged in the event", http.StatusForbidden)
+		}
+
+		event, err := rc.eventUC.GetByID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		if event.UserID != owner.ID {
+			return pkg.NewError(nil, "you can remove only your tags or tags in your events", http.StatusForbidden)
+		}
+	}
+
+	return rc.repo.Delete(ctx, exist.ID)
+}
+
+// List returns the users tagged in the event, the event has to be visible to the owner.
+// Pending and rejected tags are listed only to the event owner.
+func (rc *MentionUC) List(ctx context.Context, eventID string, opts *model.MentionFindOpts) (*model.MentionList, error) {
+	event, err := rc.eventUC.GetVisible(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.EventID = model.Filter{
+		Value:    eventID,
+		IsSended: true,
+	}
+
+	if event.UserID != util.GetOwnerIDFromCtx(ctx) {
+		opts.Status = model.Filter{
+			Value:    fmt.Sprintf("%d", model.RequestStatusApproved),
+			IsSended: true,
+		}
+	}
+
+	return rc.repo.List(ctx, opts)
+}
+
+// ListOwn returns the events the owner is tagged in.
+func (rc *MentionUC) ListOwn(ctx context.Context, opts *model.MentionFindOpts) (*model.MentionList, error) {
+	opts.UserID = model.Filter{
+		Value:    util.GetOwnerIDFromCtx(ctx),
+		IsSended: true,
+	}
+
+	return rc.repo.List(ctx, opts)
+}
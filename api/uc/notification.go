@@ -2,7 +2,11 @@ package uc
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/fleimkeipa/lifery/model"
@@ -11,31 +15,119 @@ import (
 	"github.com/fleimkeipa/lifery/util"
 )
 
+const (
+	// replayLimit is the most notifications replayed to a reconnecting stream
+	replayLimit = 200
+	// streamTicketTTL is how long a stream ticket can be used, the client opens the stream right after taking it
+	streamTicketTTL = 30 * time.Second
+)
+
 type NotificationUC struct {
-	repo interfaces.NotificationRepository
+	repo       interfaces.NotificationRepository
+	prefsRepo  interfaces.NotificationPreferenceRepository
+	hub        interfaces.NotificationHub
+	ticketRepo interfaces.StreamTicketRepository
+	dispatcher *notificationDispatcher
+	userUC     *UserUC
 }
 
-func NewNotificationUC(repo interfaces.NotificationRepository) *NotificationUC {
+func NewNotificationUC(repo interfaces.NotificationRepository, prefsRepo interfaces.NotificationPreferenceRepository, hub interfaces.NotificationHub, ticketRepo interfaces.StreamTicketRepository, emailUC *EmailUC, pushUC *PushUC, userUC *UserUC) *NotificationUC {
 	return &NotificationUC{
-		repo: repo,
+		repo:       repo,
+		prefsRepo:  prefsRepo,
+		hub:        hub,
+		ticketRepo: ticketRepo,
+		dispatcher: &notificationDispatcher{
+			repo:      repo,
+			prefsRepo: prefsRepo,
+			hub:       hub,
+			emailUC:   emailUC,
+			pushUC:    pushUC,
+			userUC:    userUC,
+		},
+		userUC: userUC,
+	}
+}
+
+// RegisterOutboxHandlers makes the outbox create the notifications the comment, mention, co-owner and follow events carry.
+func (rc *NotificationUC) RegisterOutboxHandlers(outboxUC *OutboxUC) {
+	outboxUC.Register("notification", rc.notify,
+		model.DomainEventCommentCreated,
+		model.DomainEventMentionCreated,
+		model.DomainEventMentionApproved,
+		model.DomainEventCoOwnerInvited,
+		model.DomainEventCoOwnerApproved,
+		model.DomainEventFollowCreated,
+	)
+}
+
+// notify creates the notification carried by the event, it is an outbox handler
+func (rc *NotificationUC) notify(ctx context.Context, event *model.DomainEvent) error {
+	var req model.NotificationCreateInput
+	if err := json.Unmarshal(event.Payload, &req); err != nil {
+		return pkg.NewError(err, "invalid notification event payload", http.StatusInternalServerError)
+	}
+
+	req.SourceID = event.ID
+
+	_, err := rc.Create(ctx, req)
+
+	return err
+}
+
+// notificationEvent returns the outbox event of a change that notifies its receiver, the entity id is set when it is stored
+func notificationEvent(eventType model.DomainEventType, notification model.NotificationCreateInput) (*model.DomainEvent, error) {
+	event, err := model.NewDomainEvent(eventType, "", notification)
+	if err != nil {
+		return nil, pkg.NewError(err, "failed to create "+string(eventType)+" event", http.StatusInternalServerError)
 	}
+
+	return event, nil
 }
 
+// Create stores the notification and dispatches it through the channel the user chose for its type.
+// Nothing is created and nil is returned if the user turned the type off or already got it from the same source.
+// The message is stored in the default language for clients reading it without rendering.
 func (rc *NotificationUC) Create(ctx context.Context, req model.NotificationCreateInput) (*model.Notification, error) {
+	if _, ok := notificationKinds[req.Type]; !ok {
+		return nil, pkg.NewError(nil, "unknown notification type "+string(req.Type), http.StatusBadRequest)
+	}
+
+	prefs, err := rc.prefsRepo.Get(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := prefs.Channel(req.Type)
+	if channel == model.NotificationChannelNone {
+		return nil, nil
+	}
+
 	createdAt, err := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
 	if err != nil {
 		return nil, pkg.NewError(err, "failed to parse start time", http.StatusBadRequest)
 	}
 
 	notification := model.Notification{
-		UserID:    req.UserID,
-		Type:      req.Type,
-		Message:   req.Message,
-		Read:      model.NotificationStatusUnread,
-		CreatedAt: createdAt,
+		UserID:       req.UserID,
+		Type:         req.Type,
+		Payload:      req.Payload,
+		Read:         model.NotificationStatusUnread,
+		CreatedAt:    createdAt,
+		EmailPending: channel == model.NotificationChannelEmail,
+		SourceID:     req.SourceID,
 	}
 
-	return rc.repo.Create(ctx, &notification)
+	renderNotification(&notification, model.DefaultLanguage)
+
+	newNotification, err := rc.repo.Create(ctx, &notification)
+	if err != nil || newNotification == nil {
+		return nil, err
+	}
+
+	rc.dispatcher.dispatch(ctx, newNotification, prefs)
+
+	return newNotification, nil
 }
 
 func (rc *NotificationUC) Update(ctx context.Context, id string, req model.NotificationUpdateInput) error {
@@ -63,7 +155,17 @@ func (rc *NotificationUC) List(ctx context.Context, opts *model.NotificationFind
 		return nil, err
 	}
 
-	return rc.repo.List(ctx, opts)
+	list, err := rc.repo.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	language := rc.language(ctx, opts.UserID.Value)
+	for i := range list.Notifications {
+		renderNotification(&list.Notifications[i], language)
+	}
+
+	return list, nil
 }
 
 func (rc *NotificationUC) GetByID(ctx context.Context, id string) (*model.Notification, error) {
@@ -83,6 +185,177 @@ func (rc *NotificationUC) Delete(ctx context.Context, id string) error {
 	return rc.repo.Delete(ctx, id)
 }
 
+// GetPreferences returns the notification preferences of the owner.
+func (rc *NotificationUC) GetPreferences(ctx context.Context) (*model.NotificationPreferences, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	return rc.prefsRepo.Get(ctx, ownerID)
+}
+
+// UpdatePreferences replaces the notification preferences of the owner.
+func (rc *NotificationUC) UpdatePreferences(ctx context.Context, req model.NotificationPreferencesInput) error {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	for notificationType := range req.Channels {
+		if _, ok := notificationKinds[notificationType]; !ok {
+			return pkg.NewError(nil, "unknown notification type "+string(notificationType), http.StatusBadRequest)
+		}
+	}
+
+	if (req.QuietHoursStart == "") != (req.QuietHoursEnd == "") {
+		return pkg.NewError(nil, "quiet hours need both start and end", http.StatusBadRequest)
+	}
+
+	prefs := model.NotificationPreferences{
+		Channels:        req.Channels,
+		QuietHoursStart: req.QuietHoursStart,
+		QuietHoursEnd:   req.QuietHoursEnd,
+		DailyDigest:     req.DailyDigest,
+	}
+
+	return rc.prefsRepo.Upsert(ctx, ownerID, &prefs)
+}
+
+// SendPendingEmails sends the notification emails held back by quiet hours or the daily digest, it is run periodically.
+func (rc *NotificationUC) SendPendingEmails(ctx context.Context) error {
+	return rc.dispatcher.sendPendingEmails(ctx)
+}
+
+// MarkAllRead marks every unread notification of the owner as read and returns how many were marked.
+func (rc *NotificationUC) MarkAllRead(ctx context.Context) (int, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	return rc.repo.MarkAllRead(ctx, ownerID)
+}
+
+// DeleteMany deletes the given notifications of the owner and returns how many were deleted.
+func (rc *NotificationUC) DeleteMany(ctx context.Context, req model.NotificationDeleteInput) (int, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	return rc.repo.DeleteMany(ctx, ownerID, req.IDs)
+}
+
+// UnreadCount returns the number of unread notifications of the owner.
+func (rc *NotificationUC) UnreadCount(ctx context.Context) (int, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	return rc.repo.CountUnread(ctx, ownerID)
+}
+
+// CleanupRead deletes the read notifications older than ttl.
+func (rc *NotificationUC) CleanupRead(ctx context.Context, ttl time.Duration) error {
+	_, err := rc.repo.DeleteReadBefore(ctx, time.Now().Add(-ttl))
+	return err
+}
+
+// CreateStreamTicket returns a ticket that opens the notification stream of the owner once, within streamTicketTTL.
+func (rc *NotificationUC) CreateStreamTicket(ctx context.Context) (*model.StreamTicket, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, pkg.NewError(err, "failed to generate stream ticket", http.StatusInternalServerError)
+	}
+
+	ticket := model.StreamTicket{
+		Ticket:    hex.EncodeToString(raw),
+		ExpiresAt: time.Now().Add(streamTicketTTL),
+	}
+
+	if err := rc.ticketRepo.Create(ctx, ticket.Ticket, util.GetOwnerFromCtx(ctx), ticket.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+// RedeemStreamTicket uses up the ticket and returns the context carrying its owner.
+func (rc *NotificationUC) RedeemStreamTicket(ctx context.Context, ticket string) (context.Context, error) {
+	if ticket == "" {
+		return nil, pkg.NewError(nil, "stream ticket is required", http.StatusUnauthorized)
+	}
+
+	owner, err := rc.ticketRepo.Redeem(ctx, ticket, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return util.WithOwner(ctx, owner), nil
+}
+
+// Subscribe returns the notifications of the owner created after lastID to replay and the notifications created from now on,
+// rendered in the language of the owner. The returned func has to be called when the stream ends. An empty lastID replays nothing.
+func (rc *NotificationUC) Subscribe(ctx context.Context, lastID string) ([]model.Notification, <-chan model.Notification, func(), error) {
+	if _, err := strconv.Atoi(lastID); lastID != "" && err != nil {
+		return nil, nil, nil, pkg.NewError(err, "invalid last event id "+lastID, http.StatusBadRequest)
+	}
+
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	language := rc.language(ctx, ownerID)
+
+	// subscribing before the replay query leaves no gap, the stream skips what was replayed already
+	published, unsubscribe := rc.hub.Subscribe(ownerID)
+
+	live := make(chan model.Notification)
+	go func() {
+		defer close(live)
+
+		// ends when unsubscribe closes the published channel
+		for notification := range published {
+			renderNotification(&notification, language)
+
+			select {
+			case live <- notification:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	if lastID == "" {
+		return nil, live, unsubscribe, nil
+	}
+
+	list, err := rc.repo.List(ctx, &model.NotificationFindOpts{
+		OrderByOpts: model.OrderByOpts{
+			Column:   "id",
+			OrderBy:  "asc",
+			IsSended: true,
+		},
+		ID: model.Filter{
+			Value:    lastID,
+			Operand:  model.OperandGreater,
+			IsSended: true,
+		},
+		UserID: model.Filter{
+			Value:    ownerID,
+			IsSended: true,
+		},
+		PaginationOpts: model.PaginationOpts{
+			Limit: replayLimit,
+		},
+	})
+	if err != nil {
+		unsubscribe()
+		return nil, nil, nil, err
+	}
+
+	for i := range list.Notifications {
+		renderNotification(&list.Notifications[i], language)
+	}
+
+	return list.Notifications, live, unsubscribe, nil
+}
+
+// language returns the language of the user, the default one if the user cannot be read
+func (rc *NotificationUC) language(ctx context.Context, userID string) model.Language {
+	user, err := rc.userUC.GetByID(ctx, userID)
+	if err != nil {
+		return model.DefaultLanguage
+	}
+
+	return user.LanguageOrDefault()
+}
+
 func (rc *NotificationUC) isOwner(ctx context.Context, id string) bool {
 	ownerID := util.GetOwnerIDFromCtx(ctx)
 	return id == ownerID
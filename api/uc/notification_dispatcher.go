@@ -0,0 +1,163 @@
+package uc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg/logger"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+)
+
+// maxEmailNotifications caps the notifications listed in one email, the rest are sent with the next one
+const maxEmailNotifications = 100
+
+// notificationDispatcher delivers stored notifications through the channels the user chose for their type.
+// Every notification is published to the open streams of the user and queued to their subscribed browsers,
+// email ones are emailed as well. Pushes are skipped in quiet hours. Emails are held back in quiet hours
+// and for users on the daily digest, sendPendingEmails sends them later.
+type notificationDispatcher struct {
+	repo      interfaces.NotificationRepository
+	prefsRepo interfaces.NotificationPreferenceRepository
+	hub       interfaces.NotificationHub
+	emailUC   *EmailUC
+	pushUC    *PushUC
+	userUC    *UserUC
+}
+
+func (rc *notificationDispatcher) dispatch(ctx context.Context, notification *model.Notification, prefs *model.NotificationPreferences) {
+	if err := rc.hub.Publish(ctx, notification); err != nil {
+		// Log the error but don't fail the request, the stream replays it on reconnect
+		logger.Log.Errorf("failed to publish notification %s: %v", notification.ID, err)
+	}
+
+	user, err := rc.userUC.GetByID(ctx, notification.UserID)
+	if err != nil {
+		// Log the error but don't fail the request, an email stays pending
+		logger.Log.Errorf("failed to deliver notification %s: %v", notification.ID, err)
+		return
+	}
+
+	quiet := prefs.InQuietHours(time.Now().In(user.Location()))
+
+	if !quiet {
+		if err := rc.pushUC.Queue(ctx, user, *notification); err != nil {
+			// Log the error but don't fail the request
+			logger.Log.Errorf("failed to queue push of notification %s: %v", notification.ID, err)
+		}
+	}
+
+	if !notification.EmailPending || prefs.DailyDigest || quiet {
+		return
+	}
+
+	if err := rc.sendEmail(ctx, user, []model.Notification{*notification}, false); err != nil {
+		// Log the error but don't fail the request, the email stays pending
+		logger.Log.Errorf("failed to email notification %s: %v", notification.ID, err)
+	}
+}
+
+// sendPendingEmails sends the held back notification emails of every user, it is run periodically.
+// A failing user does not stop the others, their errors are returned together.
+func (rc *notificationDispatcher) sendPendingEmails(ctx context.Context) error {
+	userIDs, err := rc.repo.EmailPendingUserIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	var errs []error
+	for _, v := range userIDs {
+		if err := rc.sendPending(ctx, v, now); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", v, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendPending emails the pending notifications of the user together unless it is their quiet hours.
+// Users on the daily digest get them once a day after digestSendHour in their timezone.
+func (rc *notificationDispatcher) sendPending(ctx context.Context, userID string, now time.Time) error {
+	user, err := rc.userUC.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	prefs, err := rc.prefsRepo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	local := now.In(user.Location())
+	if prefs.InQuietHours(local) {
+		return nil
+	}
+
+	if prefs.DailyDigest {
+		today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+		if local.Before(today.Add(digestSendHour*time.Hour)) || !prefs.DigestSentAt.Before(today) {
+			return nil
+		}
+	}
+
+	list, err := rc.repo.List(ctx, &model.NotificationFindOpts{
+		OrderByOpts: model.OrderByOpts{
+			Column:   "id",
+			OrderBy:  "asc",
+			IsSended: true,
+		},
+		UserID: model.Filter{
+			Value:    userID,
+			IsSended: true,
+		},
+		EmailPending: model.Filter{
+			Value:    "true",
+			IsSended: true,
+		},
+		PaginationOpts: model.PaginationOpts{
+			Limit: maxEmailNotifications,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(list.Notifications) == 0 {
+		return nil
+	}
+
+	if err := rc.sendEmail(ctx, user, list.Notifications, prefs.DailyDigest || len(list.Notifications) > 1); err != nil {
+		return err
+	}
+
+	if prefs.DailyDigest {
+		return rc.prefsRepo.UpdateDigestSentAt(ctx, userID, now)
+	}
+
+	return nil
+}
+
+// sendEmail emails the notifications rendered in the language of the user and clears their pending email
+func (rc *notificationDispatcher) sendEmail(ctx context.Context, user *model.User, notifications []model.Notification, digest bool) error {
+	ids := make([]string, 0, len(notifications))
+	for i := range notifications {
+		renderNotification(&notifications[i], user.LanguageOrDefault())
+		ids = append(ids, notifications[i].ID)
+	}
+
+	email := model.NotificationEmail{
+		Username:      user.Username,
+		Notifications: notifications,
+		Digest:        digest,
+	}
+
+	if err := rc.emailUC.SendNotificationEmail(ctx, user, &email); err != nil {
+		return err
+	}
+
+	return rc.repo.MarkEmailed(ctx, ids)
+}
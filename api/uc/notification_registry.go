@@ -0,0 +1,138 @@
+package uc
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/fleimkeipa/lifery/model"
+)
+
+// notificationKind is how a notification type is shown, its messages are templates over the payload
+type notificationKind struct {
+	messages map[model.Language]*template.Template
+	link     func(payload model.NotificationPayload) string
+}
+
+func newNotificationKind(en, tr string, link func(payload model.NotificationPayload) string) notificationKind {
+	return notificationKind{
+		messages: map[model.Language]*template.Template{
+			model.LanguageEnglish: template.Must(template.New("en").Parse(en)),
+			model.LanguageTurkish: template.Must(template.New("tr").Parse(tr)),
+		},
+		link: link,
+	}
+}
+
+// links are paths of the ui pages, the ui adds the locale prefix
+func connectsLink(model.NotificationPayload) string {
+	return "/connects"
+}
+
+func actorLink(payload model.NotificationPayload) string {
+	return "/user/" + payload.ActorID
+}
+
+func targetLink(payload model.NotificationPayload) string {
+	if payload.TargetType == model.NotificationTargetEra {
+		return "/eras/" + payload.TargetID
+	}
+
+	return "/events/" + payload.TargetID
+}
+
+// notificationKinds is the registry of the notification types, creating a notification of a type missing here fails
+var notificationKinds = map[model.NotificationType]notificationKind{
+	model.NotificationTypeConnectRequest: newNotificationKind(
+		"{{.ActorName}} sent you a connection request",
+		"{{.ActorName}} sana bağlantı isteği gönderdi",
+		connectsLink,
+	),
+	model.NotificationTypeConnectApproved: newNotificationKind(
+		"Your connection request to {{.ActorName}} was accepted",
+		"{{.ActorName}} bağlantı isteğini kabul etti",
+		actorLink,
+	),
+	model.NotificationTypeConnectRejected: newNotificationKind(
+		"Your connection request to {{.ActorName}} was declined",
+		"{{.ActorName}} bağlantı isteğini reddetti",
+		connectsLink,
+	),
+	model.NotificationTypeConnectCancelled: newNotificationKind(
+		"{{.ActorName}} cancelled their connection request",
+		"{{.ActorName}} bağlantı isteğini geri çekti",
+		connectsLink,
+	),
+	model.NotificationTypeConnectExpired: newNotificationKind(
+		"Your connection request{{with .ActorName}} to {{.}}{{end}} expired",
+		"{{with .ActorName}}{{.}} kullanıcısına gönderdiğin {{end}}bağlantı isteğinin süresi doldu",
+		connectsLink,
+	),
+	model.NotificationTypeEventComment: newNotificationKind(
+		"{{.ActorName}} commented on your event {{.TargetName}}",
+		"{{.ActorName}} {{.TargetName}} etkinliğine yorum yaptı",
+		targetLink,
+	),
+	model.NotificationTypeEventReaction: newNotificationKind(
+		"{{if eq .Count 1}}1 person{{else}}{{.Count}} people{{end}} reacted to your event {{.TargetName}}",
+		"{{.Count}} kişi {{.TargetName}} etkinliğine tepki verdi",
+		targetLink,
+	),
+	model.NotificationTypeEventMention: newNotificationKind(
+		"{{.ActorName}} tagged you in the event {{.TargetName}}",
+		"{{.ActorName}} seni {{.TargetName}} etkinliğinde etiketledi",
+		targetLink,
+	),
+	model.NotificationTypeMentionApproved: newNotificationKind(
+		"{{.ActorName}} approved being tagged in your event {{.TargetName}}",
+		"{{.ActorName}} {{.TargetName}} etkinliğindeki etiketini onayladı",
+		targetLink,
+	),
+	model.NotificationTypeCoOwnerRequest: newNotificationKind(
+		"{{.ActorName}} invited you to co-own the {{.TargetType}} {{.TargetName}}",
+		`{{.ActorName}} seni {{.TargetName}} {{if eq .TargetType "era"}}dönemine{{else}}etkinliğine{{end}} ortak sahip olarak davet etti`,
+		targetLink,
+	),
+	model.NotificationTypeCoOwnerApproved: newNotificationKind(
+		"{{.ActorName}} is now co-owning the {{.TargetType}} {{.TargetName}}",
+		`{{.ActorName}} artık {{.TargetName}} {{if eq .TargetType "era"}}döneminin{{else}}etkinliğinin{{end}} ortak sahibi`,
+		targetLink,
+	),
+	model.NotificationTypeNewFollower: newNotificationKind(
+		"{{.ActorName}} started following you",
+		"{{.ActorName}} seni takip etmeye başladı",
+		actorLink,
+	),
+	model.NotificationTypeEventPublished: newNotificationKind(
+		"{{.ActorName}} shared a new event {{.TargetName}}",
+		"{{.ActorName}} yeni bir etkinlik paylaştı: {{.TargetName}}",
+		targetLink,
+	),
+	model.NotificationTypeCapsuleOpened: newNotificationKind(
+		"{{with .ActorName}}The time capsule {{.}} sent you{{else}}Your time capsule{{end}} {{.TargetName}} opened",
+		"{{with .ActorName}}{{.}} kullanıcısının sana gönderdiği{{else}}Senin{{end}} {{.TargetName}} zaman kapsülü açıldı",
+		targetLink,
+	),
+}
+
+// renderNotification fills the message and link of the notification in the language.
+// Notifications without a payload were stored with their message and keep it.
+func renderNotification(notification *model.Notification, language model.Language) {
+	kind, ok := notificationKinds[notification.Type]
+	if !ok || notification.Payload == (model.NotificationPayload{}) {
+		return
+	}
+
+	notification.Link = kind.link(notification.Payload)
+
+	tmpl, ok := kind.messages[language]
+	if !ok {
+		tmpl = kind.messages[model.DefaultLanguage]
+	}
+
+	var message strings.Builder
+	if err := tmpl.Execute(&message, notification.Payload); err != nil {
+		return
+	}
+
+	notification.Message = message.String()
+}
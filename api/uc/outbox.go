@@ -0,0 +1,128 @@
+package uc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+)
+
+const (
+	// outboxBatchSize is the number of due events claimed at once
+	outboxBatchSize = 100
+	// outboxLease is how long claimed events are skipped by other instances, longer than a batch takes
+	outboxLease = 5 * time.Minute
+	// outboxMaxAttempts is after how many failed attempts an event goes to the dead letters
+	outboxMaxAttempts = 10
+	// outboxBaseBackoff doubles after each failed attempt up to outboxMaxBackoff
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = 6 * time.Hour
+)
+
+// OutboxHandler delivers a side effect of a domain event, an error retries the event later.
+// Delivery is at least once, a handler may see an event again if the dispatcher stops before recording it.
+type OutboxHandler func(ctx context.Context, event *model.DomainEvent) error
+
+type outboxHandler struct {
+	name    string
+	handler OutboxHandler
+}
+
+type OutboxUC struct {
+	repo     interfaces.OutboxRepository
+	handlers map[model.DomainEventType][]outboxHandler
+}
+
+func NewOutboxUC(repo interfaces.OutboxRepository) *OutboxUC {
+	return &OutboxUC{
+		repo:     repo,
+		handlers: make(map[model.DomainEventType][]outboxHandler),
+	}
+}
+
+// Register adds a handler of the event types. The name records its delivery so retries skip it, it has to stay the same.
+// Handlers are registered at startup before the dispatcher runs.
+func (rc *OutboxUC) Register(name string, handler OutboxHandler, eventTypes ...model.DomainEventType) {
+	for _, v := range eventTypes {
+		rc.handlers[v] = append(rc.handlers[v], outboxHandler{name: name, handler: handler})
+	}
+}
+
+// Dispatch delivers the due events of the outbox to their handlers, it is run periodically.
+// Failed events are retried with exponential backoff and moved to the dead letters after outboxMaxAttempts.
+func (rc *OutboxUC) Dispatch(ctx context.Context) error {
+	events, err := rc.repo.ClaimDue(ctx, time.Now(), outboxLease, outboxBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range events {
+		if err := rc.deliver(ctx, &events[i]); err != nil {
+			errs = append(errs, fmt.Errorf("outbox event %s: %w", events[i].ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (rc *OutboxUC) ListDeadLetters(ctx context.Context, opts model.PaginationOpts) (*model.DeadLetterList, error) {
+	return rc.repo.ListDeadLetters(ctx, opts)
+}
+
+// RetryDeadLetter puts the dead letter back to the outbox, it is delivered with the next dispatch.
+func (rc *OutboxUC) RetryDeadLetter(ctx context.Context, id string) error {
+	return rc.repo.RetryDeadLetter(ctx, id)
+}
+
+// deliver runs the handlers of the event that did not deliver it yet, the event is removed once all of them did.
+// The returned error is about recording the attempt, handler errors are recorded on the event.
+func (rc *OutboxUC) deliver(ctx context.Context, event *model.DomainEvent) error {
+	var errs []error
+	for _, v := range rc.handlers[event.Type] {
+		if slices.Contains(event.Delivered, v.name) {
+			continue
+		}
+
+		if err := v.handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", v.name, errorText(err)))
+			continue
+		}
+
+		event.Delivered = append(event.Delivered, v.name)
+	}
+
+	if len(errs) == 0 {
+		return rc.repo.Delete(ctx, event.ID)
+	}
+
+	event.Attempts++
+	event.LastError = errors.Join(errs...).Error()
+
+	if event.Attempts >= outboxMaxAttempts {
+		return rc.repo.MoveToDeadLetters(ctx, event)
+	}
+
+	event.NextAttemptAt = time.Now().Add(pkg.ExponentialBackoff(event.Attempts, outboxBaseBackoff, outboxMaxBackoff))
+
+	return rc.repo.Reschedule(ctx, event)
+}
+
+// errorText returns the message and the cause of the error, pkg errors keep their message apart from the cause
+func errorText(err error) string {
+	var pe *pkg.Error
+	if !errors.As(err, &pe) {
+		return err.Error()
+	}
+
+	if pe.Error() == "" {
+		return pe.Message()
+	}
+
+	return pe.Message() + ": " + pe.Error()
+}
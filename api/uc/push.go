@@ -0,0 +1,210 @@
+package uc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+const (
+	// pushTitle is the title of every web push notification, the message is the body
+	pushTitle = "Lifery"
+	// browser key sizes, an uncompressed P-256 point and the auth secret
+	pushKeySize        = 65
+	pushAuthSecretSize = 16
+)
+
+type PushUC struct {
+	repo       interfaces.PushSubscriptionRepository
+	sender     interfaces.PushSender
+	outboxRepo interfaces.OutboxRepository
+}
+
+func NewPushUC(repo interfaces.PushSubscriptionRepository, sender interfaces.PushSender, outboxRepo interfaces.OutboxRepository) *PushUC {
+	return &PushUC{
+		repo:       repo,
+		sender:     sender,
+		outboxRepo: outboxRepo,
+	}
+}
+
+// PublicKey returns the VAPID public key browsers subscribe with.
+func (rc *PushUC) PublicKey(ctx context.Context) (*model.PushPublicKey, error) {
+	publicKey := rc.sender.PublicKey()
+	if publicKey == "" {
+		return nil, pkg.NewError(nil, "web push is not configured", http.StatusServiceUnavailable)
+	}
+
+	return &model.PushPublicKey{PublicKey: publicKey}, nil
+}
+
+// Subscribe stores the push subscription of a browser of the owner, a browser subscribing again replaces its keys.
+func (rc *PushUC) Subscribe(ctx context.Context, req *model.PushSubscriptionInput, userAgent string) (*model.PushSubscription, error) {
+	if err := checkPushEndpoint(req.Endpoint); err != nil {
+		return nil, err
+	}
+
+	if err := checkPushKey(req.Keys.P256dh, pushKeySize); err != nil {
+		return nil, err
+	}
+
+	if err := checkPushKey(req.Keys.Auth, pushAuthSecretSize); err != nil {
+		return nil, err
+	}
+
+	subscription := model.PushSubscription{
+		CreatedAt: time.Now(),
+		UserID:    util.GetOwnerIDFromCtx(ctx),
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: userAgent,
+	}
+
+	if req.ExpirationTime != nil {
+		subscription.ExpiresAt = time.UnixMilli(*req.ExpirationTime)
+	}
+
+	return rc.repo.Upsert(ctx, &subscription)
+}
+
+func (rc *PushUC) Unsubscribe(ctx context.Context, subscriptionID string) error {
+	return rc.repo.Delete(ctx, util.GetOwnerIDFromCtx(ctx), subscriptionID)
+}
+
+// List returns the subscribed devices of the owner.
+func (rc *PushUC) List(ctx context.Context) ([]model.PushSubscription, error) {
+	return rc.repo.ListByUser(ctx, util.GetOwnerIDFromCtx(ctx))
+}
+
+// Queue queues the notification to every subscribed device of the user in their language, the outbox sends them.
+// Nothing is queued while web push is not configured. Subscriptions that expired are deleted.
+func (rc *PushUC) Queue(ctx context.Context, user *model.User, notification model.Notification) error {
+	if rc.sender.PublicKey() == "" {
+		return nil
+	}
+
+	subscriptions, err := rc.repo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	renderNotification(&notification, user.LanguageOrDefault())
+
+	message := model.PushMessage{
+		ID:    notification.ID,
+		Type:  notification.Type,
+		Title: pushTitle,
+		Body:  notification.Message,
+		Link:  notification.Link,
+	}
+
+	now := time.Now()
+
+	var errs []error
+	events := make([]*model.DomainEvent, 0, len(subscriptions))
+	for _, v := range subscriptions {
+		if v.Expired(now) {
+			errs = append(errs, rc.repo.Delete(ctx, user.ID, v.ID))
+			continue
+		}
+
+		event, err := model.NewDomainEvent(model.DomainEventPushQueued, v.ID, model.PushDelivery{
+			UserID:         user.ID,
+			SubscriptionID: v.ID,
+			Message:        message,
+		})
+		if err != nil {
+			return pkg.NewError(err, "failed to create push event", http.StatusInternalServerError)
+		}
+
+		events = append(events, event)
+	}
+
+	if len(events) > 0 {
+		errs = append(errs, rc.outboxRepo.Add(ctx, events...))
+	}
+
+	return errors.Join(errs...)
+}
+
+// RegisterOutboxHandlers makes the outbox send the queued push messages.
+func (rc *PushUC) RegisterOutboxHandlers(outboxUC *OutboxUC) {
+	outboxUC.Register("web_push", rc.deliver, model.DomainEventPushQueued)
+}
+
+// deliver sends a queued push message, it is an outbox handler.
+// Messages to subscriptions removed since are dropped, subscriptions the push service dropped are deleted.
+func (rc *PushUC) deliver(ctx context.Context, event *model.DomainEvent) error {
+	var delivery model.PushDelivery
+	if err := json.Unmarshal(event.Payload, &delivery); err != nil {
+		return pkg.NewError(err, "invalid push event payload", http.StatusInternalServerError)
+	}
+
+	subscription, err := rc.repo.Get(ctx, delivery.UserID, delivery.SubscriptionID)
+
+	var pe *pkg.Error
+	if errors.As(err, &pe) && pe.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if subscription.Expired(time.Now()) {
+		return rc.repo.Delete(ctx, subscription.UserID, subscription.ID)
+	}
+
+	payload, err := json.Marshal(delivery.Message)
+	if err != nil {
+		return pkg.NewError(err, "failed to marshal push message", http.StatusInternalServerError)
+	}
+
+	err = rc.sender.Send(ctx, subscription, payload)
+	if errors.As(err, &pe) && pe.StatusCode() == http.StatusGone {
+		return rc.repo.Delete(ctx, subscription.UserID, subscription.ID)
+	}
+
+	return err
+}
+
+// DeleteExpired deletes the subscriptions browsers dropped, it is run periodically.
+func (rc *PushUC) DeleteExpired(ctx context.Context) error {
+	_, err := rc.repo.DeleteExpired(ctx, time.Now())
+	return err
+}
+
+// checkPushEndpoint checks that the endpoint is an https url of a host, private addresses are refused when sending
+func checkPushEndpoint(endpoint string) error {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Scheme != "https" || endpointURL.Hostname() == "" || endpointURL.User != nil {
+		return pkg.NewError(err, "push endpoint has to be an https url", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// checkPushKey checks that the base64url browser key decodes to size bytes
+func checkPushKey(key string, size int) error {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+	if err != nil || len(raw) != size {
+		return pkg.NewError(err, "invalid push subscription keys", http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package uc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+type ReactionUC struct {
+	repo           interfaces.ReactionRepository
+	eventUC        *EventUC
+	notificationUC *NotificationUC
+}
+
+func NewReactionUC(repo interfaces.ReactionRepository, eventUC *EventUC, notificationUC *NotificationUC) *ReactionUC {
+	return &ReactionUC{
+		repo:           repo,
+		eventUC:        eventUC,
+		notificationUC: notificationUC,
+	}
+}
+
+// Set reacts to the event with the emoji, replacing the earlier reaction of the owner.
+// The event has to be visible to the owner.
+func (rc *ReactionUC) Set(ctx context.Context, eventID string, req *model.ReactionSetInput) (*model.Reaction, error) {
+	if !slices.Contains(model.ReactionEmojis, req.Emoji) {
+		return nil, pkg.NewError(nil, "unsupported reaction: "+req.Emoji, http.StatusBadRequest)
+	}
+
+	event, err := rc.eventUC.GetVisible(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+	now := time.Now()
+
+	reaction := model.Reaction{
+		Emoji:     req.Emoji,
+		EventID:   event.ID,
+		UserID:    ownerID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return rc.repo.Set(ctx, &reaction, event.UserID == ownerID)
+}
+
+// Delete removes the reaction of the owner from the event.
+func (rc *ReactionUC) Delete(ctx context.Context, eventID string) error {
+	return rc.repo.Delete(ctx, eventID, util.GetOwnerIDFromCtx(ctx))
+}
+
+// List returns who reacted to the event, newest first. The event has to be visible to the owner.
+func (rc *ReactionUC) List(ctx context.Context, eventID string, opts *model.ReactionFindOpts) (*model.ReactionList, error) {
+	if _, err := rc.eventUC.GetVisible(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	opts.EventID = model.Filter{
+		Value:    eventID,
+		IsSended: true,
+	}
+
+	return rc.repo.List(ctx, opts)
+}
+
+// NotifyOwners sends one notification per event for the reactions since the last run,
+// so owners are told "3 people reacted" instead of getting a notification for every reaction.
+func (rc *ReactionUC) NotifyOwners(ctx context.Context) error {
+	batches, err := rc.repo.PendingBatches(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, batch := range batches {
+		if err := rc.notifyOwner(ctx, &batch); err != nil {
+			errs = append(errs, fmt.Errorf("event %s: %w", batch.EventID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (rc *ReactionUC) notifyOwner(ctx context.Context, batch *model.ReactionBatch) error {
+	_, err := rc.notificationUC.Create(ctx, model.NotificationCreateInput{
+		UserID: batch.OwnerID,
+		Type:   model.NotificationTypeEventReaction,
+		Payload: model.NotificationPayload{
+			TargetType: model.NotificationTargetEvent,
+			TargetID:   batch.EventID,
+			TargetName: batch.EventName,
+			Count:      batch.Count,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return rc.repo.MarkNotified(ctx, batch)
+}
@@ -0,0 +1,160 @@
+package uc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+const (
+	// minStatsGapDays is the shortest time without events reported as a gap
+	minStatsGapDays = 30
+	maxStatsGaps    = 5
+	maxStatsTags    = 10
+)
+
+type StatsUC struct {
+	eventRepo   interfaces.EventRepository
+	eraRepo     interfaces.EraRepository
+	tagRepo     interfaces.TagRepository
+	connectRepo interfaces.ConnectInterfaces
+	cacheRepo   interfaces.CacheRepository
+}
+
+func NewStatsUC(eventRepo interfaces.EventRepository, eraRepo interfaces.EraRepository, tagRepo interfaces.TagRepository, connectRepo interfaces.ConnectInterfaces, cacheRepo interfaces.CacheRepository) *StatsUC {
+	return &StatsUC{
+		eventRepo:   eventRepo,
+		eraRepo:     eraRepo,
+		tagRepo:     tagRepo,
+		connectRepo: connectRepo,
+		cacheRepo:   cacheRepo,
+	}
+}
+
+// Get returns the statistics of the users timeline, they are cached until the users events change.
+// Only the user itself and admins can see them.
+func (rc *StatsUC) Get(ctx context.Context, opts *model.StatsFindOpts) (*model.Stats, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	if opts.UserID == "" {
+		opts.UserID = ownerID
+	}
+
+	if opts.UserID != ownerID && util.GetOwnerFromCtx(ctx).RoleID != model.AdminRole {
+		return nil, pkg.NewError(nil, "you cannot get another users stats", http.StatusForbidden)
+	}
+
+	if opts.GroupBy == "" {
+		opts.GroupBy = model.TimelinePeriodYear
+	}
+
+	if opts.GroupBy != model.TimelinePeriodYear && opts.GroupBy != model.TimelinePeriodMonth {
+		return nil, pkg.NewError(nil, "group by must be year or month", http.StatusBadRequest)
+	}
+
+	cacheID := StatsCacheID(opts.UserID, opts.GroupBy)
+
+	// a broken cache entry is computed again
+	if cached, err := rc.cacheRepo.Get(ctx, cacheID); err == nil {
+		var stats model.Stats
+		if err := json.Unmarshal([]byte(cached), &stats); err == nil {
+			return &stats, nil
+		}
+	}
+
+	stats, err := rc.compute(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(stats); err == nil {
+		_ = rc.cacheRepo.Set(ctx, cacheID, string(data))
+	}
+
+	return stats, nil
+}
+
+func (rc *StatsUC) compute(ctx context.Context, opts *model.StatsFindOpts) (*model.Stats, error) {
+	userFilter := model.Filter{Value: opts.UserID, IsSended: true}
+	eventOpts := model.EventFindOpts{UserID: userFilter}
+
+	stats := model.Stats{
+		GeneratedAt: time.Now(),
+		GroupBy:     opts.GroupBy,
+	}
+
+	perPeriod, err := rc.eventRepo.CountByPeriod(ctx, &eventOpts, opts.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// undated events fall into a zero period
+	stats.EventsPerPeriod = make([]model.PeriodCount, 0, len(perPeriod))
+	for _, v := range perPeriod {
+		if !v.Period.IsZero() {
+			stats.EventsPerPeriod = append(stats.EventsPerPeriod, v)
+		}
+	}
+
+	stats.MediaCounts, err = rc.eventRepo.CountByItemType(ctx, &eventOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Visibility, err = rc.eventRepo.CountByVisibility(ctx, &eventOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range stats.Visibility {
+		stats.EventCount += v.Count
+	}
+
+	stats.Gaps, err = rc.eventRepo.Gaps(ctx, &eventOpts, minStatsGapDays, maxStatsGaps)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.LongestEra, err = rc.eraRepo.Longest(ctx, &model.EraFindOpts{UserID: userFilter})
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.LongestEra != nil {
+		stats.LongestEraDays = int(stats.LongestEra.TimeEnd.Sub(stats.LongestEra.TimeStart).Hours() / 24)
+	}
+
+	stats.TopTags, err = rc.tagRepo.Counts(ctx, &model.TagFindOpts{
+		UserID:         userFilter,
+		PaginationOpts: model.PaginationOpts{Limit: maxStatsTags},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats.ConnectionGrowth, err = rc.connectRepo.CountByPeriod(ctx, opts.UserID, opts.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// invalidateStats drops the cached statistics of the user, it is called whenever their events change
+func invalidateStats(ctx context.Context, cacheRepo interfaces.CacheRepository, userID string) {
+	_ = cacheRepo.Delete(ctx,
+		StatsCacheID(userID, model.TimelinePeriodYear),
+		StatsCacheID(userID, model.TimelinePeriodMonth),
+	)
+}
+
+func StatsCacheID(userID string, groupBy model.TimelinePeriod) string {
+	return fmt.Sprintf("stats:%s:%s", userID, groupBy)
+}
@@ -0,0 +1,184 @@
+package uc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+const autocompleteLimit = 10
+
+type TagUC struct {
+	repo interfaces.TagRepository
+}
+
+func NewTagUC(repo interfaces.TagRepository) *TagUC {
+	return &TagUC{
+		repo: repo,
+	}
+}
+
+func (rc *TagUC) Create(ctx context.Context, req *model.TagCreateInput) (*model.Tag, error) {
+	name := normalizeTagName(req.Name)
+	if name == "" {
+		return nil, pkg.NewError(nil, "tag name is empty", http.StatusBadRequest)
+	}
+
+	color := req.Color
+	if color == "" {
+		color = model.DefaultTagColor
+	}
+
+	tag := model.Tag{
+		Name:      name,
+		Color:     color,
+		UserID:    util.GetOwnerIDFromCtx(ctx),
+		CreatedAt: util.Now(),
+	}
+
+	return rc.repo.Create(ctx, &tag)
+}
+
+func (rc *TagUC) Update(ctx context.Context, tagID string, req *model.TagUpdateInput) (*model.Tag, error) {
+	// tag exist control
+	exist, err := rc.GetByID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := normalizeTagName(req.Name)
+	if name == "" {
+		return nil, pkg.NewError(nil, "tag name is empty", http.StatusBadRequest)
+	}
+
+	tag := model.Tag{
+		Name:      name,
+		Color:     req.Color,
+		UserID:    exist.UserID,
+		CreatedAt: exist.CreatedAt,
+		UpdatedAt: util.Now(),
+	}
+
+	return rc.repo.Update(ctx, tagID, &tag)
+}
+
+func (rc *TagUC) Delete(ctx context.Context, id string) error {
+	return rc.repo.Delete(ctx, id)
+}
+
+func (rc *TagUC) List(ctx context.Context, opts *model.TagFindOpts) (*model.TagList, error) {
+	if err := rc.checkOwner(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	return rc.repo.List(ctx, opts)
+}
+
+func (rc *TagUC) GetByID(ctx context.Context, id string) (*model.Tag, error) {
+	tag, err := rc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rc.isOwner(ctx, tag.UserID) {
+		return nil, pkg.NewError(nil, "you can get only your tags", http.StatusForbidden)
+	}
+
+	return tag, nil
+}
+
+// Autocomplete returns the owners tags containing the query, most used first.
+func (rc *TagUC) Autocomplete(ctx context.Context, query string) ([]model.TagCount, error) {
+	opts := model.TagFindOpts{
+		PaginationOpts: model.PaginationOpts{
+			Limit: autocompleteLimit,
+		},
+	}
+
+	if query = normalizeTagName(query); query != "" {
+		opts.Name = model.Filter{
+			Value:    query,
+			Operand:  model.OperandLike,
+			IsSended: true,
+		}
+	}
+
+	return rc.Counts(ctx, &opts)
+}
+
+// Counts returns the tags with the number of events they are used in.
+func (rc *TagUC) Counts(ctx context.Context, opts *model.TagFindOpts) ([]model.TagCount, error) {
+	if err := rc.checkOwner(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	return rc.repo.Counts(ctx, opts)
+}
+
+// Resolve returns the owners tags with the given names, missing ones are created with the default color.
+func (rc *TagUC) Resolve(ctx context.Context, names []string) ([]model.Tag, error) {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, v := range names {
+		name := normalizeTagName(v)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	return rc.repo.FindOrCreate(ctx, ownerID, normalized, model.DefaultTagColor)
+}
+
+func (rc *TagUC) isOwner(ctx context.Context, id string) bool {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+	return id == ownerID
+}
+
+func (rc *TagUC) checkOwner(ctx context.Context, opts *model.TagFindOpts) error {
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+	if !opts.UserID.IsSended {
+		opts.UserID = model.Filter{
+			Value:    ownerID,
+			Operand:  model.OperandEqual,
+			IsSended: true,
+		}
+
+		return nil
+	}
+
+	// the operand is dropped for the own id, ne or nin on it would select the tags of every other user
+	if opts.UserID.Value == ownerID {
+		opts.UserID = model.Filter{
+			Value:    ownerID,
+			Operand:  model.OperandEqual,
+			IsSended: true,
+		}
+
+		return nil
+	}
+
+	owner := util.GetOwnerFromCtx(ctx)
+	if owner.RoleID != model.AdminRole {
+		return pkg.NewError(nil, "you cannot get another users tags", http.StatusForbidden)
+	}
+
+	if opts.UserID.Operand != model.OperandEqual {
+		return pkg.NewError(nil, "user_id filter supports only the eq operand", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
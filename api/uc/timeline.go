@@ -0,0 +1,327 @@
+package uc
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+const (
+	defaultTimelineBuckets = 12
+	maxTimelineBuckets     = 100
+	// maxTimelineItems caps the events and eras loaded for one page, buckets are not split so
+	// a single bucket may still exceed it and gets truncated
+	maxTimelineItems = 200
+)
+
+type TimelineUC struct {
+	eventRepo interfaces.EventRepository
+	eraRepo   interfaces.EraRepository
+	eventUC   *EventUC
+}
+
+func NewTimelineUC(eventRepo interfaces.EventRepository, eraRepo interfaces.EraRepository, eventUC *EventUC) *TimelineUC {
+	return &TimelineUC{
+		eventRepo: eventRepo,
+		eraRepo:   eraRepo,
+		eventUC:   eventUC,
+	}
+}
+
+// Get returns a page of the users events and eras grouped by year or month between opts.From and opts.To.
+// Buckets without any events or eras are left out, newest first unless opts.Ascending is set.
+func (rc *TimelineUC) Get(ctx context.Context, opts *model.TimelineFindOpts) (*model.Timeline, error) {
+	if opts.UserID == "" {
+		opts.UserID = util.GetOwnerIDFromCtx(ctx)
+	}
+
+	if opts.UserID == "" {
+		return nil, pkg.NewError(nil, "user id is empty", http.StatusBadRequest)
+	}
+
+	if opts.GroupBy == "" {
+		opts.GroupBy = model.TimelinePeriodYear
+	}
+
+	if opts.GroupBy != model.TimelinePeriodYear && opts.GroupBy != model.TimelinePeriodMonth {
+		return nil, pkg.NewError(nil, "group by must be year or month", http.StatusBadRequest)
+	}
+
+	if opts.Limit <= 0 {
+		opts.Limit = defaultTimelineBuckets
+	}
+	opts.Limit = min(opts.Limit, maxTimelineBuckets)
+
+	from, to, err := rc.window(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	visibility, err := rc.eventUC.visibilityFilter(ctx, opts.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedWith, sharedVisibility := rc.eventUC.sharedFilter(ctx, opts.UserID)
+
+	eventOpts := model.EventFindOpts{
+		UserID:           model.Filter{Value: opts.UserID, IsSended: true},
+		Visibility:       visibility,
+		SharedWith:       sharedWith,
+		SharedVisibility: sharedVisibility,
+		DraftsOf:         rc.eventUC.draftsOf(ctx, opts.UserID),
+		Recipient:        rc.eventUC.recipientFilter(ctx, opts.UserID),
+		Date:             rangeFilter(from, to),
+	}
+	eraOpts := model.EraFindOpts{
+		UserID:     model.Filter{Value: opts.UserID, IsSended: true},
+		SharedWith: model.Filter{Value: opts.UserID, IsSended: true},
+		TimeStart:  rangeFilter(from, to),
+	}
+
+	eventCounts, err := rc.eventRepo.CountByPeriod(ctx, &eventOpts, opts.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	eraCounts, err := rc.eraRepo.CountByPeriod(ctx, &eraOpts, opts.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := rc.mergeCounts(eventCounts, eraCounts, opts)
+
+	page, hasMore := rc.paginate(buckets, opts.Limit)
+
+	timeline := model.Timeline{
+		Buckets: page,
+	}
+
+	if len(page) == 0 {
+		return &timeline, nil
+	}
+
+	if hasMore {
+		last := page[len(page)-1]
+		timeline.NextCursor = last.Start.Format(time.RFC3339Nano)
+		if opts.Ascending {
+			timeline.NextCursor = last.End.Format(time.RFC3339Nano)
+		}
+	}
+
+	if opts.CountsOnly {
+		return &timeline, nil
+	}
+
+	if err := rc.fillItems(ctx, &timeline, eventOpts, eraOpts, opts); err != nil {
+		return nil, err
+	}
+
+	return &timeline, nil
+}
+
+// window returns the time range to look at, narrowed down by the cursor of the previous page.
+// Zero times leave the range open on that side.
+func (rc *TimelineUC) window(opts *model.TimelineFindOpts) (time.Time, time.Time, error) {
+	from, err := parseTimelineTime(opts.From)
+	if err != nil {
+		return time.Time{}, time.Time{}, pkg.NewError(err, "failed to parse from", http.StatusBadRequest)
+	}
+
+	to, err := parseTimelineTime(opts.To)
+	if err != nil {
+		return time.Time{}, time.Time{}, pkg.NewError(err, "failed to parse to", http.StatusBadRequest)
+	}
+
+	if opts.Cursor == "" {
+		return from, to, nil
+	}
+
+	cursor, err := time.Parse(time.RFC3339Nano, opts.Cursor)
+	if err != nil {
+		return time.Time{}, time.Time{}, pkg.NewError(err, "invalid cursor", http.StatusBadRequest)
+	}
+
+	// the cursor is the start of the last bucket when going back in time,
+	// and the start of the following bucket when going forward
+	if opts.Ascending {
+		if cursor.After(from) {
+			from = cursor
+		}
+
+		return from, to, nil
+	}
+
+	// postgres keeps microseconds, step back one to exclude the cursor itself
+	cursor = cursor.Add(-time.Microsecond)
+	if to.IsZero() || cursor.Before(to) {
+		to = cursor
+	}
+
+	return from, to, nil
+}
+
+func (rc *TimelineUC) mergeCounts(eventCounts, eraCounts []model.PeriodCount, opts *model.TimelineFindOpts) []model.TimelineBucket {
+	byStart := make(map[int64]*model.TimelineBucket)
+
+	bucketOf := func(start time.Time) *model.TimelineBucket {
+		bucket, ok := byStart[start.UnixNano()]
+		if ok {
+			return bucket
+		}
+
+		end := start.AddDate(1, 0, 0)
+		period := start.Format("2006")
+		if opts.GroupBy == model.TimelinePeriodMonth {
+			end = start.AddDate(0, 1, 0)
+			period = start.Format("2006-01")
+		}
+
+		bucket = &model.TimelineBucket{
+			Start:  start,
+			End:    end,
+			Period: period,
+		}
+		byStart[start.UnixNano()] = bucket
+
+		return bucket
+	}
+
+	for _, v := range eventCounts {
+		bucketOf(v.Period).EventCount += v.Count
+	}
+
+	for _, v := range eraCounts {
+		bucketOf(v.Period).EraCount += v.Count
+	}
+
+	buckets := make([]model.TimelineBucket, 0, len(byStart))
+	for _, v := range byStart {
+		buckets = append(buckets, *v)
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		if opts.Ascending {
+			return buckets[i].Start.Before(buckets[j].Start)
+		}
+
+		return buckets[i].Start.After(buckets[j].Start)
+	})
+
+	return buckets
+}
+
+// paginate takes buckets until the limit is reached or the page would hold too many items to load at once.
+func (rc *TimelineUC) paginate(buckets []model.TimelineBucket, limit int) ([]model.TimelineBucket, bool) {
+	items := 0
+	for i, v := range buckets {
+		if i == limit {
+			return buckets[:i], true
+		}
+
+		items += v.EventCount + v.EraCount
+		if i > 0 && items > maxTimelineItems {
+			return buckets[:i], true
+		}
+	}
+
+	return buckets, false
+}
+
+// fillItems loads the events and eras of the page and puts them into their buckets.
+func (rc *TimelineUC) fillItems(ctx context.Context, timeline *model.Timeline, eventOpts model.EventFindOpts, eraOpts model.EraFindOpts, opts *model.TimelineFindOpts) error {
+	buckets := timeline.Buckets
+
+	pageStart := buckets[len(buckets)-1].Start
+	pageEnd := buckets[0].End
+	if opts.Ascending {
+		pageStart = buckets[0].Start
+		pageEnd = buckets[len(buckets)-1].End
+	}
+	pageEnd = pageEnd.Add(-time.Microsecond)
+
+	order := "desc"
+	if opts.Ascending {
+		order = "asc"
+	}
+
+	eventOpts.Date = rangeFilter(pageStart, pageEnd)
+	eventOpts.OrderByOpts = model.OrderByOpts{Column: "date", OrderBy: order, IsSended: true}
+	eventOpts.PaginationOpts = model.PaginationOpts{Limit: maxTimelineItems}
+
+	events, err := rc.eventRepo.List(ctx, &eventOpts)
+	if err != nil {
+		return err
+	}
+
+	eraOpts.TimeStart = rangeFilter(pageStart, pageEnd)
+	eraOpts.OrderByOpts = model.OrderByOpts{Column: "era.time_start", OrderBy: order, IsSended: true}
+	eraOpts.PaginationOpts = model.PaginationOpts{Limit: maxTimelineItems}
+
+	eras, err := rc.eraRepo.List(ctx, &eraOpts)
+	if err != nil {
+		return err
+	}
+
+	for i := range buckets {
+		bucket := &buckets[i]
+
+		for _, v := range events.Events {
+			if inBucket(bucket, v.Date) {
+				bucket.Events = append(bucket.Events, v)
+			}
+		}
+
+		for _, v := range eras.Eras {
+			if inBucket(bucket, v.TimeStart) {
+				bucket.Eras = append(bucket.Eras, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+func inBucket(bucket *model.TimelineBucket, t time.Time) bool {
+	return !t.Before(bucket.Start) && t.Before(bucket.End)
+}
+
+// rangeFilter builds an inclusive between filter, zero times leave the range open on that side
+func rangeFilter(from, to time.Time) model.Filter {
+	if from.IsZero() && to.IsZero() {
+		return model.Filter{}
+	}
+
+	value := ","
+	if !from.IsZero() {
+		value = from.Format(time.RFC3339Nano) + value
+	}
+	if !to.IsZero() {
+		value += to.Format(time.RFC3339Nano)
+	}
+
+	return model.Filter{
+		Value:    value,
+		Operand:  model.OperandBetween,
+		IsSended: true,
+	}
+}
+
+// parseTimelineTime accepts RFC3339 times and plain dates, an empty value is the zero time
+func parseTimelineTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	return util.ParseTime(value)
+}
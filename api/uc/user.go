@@ -65,7 +65,7 @@ func (rc *UserUC) Create(ctx context.Context, req model.UserCreateInput) (*model
 
 func (rc *UserUC) Update(ctx context.Context, userID string, req model.UserCreateInput) (*model.User, error) {
 	// user exist control
-	_, err := rc.GetByID(ctx, userID)
+	exist, err := rc.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -75,10 +75,14 @@ func (rc *UserUC) Update(ctx context.Context, userID string, req model.UserCreat
 	}
 
 	user := model.User{
-		Username: req.Username,
-		Email:    req.Email,
-		Password: req.Password,
-		AuthType: model.AuthType(req.AuthType),
+		Username:        req.Username,
+		Email:           req.Email,
+		Password:        req.Password,
+		AuthType:        model.AuthType(req.AuthType),
+		Timezone:        exist.Timezone,
+		DigestFrequency: exist.DigestFrequency,
+		DigestSentAt:    exist.DigestSentAt,
+		Language:        exist.Language,
 	}
 
 	hashedPassword, err := model.HashPassword(req.Password)
@@ -99,6 +103,18 @@ func (rc *UserUC) List(ctx context.Context, opts *model.UserFindOpts) (*model.Us
 	return rc.userRepo.List(ctx, opts)
 }
 
+// Search lists the users matching opts, hiding the ones who blocked or are blocked by the owner.
+func (rc *UserUC) Search(ctx context.Context, opts *model.UserFindOpts) (*model.UserList, error) {
+	if ownerID := util.GetOwnerIDFromCtx(ctx); ownerID != "" {
+		opts.VisibleTo = model.Filter{
+			Value:    ownerID,
+			IsSended: true,
+		}
+	}
+
+	return rc.userRepo.List(ctx, opts)
+}
+
 func (rc *UserUC) GetByID(ctx context.Context, id string) (*model.User, error) {
 	return rc.userRepo.GetByID(ctx, id)
 }
@@ -182,3 +198,68 @@ func (rc *UserUC) UpdatePasswordWithCurrent(ctx context.Context, currentPassword
 	// Update password
 	return rc.UpdatePassword(ctx, userID, newPassword)
 }
+
+func (rc *UserUC) GetPreferences(ctx context.Context) (*model.UserPreferences, error) {
+	userID := util.GetOwnerIDFromCtx(ctx)
+	if userID == "" {
+		return nil, pkg.NewError(nil, "User not authenticated", http.StatusUnauthorized)
+	}
+
+	user, err := rc.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := model.UserPreferences{
+		Timezone:        user.Timezone,
+		DigestFrequency: user.DigestFrequency,
+		Language:        user.LanguageOrDefault(),
+	}
+
+	if prefs.Timezone == "" {
+		prefs.Timezone = time.UTC.String()
+	}
+
+	if prefs.DigestFrequency == "" {
+		prefs.DigestFrequency = model.DigestFrequencyNone
+	}
+
+	return &prefs, nil
+}
+
+func (rc *UserUC) UpdatePreferences(ctx context.Context, req *model.UpdatePreferencesRequest) error {
+	userID := util.GetOwnerIDFromCtx(ctx)
+	if userID == "" {
+		return pkg.NewError(nil, "User not authenticated", http.StatusUnauthorized)
+	}
+
+	// timezone has to be an IANA name like Europe/Istanbul, LoadLocation takes "" and "Local" for the zones of the server
+	if req.Timezone == "" || req.Timezone == "Local" {
+		return pkg.NewError(nil, "Unknown timezone "+req.Timezone, http.StatusBadRequest)
+	}
+
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return pkg.NewError(err, "Unknown timezone "+req.Timezone, http.StatusBadRequest)
+	}
+
+	prefs := model.UserPreferences{
+		Timezone:        req.Timezone,
+		DigestFrequency: req.DigestFrequency,
+		Language:        req.Language,
+	}
+
+	if prefs.Language == "" {
+		user, err := rc.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		prefs.Language = user.Language
+	}
+
+	return rc.userRepo.UpdatePreferences(ctx, userID, &prefs)
+}
+
+func (rc *UserUC) UpdateDigestSentAt(ctx context.Context, userID string, sentAt time.Time) error {
+	return rc.userRepo.UpdateDigestSentAt(ctx, userID, sentAt)
+}
@@ -0,0 +1,321 @@
+package uc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fleimkeipa/lifery/model"
+	"github.com/fleimkeipa/lifery/pkg"
+	"github.com/fleimkeipa/lifery/repositories/interfaces"
+	"github.com/fleimkeipa/lifery/util"
+)
+
+const (
+	// maxWebhooks is how many webhooks a user can register
+	maxWebhooks = 10
+	// webhookSecretPrefix marks signing secrets so users can tell them apart from other credentials
+	webhookSecretPrefix = "whsec_"
+	// webhookBatchSize is the number of due deliveries claimed at once
+	webhookBatchSize = 50
+	// webhookLease is how long claimed deliveries are skipped by other instances, longer than a batch takes
+	webhookLease = 15 * time.Minute
+	// webhookMaxAttempts is after how many failed attempts a delivery is given up
+	webhookMaxAttempts = 8
+	// webhookBaseBackoff doubles after each failed attempt up to webhookMaxBackoff
+	webhookBaseBackoff = time.Minute
+	webhookMaxBackoff  = 12 * time.Hour
+)
+
+// webhook request headers, the signature is sha256=hex(hmac(secret, timestamp + "." + body))
+const (
+	webhookEventHeader     = "X-Lifery-Event"
+	webhookDeliveryHeader  = "X-Lifery-Delivery"
+	webhookTimestampHeader = "X-Lifery-Timestamp"
+	webhookSignatureHeader = "X-Lifery-Signature"
+)
+
+type WebhookUC struct {
+	repo   interfaces.WebhookRepository
+	sender interfaces.WebhookSender
+}
+
+func NewWebhookUC(repo interfaces.WebhookRepository, sender interfaces.WebhookSender) *WebhookUC {
+	return &WebhookUC{
+		repo:   repo,
+		sender: sender,
+	}
+}
+
+// Create registers a webhook of the owner, its signing secret is returned only here.
+func (rc *WebhookUC) Create(ctx context.Context, req *model.WebhookCreateInput) (*model.WebhookCreated, error) {
+	if err := checkWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	ownerID := util.GetOwnerIDFromCtx(ctx)
+
+	webhooks, err := rc.repo.ListByUser(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) >= maxWebhooks {
+		return nil, pkg.NewError(nil, fmt.Sprintf("you can register up to %d webhooks", maxWebhooks), http.StatusBadRequest)
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := rc.repo.Create(ctx, &model.Webhook{
+		CreatedAt: time.Now(),
+		UserID:    ownerID,
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    slices.Compact(slices.Sorted(slices.Values(req.Events))),
+		Enabled:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WebhookCreated{
+		Webhook: *webhook,
+		Secret:  webhook.Secret,
+	}, nil
+}
+
+// Update changes the url or events of a webhook of the owner. Enabling a disabled webhook resets its failures,
+// its pending deliveries are sent again.
+func (rc *WebhookUC) Update(ctx context.Context, id string, req *model.WebhookUpdateInput) (*model.Webhook, error) {
+	webhook, err := rc.repo.GetByID(ctx, util.GetOwnerIDFromCtx(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != "" {
+		if err := checkWebhookURL(req.URL); err != nil {
+			return nil, err
+		}
+
+		webhook.URL = req.URL
+	}
+
+	if len(req.Events) != 0 {
+		webhook.Events = slices.Compact(slices.Sorted(slices.Values(req.Events)))
+	}
+
+	if req.Enabled != nil && *req.Enabled != webhook.Enabled {
+		webhook.Enabled = *req.Enabled
+		webhook.FailureCount = 0
+		webhook.DisabledAt = time.Time{}
+	}
+
+	return rc.repo.Update(ctx, webhook)
+}
+
+func (rc *WebhookUC) Delete(ctx context.Context, id string) error {
+	return rc.repo.Delete(ctx, util.GetOwnerIDFromCtx(ctx), id)
+}
+
+// List returns the webhooks of the owner.
+func (rc *WebhookUC) List(ctx context.Context) ([]model.Webhook, error) {
+	return rc.repo.ListByUser(ctx, util.GetOwnerIDFromCtx(ctx))
+}
+
+// Deliveries returns the delivery log of a webhook of the owner.
+func (rc *WebhookUC) Deliveries(ctx context.Context, id string, opts model.PaginationOpts) (*model.WebhookDeliveryList, error) {
+	if _, err := rc.repo.GetByID(ctx, util.GetOwnerIDFromCtx(ctx), id); err != nil {
+		return nil, err
+	}
+
+	return rc.repo.ListDeliveries(ctx, id, opts)
+}
+
+// Redeliver sends a finished delivery of a webhook of the owner again, it is sent with the next dispatch.
+func (rc *WebhookUC) Redeliver(ctx context.Context, id, deliveryID string) (*model.WebhookDelivery, error) {
+	if _, err := rc.repo.GetByID(ctx, util.GetOwnerIDFromCtx(ctx), id); err != nil {
+		return nil, err
+	}
+
+	if _, err := rc.repo.GetDelivery(ctx, id, deliveryID); err != nil {
+		return nil, err
+	}
+
+	return rc.repo.Redeliver(ctx, id, deliveryID)
+}
+
+// RegisterOutboxHandlers makes the outbox queue the webhook deliveries of the domain events.
+func (rc *WebhookUC) RegisterOutboxHandlers(outboxUC *OutboxUC) {
+	outboxUC.Register("webhook", rc.enqueue, model.WebhookEventTypes...)
+}
+
+// Dispatch sends the due webhook deliveries, it is run periodically.
+// Failed deliveries are retried with exponential backoff and given up after webhookMaxAttempts.
+func (rc *WebhookUC) Dispatch(ctx context.Context) error {
+	deliveries, err := rc.repo.ClaimDueDeliveries(ctx, time.Now(), webhookLease, webhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range deliveries {
+		if err := rc.deliver(ctx, &deliveries[i]); err != nil {
+			errs = append(errs, fmt.Errorf("webhook delivery %s: %w", deliveries[i].ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// enqueue queues a delivery of the event to every enabled webhook of its users subscribed to its type, it is an outbox handler
+func (rc *WebhookUC) enqueue(ctx context.Context, event *model.DomainEvent) error {
+	userIDs, err := webhookRecipients(event)
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := rc.repo.ListSubscribed(ctx, userIDs, event.Type)
+	if err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(model.WebhookPayload{
+		CreatedAt:   event.CreatedAt,
+		ID:          event.ID,
+		Type:        event.Type,
+		AggregateID: event.AggregateID,
+		Data:        event.Payload,
+	})
+	if err != nil {
+		return pkg.NewError(err, "failed to marshal webhook payload", http.StatusInternalServerError)
+	}
+
+	now := time.Now()
+
+	deliveries := make([]model.WebhookDelivery, 0, len(webhooks))
+	for _, v := range webhooks {
+		deliveries = append(deliveries, model.WebhookDelivery{
+			CreatedAt:     now,
+			NextAttemptAt: now,
+			WebhookID:     v.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        model.WebhookDeliveryStatusPending,
+		})
+	}
+
+	return rc.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// deliver posts the delivery to its webhook signed with the secret of the webhook and records the attempt.
+// The returned error is about recording the attempt, failures of the endpoint are recorded on the delivery.
+func (rc *WebhookUC) deliver(ctx context.Context, delivery *model.WebhookDelivery) error {
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("User-Agent", "Lifery-Webhook")
+	header.Set(webhookEventHeader, string(delivery.EventType))
+	header.Set(webhookDeliveryHeader, delivery.ID)
+	header.Set(webhookTimestampHeader, timestamp)
+	header.Set(webhookSignatureHeader, signWebhook(delivery.Webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := rc.sender.Post(ctx, delivery.Webhook.URL, header, delivery.Payload)
+
+	delivery.Attempts++
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	delivery.LastError = ""
+
+	switch {
+	case err != nil:
+		delivery.LastError = errorText(err)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		delivery.ResponseStatus = resp.StatusCode
+		delivery.ResponseBody = resp.Body
+		delivery.LastError = fmt.Sprintf("endpoint returned status %d", resp.StatusCode)
+	default:
+		delivery.ResponseStatus = resp.StatusCode
+		delivery.ResponseBody = resp.Body
+		delivery.Status = model.WebhookDeliveryStatusSucceeded
+		delivery.DeliveredAt = now
+	}
+
+	if delivery.Status == model.WebhookDeliveryStatusPending {
+		if delivery.Attempts >= webhookMaxAttempts {
+			delivery.Status = model.WebhookDeliveryStatusFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(pkg.ExponentialBackoff(delivery.Attempts, webhookBaseBackoff, webhookMaxBackoff))
+		}
+	}
+
+	return rc.repo.RecordAttempt(ctx, delivery)
+}
+
+// webhookRecipients returns the users whose webhooks get the event,
+// the owner of events and eras and both sides of connects
+func webhookRecipients(event *model.DomainEvent) ([]string, error) {
+	var payload struct {
+		UserID     string `json:"user_id"`
+		SenderID   string `json:"sender_id"`
+		ReceiverID string `json:"receiver_id"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil, pkg.NewError(err, "invalid webhook event payload", http.StatusInternalServerError)
+	}
+
+	userIDs := make([]string, 0, 2)
+	for _, v := range []string{payload.UserID, payload.SenderID, payload.ReceiverID} {
+		if v != "" {
+			userIDs = append(userIDs, v)
+		}
+	}
+
+	return userIDs, nil
+}
+
+// signWebhook returns the signature header value of the body sent at the timestamp
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", pkg.NewError(err, "failed to generate webhook secret", http.StatusInternalServerError)
+	}
+
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
+
+func checkWebhookURL(url string) error {
+	if !strings.HasPrefix(url, "https://") {
+		return pkg.NewError(nil, "webhook url has to be https", http.StatusBadRequest)
+	}
+
+	return nil
+}
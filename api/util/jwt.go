@@ -210,6 +210,11 @@ func GetOwnerFromCtx(ctx context.Context) model.TokenOwner {
 	return model.TokenOwner{}
 }
 
+// WithOwner returns a copy of ctx carrying the owner, as the jwt middlewares set it
+func WithOwner(ctx context.Context, owner model.TokenOwner) context.Context {
+	return context.WithValue(ctx, "user", owner)
+}
+
 // GetOwnerIDFromCtx returns the owner id from the context string type
 func GetOwnerIDFromCtx(ctx context.Context) string {
 	owner, ok := ctx.Value("user").(model.TokenOwner)
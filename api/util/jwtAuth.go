@@ -1,7 +1,6 @@
 package util
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -83,7 +82,5 @@ func JWTAuthViewer(next echo.HandlerFunc) echo.HandlerFunc {
 func setOwnerOnCtx(c echo.Context) {
 	user, _ := GetOwnerFromToken(c)
 
-	ctx := context.WithValue(c.Request().Context(), "user", user)
-
-	c.SetRequest(c.Request().WithContext(ctx))
+	c.SetRequest(c.Request().WithContext(WithOwner(c.Request().Context(), user)))
 }